@@ -0,0 +1,550 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/uzhinskiy/extractor/modules/router"
+)
+
+// cli - command-line client of /api/v1, either of a running extractor (-server)
+// or in-process against Elasticsearch from the config file
+type cli struct {
+	base     string
+	hc       *http.Client
+	user     string
+	password string
+	output   string
+}
+
+const usageText = `Usage: extractor [-f main.yml] [-server URL] [-user NAME] [-o table|json] COMMAND
+
+Without a command the web server is started. Commands:
+  repos                                 list snapshot repositories
+  snapshots REPO                        list snapshots of a repository, -name, -state, -pattern
+                                        and -from/-to filter them, -sort and -order sort them
+  restore REPO SNAPSHOT -indices a,b    restore indices, -wait waits for the recovery
+                                        globs, -exclusions and -from/-to dates select indices
+                                        -priority N moves the job ahead in the restore queue
+  snapshot REPO -indices a,b            create a snapshot, -name, -reason and -global-state are optional
+  delete-snapshot REPO SNAPSHOT         delete a snapshot
+  find-index PATTERN                    find snapshots containing matching indices, -repos a,b limits the search
+  indices [-pattern extracted*]         list restored indices and their recovery
+  delete INDEX                          delete an index
+  cancel JOB                            cancel a queued or running restore job
+
+Without -server the commands talk to Elasticsearch from the config file directly,
+-user is then taken on trust and only selects the rbac roles.
+The password for -server is read from EXTRACTOR_PASSWORD.
+`
+
+func usage() {
+	fmt.Fprint(flag.CommandLine.Output(), usageText)
+	flag.PrintDefaults()
+}
+
+func newCli() (*cli, error) {
+	c := &cli{
+		base:     strings.TrimSuffix(server, "/") + "/api/v1/",
+		user:     cliUser,
+		password: os.Getenv("EXTRACTOR_PASSWORD"),
+		output:   output,
+		hc:       &http.Client{Timeout: time.Duration(cnf.App.TimeOut) * time.Second},
+	}
+	if server == "" {
+		c.base = "http://local/api/v1/"
+		name := cliUser
+		if name == "" {
+			name = "cli"
+			if u, err := user.Current(); err == nil {
+				name = "cli:" + u.Username
+			}
+		}
+		var err error
+		c.hc.Transport, err = router.Local(cnf, name)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// call makes the request and decodes the answer into out, errors come in the {"error", "status"} envelope
+func (c *cli) call(method, path string, body interface{}, out interface{}) ([]byte, error) {
+	var rd io.Reader
+	if body != nil {
+		j, _ := json.Marshal(body)
+		rd = bytes.NewReader(j)
+	}
+	req, err := http.NewRequest(method, c.base+path, rd)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.user != "" && server != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	resp, err := c.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		var e struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(raw, &e) == nil && e.Error != "" {
+			return nil, errors.New(e.Error)
+		}
+		return nil, errors.New(resp.Status)
+	}
+	if out != nil {
+		err = json.Unmarshal(raw, out)
+	}
+	return raw, err
+}
+
+// print writes raw JSON with -o json, otherwise the table
+func (c *cli) print(raw []byte, header []string, rows [][]string) {
+	if c.output == "json" {
+		var b bytes.Buffer
+		if json.Indent(&b, raw, "", "  ") != nil {
+			b.Write(raw)
+		}
+		fmt.Println(b.String())
+		return
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, r := range rows {
+		fmt.Fprintln(tw, strings.Join(r, "\t"))
+	}
+	tw.Flush()
+}
+
+// parseArgs parses flags mixed with positional arguments: restore repo snap -indices a,b
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return pos, nil
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+}
+
+// runCommand executes a subcommand and returns the exit code
+func runCommand(args []string) int {
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	indices := fs.String("indices", "", "Comma separated indices, globs and -exclusions to restore")
+	from := fs.String("from", "", "Restore date-suffixed indices or list snapshots from this day, 2006-01-02")
+	to := fs.String("to", "", "Restore date-suffixed indices or list snapshots up to this day, 2006-01-02")
+	wait := fs.Bool("wait", false, "Wait until the restore job is finished")
+	timeout := fs.Duration("timeout", 0, "Give up waiting after this time")
+	pattern := fs.String("pattern", "", "Index pattern, restored indices by default; for snapshots an index they contain")
+	priority := fs.Int("priority", 0, "Priority in the restore queue, higher starts first")
+	name := fs.String("name", "", "Name of the created snapshot, snapshots.name of the config by default; for snapshots a name pattern")
+	state := fs.String("state", "", "List snapshots in this state: SUCCESS, PARTIAL, FAILED, IN_PROGRESS")
+	sortBy := fs.String("sort", "", "Sort snapshots by start_time, name, duration, index_count or shard_count")
+	order := fs.String("order", "", "Sort order of snapshots, asc or desc")
+	repos := fs.String("repos", "", "Comma separated repositories to search, all by default")
+	reason := fs.String("reason", "", "Reason kept in the metadata of the created snapshot")
+	global := fs.Bool("global-state", false, "Include the cluster state into the created snapshot")
+
+	pos, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return 2
+	}
+
+	need := map[string]int{"repos": 0, "snapshots": 1, "restore": 2, "indices": 0, "delete": 1, "cancel": 1, "snapshot": 1, "delete-snapshot": 2, "find-index": 1}
+	n, ok := need[args[0]]
+	if !ok || len(pos) != n {
+		usage()
+		return 2
+	}
+
+	c, err := newCli()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "extractor:", err)
+		return 1
+	}
+	switch args[0] {
+	case "repos":
+		err = c.repos()
+	case "snapshots":
+		q := url.Values{}
+		for k, v := range map[string]string{"name": *name, "state": *state, "index": *pattern, "from": *from, "to": *to, "sort": *sortBy, "order": *order} {
+			if v != "" {
+				q.Set(k, v)
+			}
+		}
+		err = c.snapshots(pos[0], q)
+	case "restore":
+		if *indices == "" && *from == "" && *to == "" {
+			err = errors.New("-indices or -from/-to are required")
+			break
+		}
+		var list []string
+		if *indices != "" {
+			list = strings.Split(*indices, ",")
+		}
+		err = c.restore(pos[0], pos[1], list, *from, *to, *priority, *wait, *timeout)
+	case "snapshot":
+		if *indices == "" {
+			err = errors.New("-indices is required")
+			break
+		}
+		err = c.snapshot(pos[0], *name, strings.Split(*indices, ","), *reason, *global)
+	case "delete-snapshot":
+		err = c.deleteSnapshot(pos[0], pos[1])
+	case "find-index":
+		err = c.findIndex(pos[0], *repos)
+	case "indices":
+		err = c.indices(*pattern)
+	case "delete":
+		err = c.delete(pos[0])
+	case "cancel":
+		err = c.cancel(pos[0])
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "extractor:", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *cli) repos() error {
+	var list []map[string]string
+	raw, err := c.call("GET", "repositories", nil, &list)
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, r := range list {
+		rows = append(rows, []string{r["id"], r["type"]})
+	}
+	c.print(raw, []string{"REPOSITORY", "TYPE"}, rows)
+	return nil
+}
+
+// snapshots prints every page of the filtered snapshot list
+func (c *cli) snapshots(repo string, q url.Values) error {
+	var (
+		all struct {
+			Repo      string            `json:"repo"`
+			Snapshots []json.RawMessage `json:"snapshots"`
+			Total     int               `json:"total"`
+		}
+		rows [][]string
+	)
+	q.Set("limit", "1000")
+	for {
+		var page struct {
+			Snapshots []json.RawMessage `json:"snapshots"`
+			Total     int               `json:"total"`
+			Next      string            `json:"next"`
+		}
+		_, err := c.call("GET", "repositories/"+url.PathEscape(repo)+"/snapshots?"+q.Encode(), nil, &page)
+		if err != nil {
+			return err
+		}
+		for _, raw := range page.Snapshots {
+			var s struct {
+				Snapshot    string    `json:"snapshot"`
+				State       string    `json:"state"`
+				Start       time.Time `json:"start_time"`
+				Duration    int64     `json:"duration_in_millis"`
+				Indices     []string  `json:"indices"`
+				ShardsTotal int       `json:"shards_total"`
+			}
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return err
+			}
+			rows = append(rows, []string{s.Snapshot, s.State, s.Start.Local().Format("2006-01-02 15:04:05"),
+				(time.Duration(s.Duration) * time.Millisecond).String(), fmt.Sprint(len(s.Indices)), fmt.Sprint(s.ShardsTotal)})
+		}
+		all.Snapshots = append(all.Snapshots, page.Snapshots...)
+		all.Total = page.Total
+		if page.Next == "" {
+			break
+		}
+		q.Set("after", page.Next)
+	}
+	all.Repo = repo
+	if all.Snapshots == nil {
+		all.Snapshots = []json.RawMessage{}
+	}
+	raw, _ := json.Marshal(all)
+	c.print(raw, []string{"SNAPSHOT", "STATE", "START", "DURATION", "INDICES", "SHARDS"}, rows)
+	return nil
+}
+
+func (c *cli) findIndex(pattern, repos string) error {
+	var res struct {
+		Found []struct {
+			Repo     string    `json:"repo"`
+			Snapshot string    `json:"snapshot"`
+			Index    string    `json:"index"`
+			State    string    `json:"state"`
+			Start    time.Time `json:"start_time"`
+			Size     *int64    `json:"size_in_bytes"`
+			Shards   *int      `json:"shards"`
+		} `json:"found"`
+		Total     int               `json:"total"`
+		Truncated bool              `json:"truncated"`
+		Errors    map[string]string `json:"errors"`
+	}
+	q := url.Values{"index": {pattern}, "limit": {"1000"}}
+	if repos != "" {
+		q.Set("repos", repos)
+	}
+	raw, err := c.call("GET", "snapshot-indices?"+q.Encode(), nil, &res)
+	if err != nil {
+		return err
+	}
+	var rows [][]string
+	for _, f := range res.Found {
+		// размер неизвестен, пока _status снапшота не в каталоге
+		size, shards := "-", "-"
+		if f.Size != nil && f.Shards != nil {
+			size, shards = fmt.Sprint(*f.Size), fmt.Sprint(*f.Shards)
+		}
+		rows = append(rows, []string{f.Repo, f.Snapshot, f.Index, f.State, f.Start.Local().Format("2006-01-02 15:04:05"), size, shards})
+	}
+	c.print(raw, []string{"REPOSITORY", "SNAPSHOT", "INDEX", "STATE", "START", "SIZE", "SHARDS"}, rows)
+	for repo, e := range res.Errors {
+		fmt.Fprintln(os.Stderr, "extractor:", repo+":", e)
+	}
+	if res.Truncated {
+		fmt.Fprintln(os.Stderr, "extractor: shown", len(res.Found), "of", res.Total)
+	}
+	return nil
+}
+
+type cliJob struct {
+	ID       string            `json:"id"`
+	State    string            `json:"state"`
+	Error    string            `json:"error"`
+	Position int               `json:"position"`
+	Percent  float64           `json:"percent"`
+	Targets  map[string]string `json:"targets"`
+	Progress map[string]struct {
+		Percent float64 `json:"percent"`
+		Error   string  `json:"error"`
+	} `json:"progress"`
+}
+
+func (c *cli) restore(repo, snapshot string, indices []string, from, to string, priority int, wait bool, timeout time.Duration) error {
+	var res struct {
+		JobID     string            `json:"job_id"`
+		Position  int               `json:"position"`
+		Targets   map[string]string `json:"targets"`
+		Rejected  map[string]string `json:"rejected"`
+		Conflicts map[string]struct {
+			Existing string `json:"existing"`
+			Strategy string `json:"strategy"`
+		} `json:"conflicts"`
+	}
+	raw, err := c.call("POST", "repositories/"+url.PathEscape(repo)+"/snapshots/"+url.PathEscape(snapshot)+"/restore",
+		map[string]interface{}{"indices": indices, "from": from, "to": to, "priority": priority}, &res)
+	if err != nil {
+		return err
+	}
+	if !wait {
+		state := "restoring"
+		if res.Position > 0 {
+			state = fmt.Sprintf("queued, position %d", res.Position)
+		}
+		var rows [][]string
+		for _, i := range sortedKeys(res.Targets) {
+			rows = append(rows, []string{res.JobID, i, res.Targets[i], state})
+		}
+		for _, i := range sortedKeys(res.Rejected) {
+			rows = append(rows, []string{res.JobID, i, "", "rejected: " + res.Rejected[i]})
+		}
+		c.print(raw, []string{"JOB", "INDEX", "TARGET", "STATE"}, rows)
+		return nil
+	}
+
+	// ждём завершения, прогресс пишем в stderr
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		deadline = time.After(timeout)
+	}
+	for {
+		var job cliJob
+		raw, err = c.call("GET", "jobs/"+res.JobID, nil, &job)
+		if err != nil {
+			return err
+		}
+		if job.State != "running" && job.State != "queued" {
+			var rows [][]string
+			for _, i := range sortedKeys(job.Targets) {
+				// прогресс ключуется именем восстановленного индекса
+				p := job.Progress[job.Targets[i]]
+				if job.State == "done" {
+					p.Percent = 100
+				}
+				rows = append(rows, []string{job.ID, i, job.Targets[i], fmt.Sprintf("%.1f%%", p.Percent), p.Error})
+			}
+			c.print(raw, []string{"JOB", "INDEX", "TARGET", "DONE", "ERROR"}, rows)
+			if job.State != "done" {
+				return errors.New("restore job " + job.ID + " " + job.State + ": " + job.Error)
+			}
+			return nil
+		}
+		if job.State == "queued" {
+			fmt.Fprintf(os.Stderr, "job %s: queued, position %d\n", job.ID, job.Position)
+		} else {
+			fmt.Fprintf(os.Stderr, "job %s: %.1f%%\n", job.ID, job.Percent)
+		}
+		select {
+		case <-deadline:
+			return errors.New("timed out waiting for restore job " + job.ID)
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func (c *cli) indices(pattern string) error {
+	var recovery map[string]struct {
+		Shards []struct {
+			Stage  string `json:"stage"`
+			Source struct {
+				Repository string `json:"repository"`
+				Snapshot   string `json:"snapshot"`
+			} `json:"source"`
+			Index struct {
+				Size struct {
+					Total     int64 `json:"total_in_bytes"`
+					Recovered int64 `json:"recovered_in_bytes"`
+				} `json:"size"`
+			} `json:"index"`
+		} `json:"shards"`
+	}
+	path := "indices"
+	if pattern != "" {
+		path += "?pattern=" + url.QueryEscape(pattern)
+	}
+	raw, err := c.call("GET", path, nil, &recovery)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for n := range recovery {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	var rows [][]string
+	for _, n := range names {
+		var total, recovered int64
+		done, source := 0, ""
+		for _, s := range recovery[n].Shards {
+			total += s.Index.Size.Total
+			recovered += s.Index.Size.Recovered
+			if s.Stage == "DONE" {
+				done++
+			}
+			if s.Source.Snapshot != "" {
+				source = s.Source.Repository + "/" + s.Source.Snapshot
+			}
+		}
+		percent := 100.0
+		if total > 0 {
+			percent = float64(recovered) * 100 / float64(total)
+		}
+		rows = append(rows, []string{n, fmt.Sprintf("%d/%d", done, len(recovery[n].Shards)), fmt.Sprintf("%.1f%%", percent), source})
+	}
+	c.print(raw, []string{"INDEX", "SHARDS DONE", "RECOVERED", "SOURCE"}, rows)
+	return nil
+}
+
+func (c *cli) delete(index string) error {
+	raw, err := c.call("DELETE", "indices/"+url.PathEscape(index), nil, nil)
+	if err != nil {
+		return err
+	}
+	c.print(raw, []string{"INDEX", "STATE"}, [][]string{{index, "deleted"}})
+	return nil
+}
+
+func (c *cli) snapshot(repo, name string, indices []string, reason string, global bool) error {
+	var res struct {
+		Snapshot string   `json:"snapshot"`
+		State    string   `json:"state"`
+		Indices  []string `json:"indices"`
+		Percent  float64  `json:"percent"`
+	}
+	body := map[string]interface{}{"snapshot": name, "indices": indices, "reason": reason, "include_global_state": global}
+	raw, err := c.call("POST", "repositories/"+url.PathEscape(repo)+"/snapshots", body, &res)
+	if err != nil {
+		return err
+	}
+	c.print(raw, []string{"SNAPSHOT", "STATE", "INDICES", "DONE"}, [][]string{{res.Snapshot, res.State, fmt.Sprint(len(res.Indices)), fmt.Sprintf("%.0f%%", res.Percent)}})
+	return nil
+}
+
+func (c *cli) deleteSnapshot(repo, snapshot string) error {
+	raw, err := c.call("DELETE", "repositories/"+url.PathEscape(repo)+"/snapshots/"+url.PathEscape(snapshot), nil, nil)
+	if err != nil {
+		return err
+	}
+	c.print(raw, []string{"SNAPSHOT", "STATE"}, [][]string{{snapshot, "deleted"}})
+	return nil
+}
+
+func (c *cli) cancel(id string) error {
+	var job cliJob
+	raw, err := c.call("POST", "jobs/"+url.PathEscape(id)+"/cancel", nil, &job)
+	if err != nil {
+		return err
+	}
+	c.print(raw, []string{"JOB", "STATE", "ERROR"}, [][]string{{job.ID, job.State, job.Error}})
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	var res []string
+	for k := range m {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
@@ -15,6 +15,8 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"log"
 	"os"
 
@@ -28,18 +30,42 @@ var (
 	vBuild     string
 	cnf        config.Config
 	hostname   string
+	// клиентский режим
+	server  string
+	cliUser string
+	output  string
 )
 
 func init() {
 	flag.StringVar(&configfile, "config", "main.yml", "Read configuration from this file")
 	flag.StringVar(&configfile, "f", "main.yml", "Read configuration from this file")
 	vers := flag.Bool("V", false, "Show version")
+	flag.StringVar(&server, "server", "", "URL of a running extractor for commands, Elasticsearch from the config is used without it")
+	flag.StringVar(&cliUser, "user", "", "User name for commands")
+	flag.StringVar(&output, "o", "table", "Output of commands: table or json")
+	flag.Usage = usage
 	flag.Parse()
 	if *vers {
 		print("version: ", version.Version, "( ", vBuild, " )\n")
 		os.Exit(0)
 	}
 
+	if flag.NArg() > 0 {
+		// журнал сервера не смешиваем с выводом команд, ошибки печатает сама команда
+		log.SetOutput(ioutil.Discard)
+		if server != "" {
+			cnf.App.TimeOut = 30
+			return
+		}
+		var err error
+		cnf, err = config.Load(configfile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "extractor:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	hostname, _ = os.Hostname()
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 	log.SetPrefix(hostname + "\tapi.version:" + version.Version + "\t")
@@ -51,5 +77,8 @@ func init() {
 }
 
 func main() {
+	if flag.NArg() > 0 {
+		os.Exit(runCommand(flag.Args()))
+	}
 	router.Run(cnf)
 }
@@ -1,5 +1,5 @@
 var getnodes = setInterval(NodeStatus, 5000);
-var getindices = setInterval(function(){IndexList("extracted*")}, 3000);
+var getindices = setInterval(function(){IndexList("")}, 3000);
 
 function bytesToSize(bytes) {
    var sizes = ['b', 'kb', 'mb', 'gb', 'tb'];
@@ -105,6 +105,31 @@ function NodeStatus() {
 }
 
 
+$(document).ajaxError(function(e, xhr) {
+    if (xhr.status == 401) {
+      window.location = "/";
+    }
+});
+
+function UserName() {
+    var post = {
+      "action": "get_user"
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        if (data && data.name) {
+          $('#username').text(data.name);
+        }
+      }
+    });
+}
+
 $(document).ready(function(){
     var post = {
       "action": "get_repositories"
@@ -141,8 +166,9 @@ $(document).ready(function(){
       }  
     });
 
+    UserName();
     NodeStatus();
-    IndexList("extracted*");
+    IndexList("");
 });
 
 $('#repolist').on('click', 'a.repos', function(e) {
@@ -151,11 +177,40 @@ $('#repolist').on('click', 'a.repos', function(e) {
     $("#loading").removeClass('invisible');
 
     $('#selectedsnap').html("from <strong>"+reponame+"</strong>");
+    $('#s_repo').val(reponame);
+    $('#snapshot_form').removeClass('d-none').data('repo', reponame);
+    $('#repo_actions').removeClass('d-none').data('repo', reponame);
+    $('#repo_info').addClass('d-none');
+    RepoChecks(reponame);
+    SnapshotList(reponame, "");
+});
+
+$('#snapshot_filter').on('submit', function(e) {
+    var repo = $('#snapshot_form').data('repo');
+    if (repo) {
+      SnapshotList(repo, "");
+    }
+    event.preventDefault();
+});
 
+$('#snapshot_more').on('click', function(e) {
+    SnapshotList($('#snapshot_form').data('repo'), $('#snapshot_more').data('next'));
+});
+
+// список снапшотов приходит страницами, after - next предыдущей страницы
+function SnapshotList(reponame, after) {
+    $("#loading").removeClass('invisible');
     var post = {
       "action": "get_snapshots",
       "values" : {
-        "repo": reponame
+        "repo": reponame,
+        "snapshot_pattern": $('#f_name').val(),
+        "state": $('#f_state').val(),
+        "ipattern": $('#f_index').val(),
+        "from": $('#f_from').val(),
+        "to": $('#f_to').val(),
+        "sort": $('#f_sort').val(),
+        "after": after
       }
     };
 
@@ -168,33 +223,297 @@ $('#repolist').on('click', 'a.repos', function(e) {
       success: function (data) {
         var str = "";
 
-        dlicon = '<svg width="1em" height="1em" viewBox="0 0 16 16" class="bi bi-download" fill="currentColor" xmlns="http://www.w3.org/2000/svg"><path fill-rule="evenodd" d="M.5 9.9a.5.5 0 0 1 .5.5v2.5a1 1 0 0 0 1 1h12a1 1 0 0 0 1-1v-2.5a.5.5 0 0 1 1 0v2.5a2 2 0 0 1-2 2H2a2 2 0 0 1-2-2v-2.5a.5.5 0 0 1 .5-.5z"/><path fill-rule="evenodd" d="M7.646 11.854a.5.5 0 0 0 .708 0l3-3a.5.5 0 0 0-.708-.708L8.5 10.293V1.5a.5.5 0 0 0-1 0v8.793L5.354 8.146a.5.5 0 1 0-.708.708l3 3z"/></svg>';
+        var dlicon = '<svg width="1em" height="1em" viewBox="0 0 16 16" class="bi bi-download" fill="currentColor" xmlns="http://www.w3.org/2000/svg"><path fill-rule="evenodd" d="M.5 9.9a.5.5 0 0 1 .5.5v2.5a1 1 0 0 0 1 1h12a1 1 0 0 0 1-1v-2.5a.5.5 0 0 1 1 0v2.5a2 2 0 0 1-2 2H2a2 2 0 0 1-2-2v-2.5a.5.5 0 0 1 .5-.5z"/><path fill-rule="evenodd" d="M7.646 11.854a.5.5 0 0 0 .708 0l3-3a.5.5 0 0 0-.708-.708L8.5 10.293V1.5a.5.5 0 0 0-1 0v8.793L5.354 8.146a.5.5 0 1 0-.708.708l3 3z"/></svg>';
 
-        for(var k in data) {
-          snapshot = data[k].id;
-          uniqid = data[k].start_epoch;
-          status = data[k].status;
-          date = new Date(data[k].start_epoch * 1000);
-          hdate = date.toLocaleString("ru-RU", {timeZoneName: "short"});
-          restore_button = "";
-
-          if (status == "SUCCESS") {
+        for(var k in data.snapshots) {
+          var s = data.snapshots[k];
+          var snapshot = s.snapshot;
+          var hdate = new Date(s.start_time).toLocaleString("ru-RU", {timeZoneName: "short"});
+          var icon, restore_button = "";
+
+          if (s.state == "SUCCESS") {
             icon = '<svg width="1em" height="1em" viewBox="0 0 16 16" class="bi bi-server text-success" fill="currentColor" xmlns="http://www.w3.org/2000/svg"><path fill-rule="evenodd" d="M1.333 2.667C1.333 1.194 4.318 0 8 0s6.667 1.194 6.667 2.667V4C14.665 5.474 11.68 6.667 8 6.667 4.318 6.667 1.333 5.473 1.333 4V2.667zm0 3.667v3C1.333 10.805 4.318 12 8 12c3.68 0 6.665-1.193 6.667-2.665V6.334c-.43.32-.931.58-1.458.79C11.81 7.684 9.967 8 8 8c-1.967 0-3.81-.317-5.21-.876a6.508 6.508 0 0 1-1.457-.79zm13.334 5.334c-.43.319-.931.578-1.458.789-1.4.56-3.242.876-5.209.876-1.967 0-3.81-.316-5.21-.876a6.51 6.51 0 0 1-1.457-.79v1.666C1.333 14.806 4.318 16 8 16s6.667-1.194 6.667-2.667v-1.665z"/></svg>';
-            restore_button = "<a href='#' class='float-right btn' title='X-tract it' data-target='#update_instance' data-toggle='modal' data-repo='" + reponame + "' data-id='" + data[k].id + "'>"+dlicon+"</a>";
+            restore_button = "<a href='#' class='float-right btn' title='X-tract it' data-target='#update_instance' data-toggle='modal' data-repo='" + reponame + "' data-id='" + snapshot + "'>"+dlicon+"</a>";
           } else {
             icon = '<svg width="1em" height="1em" viewBox="0 0 16 16" class="bi bi-server text-danger" fill="currentColor" xmlns="http://www.w3.org/2000/svg"><path fill-rule="evenodd" d="M1.333 2.667C1.333 1.194 4.318 0 8 0s6.667 1.194 6.667 2.667V4C14.665 5.474 11.68 6.667 8 6.667 4.318 6.667 1.333 5.473 1.333 4V2.667zm0 3.667v3C1.333 10.805 4.318 12 8 12c3.68 0 6.665-1.193 6.667-2.665V6.334c-.43.32-.931.58-1.458.79C11.81 7.684 9.967 8 8 8c-1.967 0-3.81-.317-5.21-.876a6.508 6.508 0 0 1-1.457-.79zm13.334 5.334c-.43.319-.931.578-1.458.789-1.4.56-3.242.876-5.209.876-1.967 0-3.81-.316-5.21-.876a6.51 6.51 0 0 1-1.457-.79v1.666C1.333 14.806 4.318 16 8 16s6.667-1.194 6.667-2.667v-1.665z"/></svg>';
-            restore_button = "";
           }
+          restore_button += "<a href='#' class='float-right btn text-danger del_snapshot' title='Delete the snapshot' data-repo='" + reponame + "' data-id='" + snapshot + "'>&times;</a>";
 
-          str += "<li><h5 class='font-weight-bold  list-group-item list-group-item-action' title='"+status+"'>"+icon+" <strong>" + snapshot + "</strong> created at " + hdate + restore_button +"</h5></li>";
+          var details = s.indices.length + " indices, " + s.shards_total + " shards, " + Math.round(s.duration_in_millis / 1000) + "s";
+          str += "<li><h5 class='font-weight-bold  list-group-item list-group-item-action' title='"+s.state+"'>"+icon+" <strong>" + snapshot + "</strong> created at " + hdate + " <small class='text-muted'>" + details + "</small>" + restore_button +"</h5></li>";
         }
 
         $("#loading").addClass('invisible');
-        $('#snapshotlist').html(str);
-    }
+        if (after) {
+          $('#snapshotlist').append(str);
+        } else {
+          $('#snapshotlist').html(str);
+        }
+        $('#snapshot_total').text(data.total ? $('#snapshotlist li').length + " of " + data.total : "");
+        $('#snapshot_more').data('next', data.next || "").toggleClass('d-none', !data.next);
+      },
+      error: function (data) {
+        $("#loading").addClass('invisible');
+        ResultAlert('alert-danger', data.responseJSON.error);
+      }
   });
+}
+
+
+$('#find_form').on('submit', function(e) {
+    var post = {
+      "action": "find_index",
+      "values" : {
+        "ipattern": $('#find_index').val()
+      }
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        var str = "";
+        for (var k in data.found) {
+          var f = data.found[k];
+          var restore = f.state == "SUCCESS" ? "<a href='#' title='X-tract it' data-target='#update_instance' data-toggle='modal' data-repo='" + f.repo + "' data-id='" + f.snapshot + "' data-index='" + f.index + "'>restore</a>" : "";
+          str += "<tr><td>" + f.repo + "</td><td>" + f.snapshot + "</td><td>" + f.index + "</td><td>" + ScheduleDate(f.start_time) + "</td><td>" + (f.size_in_bytes == null ? "?" : bytesToSize(f.size_in_bytes)) + "</td><td>" + (f.shards == null ? "?" : f.shards) + "</td><td>" + restore + "</td></tr>";
+        }
+        for (var repo in data.errors) {
+          str += "<tr class='text-danger'><td>" + repo + "</td><td colspan='6'>" + data.errors[repo] + "</td></tr>";
+        }
+        if (data.truncated) {
+          str += "<tr class='text-muted'><td colspan='7'>" + data.found.length + " of " + data.total + " shown</td></tr>";
+        }
+        if (str == "") {
+          str = "<tr class='text-muted'><td colspan='7'>Nothing found</td></tr>";
+        }
+        $('#found_indices tbody').html(str);
+        $('#found_indices').removeClass('d-none');
+      },
+      error: function (data) {
+        ResultAlert('alert-danger', data.responseJSON.error);
+      }
+    });
+    event.preventDefault();
+});
+
+function ResultAlert(cls, text) {
+  $("#result").html('<div class="alert '+cls+' alert-dismissible fade show">'+text+'<button type="button" class="close" data-dismiss="alert" aria-label="Close"><span aria-hidden="true">&times;</span></button></div>');
+}
+
+// проверки репозитория идут в фоне, список обновляется, пока есть незавершённые
+var checksTimer = null;
+
+function RepoChecks(repo) {
+    clearTimeout(checksTimer);
+    var post = {
+      "action": "list_repository_checks",
+      "values" : {
+        "repo": repo
+      }
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        var str = "";
+        var running = false;
+        var cls = {"passed": "text-success", "failed": "text-danger", "skipped": "text-muted"};
+        for (var k in data) {
+          var c = data[k];
+          running = running || c.state == "running";
+          var steps = c.steps.map(function (st) {
+            var title = st.error || (st.result ? JSON.stringify(st.result) : "");
+            return "<span class='" + (cls[st.state] || "") + "' title='" + title.replace(/'/g, "&#39;") + "'>" + st.name + (st.state ? ": " + st.state : "...") + "</span>";
+          });
+          str += "<tr><td>" + c.kind + "</td><td>" + ScheduleDate(c.started) + "</td><td class='" + (c.state == "failed" ? "text-danger" : "") + "'>" + c.state + "</td><td>" + steps.join(", ") + "</td></tr>";
+        }
+        $('#repo_checks tbody').html(str);
+        $('#repo_checks').toggleClass('d-none', data.length == 0);
+        if (running && $('#repo_actions').data('repo') == repo) {
+          checksTimer = setTimeout(function () { RepoChecks(repo); }, 2000);
+        }
+      }
+    });
+}
+
+$('#repo_actions').on('click', 'button.repo_action', function(e) {
+    var repo = $('#repo_actions').data('repo');
+    var action = e.currentTarget.dataset.action;
+    if (action == "delete_repository" && !confirm("Unregister " + repo + "? Its snapshots stay in the storage.")) {
+      return;
+    }
+    var post = {
+      "action": action,
+      "values" : {
+        "repo": repo
+      }
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        switch (action) {
+          case "get_repository":
+            $('#repo_info').text(data.type + " " + JSON.stringify(data.settings, null, 2)).removeClass('d-none');
+            break;
+          case "verify_repository":
+          case "analyze_repository":
+            RepoChecks(repo);
+            break;
+          case "delete_repository":
+            ResultAlert('alert-danger', repo + ' unregistered');
+            window.location.reload();
+            break;
+        }
+      },
+      error: function (data) {
+        ResultAlert('alert-danger', data.responseJSON.error);
+      }
+    });
+});
+
+$("#repo_create").click(function(){
+    var settings = {};
+    if ($('#p_settings').val().trim() != "") {
+      try {
+        settings = JSON.parse($('#p_settings').val());
+      } catch (err) {
+        $('#p_result').html('<div class="alert alert-danger">Settings: '+err.message+'</div>');
+        return;
+      }
+    }
+    if ($('#p_readonly').is(":checked")) {
+      settings.readonly = true;
+    }
+    var post = {
+      "action": "create_repository",
+      "values" : {
+        "repo": $('#p_name').val(),
+        "type": $('#p_type').val(),
+        "settings": settings
+      }
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        window.location.reload();
+      },
+      error: function (data) {
+        $('#p_result').html('<div class="alert alert-danger">'+data.responseJSON.error+'</div>');
+      }
+    });
+});
+
+// каталог снапшотов обновляется в фоне, кнопка - чтобы не ждать
+$('#catalog_refresh').on('click', function(e) {
+    var post = {
+      "action": "refresh_catalog"
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        ResultAlert('alert-success', 'Catalog refreshed: ' + data.repositories + ' repositories, ' + data.snapshots + ' snapshots in ' + data.refresh_duration_in_millis + ' ms');
+        var repo = $('#snapshot_form').data('repo');
+        if (repo) {
+          SnapshotList(repo, "");
+        }
+      },
+      error: function (data) {
+        ResultAlert('alert-danger', data.responseJSON.error);
+      }
+    });
+    event.preventDefault();
+});
+
+$('#repo_modal').on('hidden.bs.modal',function(){
+    $('#repo_form').trigger('reset');
+    $('#p_result').html('');
+});
+
+// снапшот создаётся в фоне, прогресс - из того же _status, что и у get_snapshot
+$("#snapshot_create").click(function(){
+    var repo = $('#snapshot_form').data('repo');
+    var post = {
+      "action": "create_snapshot",
+      "values" : {
+        "repo": repo,
+        "indices": $('#n_indices').val().split(",").map(function (s) { return s.trim(); }).filter(function (s) { return s != ""; }),
+        "reason": $('#n_reason').val(),
+        "include_global_state": $('#n_global').is(":checked")
+      }
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        ResultAlert('alert-success', 'Snapshot ' + data.snapshot + ' of ' + data.indices.length + ' indices: ' + data.state + ' ' + Math.round(data.percent) + '%');
+        $('#snapshot_form').trigger('reset');
+        $("#repolist a.repos[data-id='" + repo + "']").click();
+      },
+      error: function (data) {
+        ResultAlert('alert-danger', data.responseJSON.error);
+      }
+    });
+    event.preventDefault();
 });
 
+$('#snapshotlist').on('click', 'a.del_snapshot', function(e) {
+    var repo = e.currentTarget.dataset.repo;
+    var snapshot = e.currentTarget.dataset.id;
+    if (!confirm("Delete the snapshot " + snapshot + "?")) {
+      event.preventDefault();
+      return;
+    }
+    var post = {
+      "action": "delete_snapshot",
+      "values" : {
+        "repo": repo,
+        "snapshot": snapshot
+      }
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        ResultAlert('alert-danger', 'Snapshot ' + snapshot + ' deleted');
+        $("#repolist a.repos[data-id='" + repo + "']").click();
+      },
+      error: function (data) {
+        ResultAlert('alert-danger', data.responseJSON.error);
+      }
+    });
+    event.preventDefault();
+});
 
 $('#indlist').on('click', 'a.del_button', function(e) {
     console.log(e)
@@ -227,6 +546,8 @@ $('#indlist').on('click', 'a.del_button', function(e) {
 $('#update_instance').on('shown.bs.modal',function(e){
     var snapshot = $(e.relatedTarget).data('id');
     var repo = $(e.relatedTarget).data('repo');
+    // из результатов поиска выбран только найденный индекс
+    var only = $(e.relatedTarget).data('index');
     
     $('#indices').find('option')
     .remove()
@@ -251,27 +572,247 @@ $('#update_instance').on('shown.bs.modal',function(e){
         for (k in indices) {
           optText = k+" / "+bytesToSize(indices[k].stats.total.size_in_bytes);
           optValue = k;
-          $('#indices').append(new Option(optText, optValue,true,true));
+          $('#indices').append(new Option(optText, optValue, !only || k == only, !only || k == only));
         }
       }
     });
     
     $(r_repo).val(repo);
     $(r_snapshot).val(snapshot);
+    ResetPreview();
+    RestoreOptions();
     
 });
 
 
+// восстановление в два шага: сначала preview_restore, затем restore
+var previewed = false;
+
+function ResetPreview() {
+  previewed = false;
+  $('#preview').html('');
+  $('#restore').text('Preview');
+}
+
+function RestorePost(action) {
+  return {
+    "action": action,
+    "values" : {
+      "repo": $('#r_repo').val(),
+      "snapshot": $('#r_snapshot').val(),
+      "indices": $('#indices').val(),
+      "options": RestoreOptionsValues()
+    }
+  };
+}
+
+function ShowPreview(data) {
+  var html = '<table class="table table-sm"><thead><tr><th>Index</th><th>Restored as</th><th>Size</th></tr></thead><tbody>';
+  var sizes = {};
+  var conflicts = data.conflicts || {};
+  var replaced = [];
+  (data.placement.indices || []).forEach(function (v) { sizes[v.index] = v.size; });
+  (data.accepted || []).forEach(function (i) {
+    var c = conflicts[i];
+    var note = '';
+    if (c) {
+      note = ' (' + c.existing + ' exists, ' + c.strategy + ')';
+      if (c.strategy == "replace") {
+        replaced.push(c.existing);
+      }
+    }
+    html += '<tr' + (c ? ' class="table-warning"' : '') + '><td>' + i + '</td><td>' + data.targets[i] + note + '</td><td>' + bytesToSize(sizes[i]) + '</td></tr>';
+  });
+  for (var i in conflicts) {
+    if (conflicts[i].strategy == "skip") {
+      html += '<tr class="table-secondary"><td>' + i + '</td><td colspan="2">skipped, ' + conflicts[i].existing + ' exists</td></tr>';
+    }
+  }
+  for (var i in (data.rejected || {})) {
+    html += '<tr class="table-danger"><td>' + i + '</td><td colspan="2">' + data.rejected[i] + '</td></tr>';
+  }
+  html += '</tbody></table>';
+  html += '<p>Total: ' + bytesToSize(data.size_bytes);
+  if (data.eta_seconds) {
+    html += ', expected time: ' + Math.ceil(data.eta_seconds / 60) + ' min';
+  }
+  html += '</p>';
+  if (replaced.length > 0) {
+    html += '<div class="form-check"><input type="checkbox" class="form-check-input" id="o_confirm">';
+    html += '<label class="form-check-label" for="o_confirm">Delete ' + replaced.join(", ") + ' before the restore</label></div>';
+  }
+  $('#preview').html(html);
+}
+
+$('#indices, #update_form input, #o_conflict').on('change', ResetPreview);
+
 $("#restore").click(function(){
+    if (!previewed) {
+      $.ajax({
+        type: "POST",
+        url: "/api/",
+        data: JSON.stringify(RestorePost("preview_restore")),
+        dataType: 'json',
+        contentType: 'application/json',
+        success: function (data) {
+          ShowPreview(data);
+          if ((data.accepted || []).length > 0) {
+            previewed = true;
+            $('#restore').text('Restore');
+          }
+        },
+        error: function (data) {
+          $('#preview').html('<div class="alert alert-danger">'+data.responseJSON.error+'</div>');
+        }
+      });
+      event.preventDefault();
+      return;
+    }
+
+    // замена удаляет существующие индексы, нужно подтверждение
+    if ($('#o_confirm').length > 0 && !$('#o_confirm').is(":checked")) {
+      $('#o_confirm').addClass("is-invalid");
+      event.preventDefault();
+      return;
+    }
+    var post = RestorePost("restore");
+    post.values.options.confirm_replace = $('#o_confirm').is(":checked");
+
+    $('#update_instance').modal('hide');
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        // 207 - часть индексов не восстанавливается
+        var cls = $.isEmptyObject(data.rejected || {}) ? 'alert-success' : 'alert-warning';
+        $("#result").html('<div class="alert '+cls+' alert-dismissible fade show">'+data.message+'<button type="button" class="close" data-dismiss="alert" aria-label="Close"><span aria-hidden="true">&times;</span></button></div>');
+      },
+      error: function (data) {
+        $("#result").html('<div class="alert alert-danger alert-dismissible fade show">'+data.responseJSON.error+'<button type="button" class="close" data-dismiss="alert" aria-label="Close"><span aria-hidden="true">&times;</span></button></div>')
+      }
+    });
+    event.preventDefault();
+});
+
+// glob из настроек restore в регулярное выражение
+function globMatch(patterns, name) {
+  for (var i = 0; i < patterns.length; i++) {
+    var re = new RegExp("^" + patterns[i].replace(/[.+^${}()|\\]/g, "\\$&").replace(/\*/g, ".*").replace(/\?/g, ".") + "$");
+    if (re.test(name)) {
+      return true;
+    }
+  }
+  return false;
+}
+
+// показывает параметры восстановления, разрешённые администратором
+function RestoreOptions() {
+    var post = {
+      "action": "get_restore_options",
+      "values" : {}
+    };
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        $('#o_replicas').attr("max", data.max_replicas).val(data.replicas).prop("disabled", data.max_replicas == 0);
+        $('#o_refresh_group').toggleClass("d-none", !globMatch(data.settings || [], "index.refresh_interval"));
+        $('#o_ignore_group').toggleClass("d-none", (data.ignore_settings || []).length == 0);
+        $('#o_aliases_group').toggleClass("d-none", !data.allow_aliases);
+        $('#o_partial_group').toggleClass("d-none", !data.allow_partial);
+        $('#o_conflict').val(data.conflict);
+      }
+    });
+}
+
+function RestoreOptionsValues() {
+  var o = {
+    "replicas": parseInt($('#o_replicas').val() || "0", 10),
+    "include_aliases": $('#o_aliases').is(":checked"),
+    "partial": $('#o_partial').is(":checked"),
+    "conflict": $('#o_conflict').val()
+  };
+  if ($('#o_refresh').val() != "") {
+    o.index_settings = {"index.refresh_interval": $('#o_refresh').val()};
+  }
+  if ($('#o_ignore').val() != "") {
+    o.ignore_index_settings = $('#o_ignore').val().split(",").map(function (s) { return s.trim(); });
+  }
+  return o;
+}
+
+$('#update_instance').on('hidden.bs.modal',function(){
+	$('#update_form').trigger('reset');
+});
+
+
+// расписания восстановления
+function ScheduleDate(s) {
+  return s ? new Date(s).toLocaleString("ru-RU", {timeZoneName: "short"}) : "";
+}
+
+function ScheduleResult(cls, text) {
+  $("#s_result").html('<div class="alert '+cls+' alert-dismissible fade show">'+text+'<button type="button" class="close" data-dismiss="alert" aria-label="Close"><span aria-hidden="true">&times;</span></button></div>');
+}
+
+function ScheduleList() {
+    var post = {
+      "action": "list_schedules"
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        var str = "";
+        for (var k in data) {
+          var s = data[k];
+          var last = ScheduleDate(s.last_run);
+          if (s.last_error) {
+            last = "<span class='text-danger' title='" + s.last_error + "'>" + last + "</span>";
+          } else if (s.last_job) {
+            last = "<span class='text-success' title='" + s.last_job + "'>" + last + "</span>";
+          }
+          str += "<tr" + (s.enabled ? "" : " class='text-muted'") + "><td>" + s.name + "</td><td class='text-monospace'>" + s.cron + "</td>";
+          str += "<td>" + s.repo + " / " + (s.snapshot || s.snapshot_pattern) + "</td><td>" + s.indices.join(", ") + "</td>";
+          str += "<td>" + (s.enabled ? ScheduleDate(s.next) : "paused") + "</td><td>" + last + "</td>";
+          str += "<td class='text-nowrap'><a href='#' class='schedule_action' data-action='run_schedule' data-id='" + s.id + "' title='Run now'>&#9654;</a>&nbsp;";
+          str += "<a href='#' class='schedule_action' data-action='" + (s.enabled ? "pause" : "resume") + "' data-id='" + s.id + "' title='" + (s.enabled ? "Pause" : "Resume") + "'>" + (s.enabled ? "&#10074;&#10074;" : "&#8635;") + "</a>&nbsp;";
+          str += "<a href='#' class='schedule_action text-danger' data-action='delete_schedule' data-id='" + s.id + "' title='Delete'>&times;</a></td></tr>";
+        }
+        $('#schedulelist').html(str);
+      }
+    });
+}
+
+$('#schedules_tab').on('shown.bs.tab', ScheduleList);
+
+$('#schedulelist').on('click', 'a.schedule_action', function(e) {
+    var action = e.currentTarget.dataset.action;
     var post = {
-      "action": "restore",
+      "action": action,
       "values" : {
-        "repo": $('#r_repo').val(),
-        "snapshot": $('#r_snapshot').val(),
-        "indices": $('#indices').val()
+        "schedule_id": e.currentTarget.dataset.id
       }
     };
-    
+    if (action == "pause" || action == "resume") {
+      post.action = "update_schedule";
+      post.values.enabled = action == "resume";
+    }
+    if (action == "delete_schedule" && !confirm("Delete the schedule?")) {
+      event.preventDefault();
+      return;
+    }
+
     $.ajax({
       type: "POST",
       url: "/api/",
@@ -279,19 +820,47 @@ $("#restore").click(function(){
       dataType: 'json',
       contentType: 'application/json',
       success: function (data) {
-        if ( data.error == 0 ) {
-          $("#result").html('<div class="alert alert-success alert-dismissible fade show">'+data.message+'<button type="button" class="close" data-dismiss="alert" aria-label="Close"><span aria-hidden="true">&times;</span></button></div>');
-        } else {
-          $("#result").html('<div class="alert alert-danger alert-dismissible fade show">'+data.message+'<button type="button" class="close" data-dismiss="alert" aria-label="Close"><span aria-hidden="true">&times;</span></button></div>')
+        if (action == "run_schedule") {
+          var cls = $.isEmptyObject(data.rejected || {}) ? 'alert-success' : 'alert-warning';
+          ScheduleResult(cls, data.message);
         }
+        ScheduleList();
       },
       error: function (data) {
-        $("#result").html('<div class="alert alert-danger alert-dismissible fade show">'+data.responseJSON.error+'<button type="button" class="close" data-dismiss="alert" aria-label="Close"><span aria-hidden="true">&times;</span></button></div>')
+        ScheduleResult('alert-danger', data.responseJSON.error);
+        ScheduleList();
       }
     });
     event.preventDefault();
 });
 
-$('#update_instance').on('hidden.bs.modal',function(){
-	$('#update_form').trigger('reset');
+$("#schedule_create").click(function(){
+    var post = {
+      "action": "create_schedule",
+      "values" : {
+        "name": $('#s_name').val(),
+        "cron": $('#s_cron').val(),
+        "repo": $('#s_repo').val(),
+        "snapshot_pattern": $('#s_snapshot').val(),
+        "indices": $('#s_indices').val().split(",").map(function (s) { return s.trim(); }).filter(function (s) { return s != ""; }),
+        "options": {"conflict": $('#s_conflict').val()}
+      }
+    };
+
+    $.ajax({
+      type: "POST",
+      url: "/api/",
+      data: JSON.stringify(post),
+      dataType: 'json',
+      contentType: 'application/json',
+      success: function (data) {
+        ScheduleResult('alert-success', 'Schedule ' + data.name + ' created, next run ' + ScheduleDate(data.next));
+        $('#schedule_form').trigger('reset');
+        ScheduleList();
+      },
+      error: function (data) {
+        ScheduleResult('alert-danger', data.responseJSON.error);
+      }
+    });
+    event.preventDefault();
 });
@@ -14,35 +14,138 @@
 package config
 
 import (
+	"errors"
 	"io/ioutil"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
 
 type Config struct {
 	App struct {
-		Port    string `yaml:"port"`
-		TimeOut int    `yaml:"timeout"`
+		Port        string `yaml:"port"`
+		TimeOut     int    `yaml:"timeout"`
+		AllowOrigin string `yaml:"allow_origin"`
 	} `yaml:"app"`
 	Elastic struct {
-		Host     string `yaml:"host`
+		Host     string `yaml:"host"`
 		SSL      bool   `yaml:"ssl"`
 		Username string `yaml:"username"`
 		Password string `yaml:"password"`
 		Cert     string `yaml:"certfile"`
 	} `yaml:"elastic"`
+	Indices struct {
+		Prefix          string `yaml:"prefix"`
+		Retention       string `yaml:"retention"`
+		CleanupInterval string `yaml:"cleanup_interval"`
+		DryRun          bool   `yaml:"dry_run"`
+		Rename          string `yaml:"rename"`
+		DateFormat      string `yaml:"date_format"`
+	} `yaml:"indices"`
+	Allocation struct {
+		Tiers      []string          `yaml:"tiers"`
+		Attributes map[string]string `yaml:"attributes"`
+	} `yaml:"allocation"`
+	Restore struct {
+		Replicas struct {
+			Default int `yaml:"default"`
+			Max     int `yaml:"max"`
+		} `yaml:"replicas"`
+		AllowAliases   bool     `yaml:"allow_aliases"`
+		AllowPartial   bool     `yaml:"allow_partial"`
+		Settings       []string `yaml:"settings"`
+		IgnoreSettings []string `yaml:"ignore_settings"`
+		FeatureStates  []string `yaml:"feature_states"`
+		Conflict       string   `yaml:"conflict"`
+		Queue          struct {
+			Concurrency int    `yaml:"concurrency"`
+			Interval    string `yaml:"interval"`
+			MaxWait     string `yaml:"max_wait"`
+		} `yaml:"queue"`
+	} `yaml:"restore"`
+	Snapshots struct {
+		Name       string `yaml:"name"`
+		DateFormat string `yaml:"date_format"`
+	} `yaml:"snapshots"`
+	Catalog struct {
+		Refresh       string `yaml:"refresh"`
+		MaxAge        string `yaml:"max_age"`
+		PrefetchSizes bool   `yaml:"prefetch_sizes"`
+		File          string `yaml:"file"`
+	} `yaml:"catalog"`
+	Store struct {
+		Type  string `yaml:"type"`
+		Path  string `yaml:"path"`
+		Index string `yaml:"index"`
+	} `yaml:"store"`
+	Auth struct {
+		Type           string   `yaml:"type"`
+		Header         string   `yaml:"header"`
+		TrustedProxies []string `yaml:"trusted_proxies"`
+		SessionTTL     string   `yaml:"session_ttl"`
+		Users          []struct {
+			Name     string   `yaml:"name"`
+			Password string   `yaml:"password"`
+			Groups   []string `yaml:"groups"`
+		} `yaml:"users"`
+		Ldap struct {
+			URL                string `yaml:"url"`
+			StartTLS           bool   `yaml:"start_tls"`
+			InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+			CAFile             string `yaml:"ca_file"`
+			BindDN             string `yaml:"bind_dn"`
+			BindPassword       string `yaml:"bind_password"`
+			BaseDN             string `yaml:"base_dn"`
+			UserFilter         string `yaml:"user_filter"`
+			GroupAttribute     string `yaml:"group_attribute"`
+			GroupBaseDN        string `yaml:"group_base_dn"`
+			GroupFilter        string `yaml:"group_filter"`
+			CacheTTL           string `yaml:"cache_ttl"`
+		} `yaml:"ldap"`
+		Oidc struct {
+			Issuer        string   `yaml:"issuer"`
+			ClientID      string   `yaml:"client_id"`
+			ClientSecret  string   `yaml:"client_secret"`
+			RedirectURL   string   `yaml:"redirect_url"`
+			Scopes        []string `yaml:"scopes"`
+			UsernameClaim string   `yaml:"username_claim"`
+			RolesClaim    string   `yaml:"roles_claim"`
+			CAFile        string   `yaml:"ca_file"`
+		} `yaml:"oidc"`
+	} `yaml:"auth"`
+	Rbac struct {
+		DefaultRoles []string `yaml:"default_roles"`
+		Roles        map[string]struct {
+			Actions      []string `yaml:"actions"`
+			Repositories []string `yaml:"repositories"`
+			Indices      []string `yaml:"indices"`
+			Admin        bool     `yaml:"admin"`
+		} `yaml:"roles"`
+		Users  map[string][]string `yaml:"users"`
+		Groups map[string][]string `yaml:"groups"`
+	} `yaml:"rbac"`
 }
 
+// Parse reads the config file, the server can't start without it and panics on errors
 func Parse(f string) Config {
+	c, err := Load(f)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Load reads the config file and fills in the defaults
+func Load(f string) (Config, error) {
 	var c Config
 	yamlFile, err := ioutil.ReadFile(f)
 	if err != nil {
-		panic(err)
+		return c, err
 	}
 
 	err = yaml.Unmarshal(yamlFile, &c)
 	if err != nil {
-		panic(err)
+		return c, err
 	}
 
 	if c.App.Port == "" {
@@ -57,5 +160,70 @@ func Parse(f string) Config {
 		c.Elastic.Host = "http://127.0.0.1:9200/"
 	}
 
-	return c
+	if c.Indices.Prefix == "" {
+		c.Indices.Prefix = "extracted"
+	}
+
+	// {prefix}, {index}, {date}, {snapshot}, {repo} and {user} are
+	// substituted on every restore, see router.renameReplacement
+	if c.Indices.Rename == "" {
+		c.Indices.Rename = "{prefix}_{index}-{date}"
+	}
+	// restored indices are found by the prefix, without it the janitor
+	// would take any index for a restored one
+	if !strings.Contains(c.Indices.Rename, "{prefix}") {
+		return c, errors.New("indices.rename must contain {prefix}")
+	}
+
+	if c.Indices.DateFormat == "" {
+		c.Indices.DateFormat = "02-01-2006"
+	}
+
+	if c.Restore.Conflict == "" {
+		c.Restore.Conflict = "fail"
+	}
+
+	if c.Restore.Settings == nil {
+		c.Restore.Settings = []string{"index.refresh_interval"}
+	}
+
+	// {repo}, {user} and {date} are substituted in names of
+	// created snapshots, see router.snapshotName
+	if c.Snapshots.Name == "" {
+		c.Snapshots.Name = "{user}-{date}"
+	}
+
+	if c.Snapshots.DateFormat == "" {
+		c.Snapshots.DateFormat = "2006.01.02-15.04.05"
+	}
+
+	if c.Auth.Header == "" {
+		c.Auth.Header = "X-Forwarded-User"
+	}
+
+	if c.Auth.Ldap.UserFilter == "" {
+		c.Auth.Ldap.UserFilter = "(sAMAccountName=%s)"
+	}
+
+	if c.Auth.Ldap.GroupAttribute == "" {
+		c.Auth.Ldap.GroupAttribute = "memberOf"
+	}
+
+	if len(c.Auth.Oidc.Scopes) == 0 {
+		c.Auth.Oidc.Scopes = []string{"openid", "profile", "email"}
+	}
+
+	if c.Auth.Oidc.UsernameClaim == "" {
+		c.Auth.Oidc.UsernameClaim = "preferred_username"
+	}
+
+	if c.Auth.Oidc.RolesClaim == "" {
+		c.Auth.Oidc.RolesClaim = "roles"
+	}
+
+	if c.Store.Type == "elastic" && c.Store.Index == "" {
+		c.Store.Index = "extractor-state"
+	}
+
+	return c, nil
 }
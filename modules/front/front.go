@@ -1,16 +1,6 @@
 // Code generated by go-bindata.
 // sources:
-// 404.html
-// assets/css/bootstrap.min.css
-// assets/css/bootstrap.min.css.map
 // assets/fonts/glyphicons-halflings-regular.ttf
-// assets/js/app.js
-// assets/js/bootstrap.bundle.min.js.map
-// assets/js/bootstrap.min.js
-// assets/js/bootstrap.min.js.map
-// assets/js/jquery-3.5.1.min.js
-// favicon.ico
-// index.html
 // DO NOT EDIT!
 
 package front
@@ -158,7 +148,7 @@ func assetsFontsGlyphiconsHalflingsRegularTtf() (*asset, error) {
 	return a, nil
 }
 
-var _assetsJsAppJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5b\xff\x6f\xdb\xc6\x92\xff\xf9\xf2\x57\x0c\x98\xde\xa3\xf4\x64\x8e\xb8\xdc\xe5\x92\xb4\x2d\x03\x3d\xf7\x00\xf7\x90\x36\x87\x4b\x9e\x71\xb8\xa0\x08\x68\x71\x2d\xf2\x85\x22\x75\xe4\x4a\x4e\x5c\xf8\x7f\x7f\x98\x25\x29\x91\x92\xec\xb8\x2f\xcd\x43\x5b\x34\x41\xa8\xe5\xee\xec\xcc\xec\x97\x99\xfd\xcc\x2c\xb3\x89\x2b\x58\x28\x5d\x94\x89\xaa\x61\x06\xb5\xd2\xdf\x17\x5a\x55\x9b\x38\x1f\xfd\x58\x26\xea\x8d\x8e\xf5\xba\x3e\x01\xdf\x75\xdd\xf1\xd9\x8b\x96\x3a\x2b\x92\x6c\x7e\x40\x7f\xbb\x2e\xe6\x3a\x2b\x8b\xd1\xf8\xe7\xef\x8b\x44\x7d\x7c\x95\xd5\x7a\x64\xa9\x8f\xba\x8a\xe7\x5a\x25\x7f\xb5\xc6\x0f\x27\xc0\x1b\x46\x2f\x3a\x62\xb8\xf9\xa4\x55\xfd\xb6\x7c\x93\xdd\xab\x91\x29\x8f\xe1\xe7\x17\x00\x40\xa2\xea\xec\xde\x48\x79\x67\xdf\xd8\x27\x60\x7f\x30\xcf\xa5\x79\x2e\xcc\x53\xdf\xd8\x3f\x9d\x11\x75\x76\x0b\x4d\x6f\x98\xcd\xc0\x1d\x43\xa5\xf4\xba\x2a\xc0\x76\x0d\x7f\xfb\xac\xe3\x98\xc1\x0c\x56\x71\x55\xab\xef\x0b\x3d\xfa\x21\xd6\x29\xde\xe6\x65\x59\x35\xc5\xbc\x5c\x74\x2a\x4c\x61\x5b\xc3\x5c\x4f\x8c\xc7\x63\xc3\xa2\x65\x6b\xda\xaa\x72\x5d\x24\xad\xd0\x96\x7c\x55\xde\x19\xf2\x13\xc8\xc6\x27\xe0\x8d\x61\x02\x36\xd8\x30\x69\x06\xf2\x2e\xfb\xe9\xec\xc5\x43\x6f\xe8\xbb\x59\xca\x56\xb1\xd6\xaa\x2a\xda\xb1\x1b\x55\x57\x65\xad\x61\xd6\x56\x00\x58\xb1\xe9\x64\x9d\x82\xb5\x50\xfa\x7d\xbb\x04\xd6\x49\xd7\xbc\x89\xf3\xb5\xaa\xad\xd3\x6d\x07\x00\xab\x63\x6b\x9d\x42\x57\x6c\x1b\x1f\xc0\x14\x1e\xcc\xa8\x9a\xf2\x37\x18\xff\x3d\xfe\x38\xea\xba\xeb\x4f\x2b\x75\x0a\xd6\x7f\xbf\x7e\xf3\x76\x2b\x64\x5d\xe5\xa7\x60\x4d\xe3\x55\x36\xdd\xd6\x25\xb1\x8e\x4f\xe1\xbf\xde\xbc\xfe\x11\x6b\x5d\x65\xc5\x22\xbb\xfd\x34\x22\xdd\xc7\x7d\x8a\xb7\x86\x9b\xfd\xf7\xba\x2c\xec\xae\x7e\x5e\x16\x5a\x15\xba\x6d\x8a\x57\xab\x3c\x9b\xc7\x34\xc6\xe9\x80\xac\x5e\xcf\xe7\xaa\xae\x4f\x61\x3b\x6f\x23\xe2\x38\xee\x0d\xd4\xec\x15\x5d\xc1\x0c\x2c\xeb\x6c\x50\x9b\xaa\x38\xd7\x29\x35\x68\xf5\x51\x3b\x2d\xaf\x1e\xd1\x6a\x4e\x8d\x37\x8b\x23\x4d\xb7\x65\x35\x22\x1e\x1f\x20\x2b\x60\x5f\x64\xbb\x46\xaa\x9a\xab\x82\x96\xc9\x3d\xdb\x6f\x3a\x56\xa9\xeb\x63\xb5\x89\xca\xdf\xdf\xac\xb5\x2e\x8b\xbd\x11\xb4\xcd\x65\xa1\x66\xb6\xdd\xaf\xde\x67\x5b\xc5\x35\x0d\xd2\x3e\xaf\x37\x0b\xb8\xcb\x12\x9d\xce\x2c\xa6\x96\x16\xa4\x2a\x5b\xa4\xba\x7d\xd9\x64\xea\xee\x3f\xca\x8f\x33\xcb\x05\x17\x98\x04\x26\x2d\x98\xe7\x71\x5d\xcf\xac\x9b\x0c\x6e\x32\xc7\xf0\xb1\x9a\x15\x73\xb2\x64\x66\xd1\xde\xfd\x40\xbb\xd8\x82\xdb\x2c\xcf\x67\xd6\x7c\x5d\x55\xaa\xd0\x97\x65\x5e\x56\x16\x7c\x5c\xe6\x45\x3d\xb3\x52\xad\x57\xa7\xd3\xe9\xdd\xdd\x1d\xde\x71\x2c\xab\xc5\xd4\x73\x5d\x77\x5a\x6f\x16\xd6\xc5\xf9\x2a\xd6\x29\x24\x33\xeb\x07\x1f\x7d\xf0\xd1\xff\x16\xa9\x60\x34\x00\x09\x72\x23\xe3\x5d\x85\xc3\xc0\xbd\xee\x57\x00\xfa\x0e\xfa\xf7\x4b\x8f\x2a\x86\xf5\xe8\x3f\xaf\x2b\x07\xf4\x77\xd5\x2e\xd1\xf5\x3b\x12\xa5\x7b\x2d\xef\xad\x69\xab\x2a\x8d\xd3\xa9\xd6\xb9\x9a\x59\x6a\xa3\x8a\x32\x49\x2c\xa3\x3e\x13\xe8\x03\x8f\x19\xd1\xb7\x02\xd9\x15\xe3\x9b\x28\xf6\xc0\x6b\xab\x3c\xf0\xae\xfc\xfe\xbb\xe3\x5d\x8b\xd4\x41\xbf\xdf\xcd\x61\xd7\xde\xee\x9d\x6a\xae\xe4\xf0\x3d\x1d\xb4\x03\x4b\x79\x9f\x03\xfd\xdd\xb0\xfb\x1f\x04\x32\x16\x82\x78\x25\x40\xa0\xeb\x47\xd7\x6c\xa7\x9c\xa1\x4a\x65\xff\xdd\x61\xd7\x86\xec\x15\x63\x18\x86\x1e\x88\x2b\xd3\xff\xfe\x07\x9a\x5a\x7e\xed\xa5\x8c\x6d\x58\xea\x30\x66\x66\x82\xd6\xee\xe2\xb1\x1d\x77\x5b\x56\x30\xca\x66\xee\x19\x64\xe7\xb4\x53\xde\x7d\xf8\x09\xeb\x34\xae\x92\x1a\x73\x55\x2c\x74\x7a\x96\x4d\x26\x43\x5b\x01\x58\x4d\x66\x5b\xbf\x3b\xec\xf4\x2e\xfb\x09\x33\xf2\x84\x48\x5e\x12\x5b\x93\x1a\x9f\x0d\xba\xeb\x7a\x32\x7b\xb2\x9b\x2e\x75\x9c\xbf\xcf\x8a\xf7\xc6\x21\xf7\x3b\x3f\xf4\xca\xab\x8a\xcc\x7d\x35\x3d\xae\x76\x8f\x90\xce\x13\x22\x3e\x07\xe9\x1e\x0c\xa5\x73\x19\x49\x5c\x2c\x54\x65\x0d\x35\x25\x6b\x25\x53\x34\xfe\xa6\xa1\xb0\xf7\x28\x1e\x37\xf7\x87\x23\x2a\x5c\x3c\xa5\xc2\x5d\x5c\x15\x59\xb1\x78\x4a\x87\x96\xe4\x0b\x95\x98\x01\x73\x1f\x57\xe3\xd0\x79\x1e\xa8\xd1\x92\x3c\xa9\xc6\x79\x0c\x69\xa5\x6e\x67\xf6\x4b\xbb\x75\x4c\xf6\x8e\xc0\x06\x9d\xe9\x5c\xcd\xec\xef\x54\xae\xb4\x82\x4c\xdb\x5b\x47\x65\x5b\xad\xa3\xb2\xec\x0b\x6b\x62\xdc\xd8\xc4\x3a\x9f\xc6\x17\x7b\x03\xeb\xbd\xdc\xc4\xf5\x07\xa5\x7f\x05\xa7\x79\x5b\xe6\x89\xaa\x3c\xa7\x5c\xa9\xc2\x1c\xf6\x66\xd8\x5f\xee\x33\x1f\x75\x44\xc0\xd1\xff\x96\xa1\x0f\x6c\xeb\xeb\xc8\x88\xbd\xd4\xc3\x40\x8a\x39\x46\x7e\x48\x95\x18\x48\xf4\x25\x78\xc8\x19\x03\x86\x2c\x14\x97\x01\x46\xa1\x0f\x1c\xa5\x08\x21\x44\x11\x82\x80\x08\x44\x2a\x0e\xf8\x31\xe3\xaa\x37\x48\xec\xfc\x00\x3d\xe9\x63\x24\x30\x0c\x24\x86\xbe\x24\x3a\x21\x73\x07\xa5\x00\x1f\x99\x27\xbe\x35\xe2\x77\x9d\x3d\x0c\x38\x07\xe6\x5f\x71\xf4\xa4\x8c\x07\xad\x8e\x87\x22\xa4\x27\x8b\x0c\x07\xa7\xe1\xb0\x27\x1e\x24\x32\x71\xcd\xd1\xbf\xff\xc1\x03\x99\x32\x6f\xe3\x0c\xbd\xb8\x71\xee\x57\xd1\xdc\xc1\x48\x0a\xf2\xea\x18\x30\x07\xa5\x17\x11\xe7\x40\x38\x0c\x99\x2f\x2e\x25\xf2\x40\x00\x47\xce\x49\xd3\xd0\x03\x0e\x3e\x7a\x52\x00\xbf\xf2\xf6\xf9\xa1\x7f\x2d\xef\x97\x0e\x72\x19\x00\x1b\x34\x89\x88\x26\xd2\xcb\x77\xe3\xed\x6b\xeb\x82\x19\x25\x30\x91\x46\x28\x64\x18\x0f\x1b\x19\x8a\x30\x72\x18\x72\x26\xf2\xdd\x70\xfb\xc7\x8f\x30\x22\x83\x2b\x86\x92\xf3\xcf\x7b\x5f\xc2\x39\x13\xb2\x95\x3c\xbb\xb0\x26\xdd\x36\x9e\x80\xf5\x97\xe2\xa6\x5e\x9d\x6d\x0d\xe1\xbc\x5e\xc5\x45\x67\x45\xb7\x79\x19\x6b\xa7\xa2\x6d\x6d\x5f\x10\x49\x1f\x75\xeb\x7a\xbc\xeb\xbf\xe3\xb2\xb3\x3c\xe2\x36\x25\x76\x43\x73\xda\x6a\x92\x64\x9b\x4e\xd0\xaa\x2a\x17\x15\x99\x39\x35\x7f\x22\x6b\x6d\x8c\xe9\x14\xf8\xea\xe3\x99\xfd\x7c\x06\xce\x4d\x5c\x01\xa9\xb1\x9a\x1b\xb3\x86\xaa\x24\x6e\x5d\xf3\x4d\x5c\xd9\x9d\x04\x63\xba\xa7\x0d\x71\x65\xa8\xff\xfd\xcc\x86\xb8\xca\x62\xc7\x80\xe2\xa2\xbc\x6b\x1c\x44\xdb\xda\x6f\x5b\x66\xc5\xcc\x76\x07\x35\xf1\xc7\x99\xcd\x5c\xd7\xbe\x38\x9f\x26\xd9\xe6\x11\x8d\x4d\xd3\xf9\x4d\x75\x71\x3e\xa5\x75\x38\xba\x56\x3b\x3f\xfa\xcd\xc8\x7e\x99\x15\x49\x9e\xd5\xda\x1e\x63\xaa\x97\xf9\xa8\xd6\xd5\xf6\x88\x6b\x08\x1f\xc6\xc3\xe8\x60\x17\x7e\x8d\x9e\x1f\x16\x98\x38\xce\xea\xb0\xfd\x1f\x17\xd7\x37\x27\xcf\xf3\xc1\x7a\x73\x92\x75\xc7\x7e\xb2\x5e\xc1\x39\x04\x07\xe7\xd9\x91\x13\x6d\xd0\xfe\xf0\x24\xc7\x8b\xa7\x39\x76\x47\xf5\x2f\xe2\x18\xfa\x4f\x70\x6c\xf1\xc7\x13\x0c\xfb\xce\xe2\x3c\x15\x9d\x91\xd5\xcb\x38\xcf\xe1\xb6\x2c\xb4\x73\x67\xcc\xd3\xb9\x29\xf3\xa4\xf1\x0c\x9d\xfc\x22\x5e\xd2\x29\x66\xc1\x14\xfa\xd5\xd9\xea\xf3\xce\x65\x3b\x04\xe3\x97\x5a\xd7\x71\x3e\x4d\xc5\xc5\x1e\x40\x78\xda\x83\xec\x3b\x10\xf7\xd0\x83\x7c\x0d\x1f\xd2\x5f\x80\xc7\x7d\xc9\x1e\xd5\x73\x7d\xca\x31\x01\x47\xfc\xcc\xf3\x3c\x0d\x1c\x9a\xc1\xd0\xe7\x90\x37\x78\x96\xd3\x79\xf1\xcd\x28\x29\xe7\xeb\x25\x01\x6f\xac\x54\x9c\x7c\xea\x67\x73\x9e\xe9\x7b\x2a\xb5\x2a\xeb\x4c\x97\x55\xf6\x07\x70\x41\x9f\xf1\x41\xcf\xc8\x0d\xd0\x28\x61\xb6\x5d\x6d\x7a\x1d\x1c\x25\x77\x99\x9e\xa7\x30\xa2\xfa\x7d\x1b\x9f\xc7\xb5\x02\xeb\x76\x98\xcd\x69\xfe\x3c\x02\x5b\x91\x0f\x80\x6b\xf3\xfa\x79\xe8\x9a\xe5\xaa\xfe\xa7\x61\x2a\x3c\x8d\x53\x05\x78\x69\xb0\x0b\x86\xc1\x03\x6f\xc3\xdc\xbd\x68\x59\xec\x47\xcb\xfd\x0e\x8e\x77\xbf\x74\x81\xed\xc2\x58\xc7\x84\xbe\xee\x5e\x9c\x1b\xec\xc7\xb9\xfd\x0e\x0e\xbb\x12\x04\xaa\x60\x97\x88\x90\xe0\x7e\x56\xb1\x8d\xc3\xf6\xb8\x7a\x7b\x5c\xe5\x50\xad\xab\x7d\xcd\x8f\x03\x39\xb3\x84\x95\x8a\x3f\x9c\x3d\xe1\xb5\x9b\xe5\x5f\x57\xf9\xd7\x5e\xff\x3c\x2b\x3e\x7c\xe9\xf2\x9b\x19\x45\xee\x13\x36\xf6\xaf\x44\xcc\x81\xb7\xb3\xe6\x82\x4c\x79\xef\xdd\xc3\x90\x3b\xc2\x80\x76\x37\x94\x84\xac\x59\x80\x2e\x73\xd0\xf3\xd1\xe5\xec\xdb\xdd\xfc\x05\xc0\x5c\xc3\xac\xa9\x32\x13\xed\x88\x94\xa1\xcf\xfd\x39\x7a\x2c\x74\x90\x07\x12\x45\xe4\x3b\x18\x30\x81\xa1\xe7\xb0\xbd\x45\x8e\x48\x9d\x9d\x70\x87\x84\x03\xb1\x70\xa3\xb0\x27\x29\x02\x89\x7e\xca\xfb\x82\x40\xa4\x8e\x91\x14\x0b\x74\x3d\x30\x8f\x66\x13\x50\x04\xc1\xae\x98\xd7\xb2\x35\xab\xef\xc8\xab\xe8\x4b\xd6\x3a\x51\xb7\xf1\x3a\xd7\xf0\x95\x57\x7a\x9e\x97\xeb\xe4\x2b\x05\xa4\x02\x85\x2b\x29\xca\x12\xde\xb7\x3e\xfa\x14\x63\xf9\xbc\x9d\xdf\x10\xbc\xb9\x87\x32\xa2\x69\xc5\xc8\xe3\xe0\x51\x08\x25\x25\x08\xf4\x83\xe8\x92\x09\x0c\xfc\x10\x24\x86\xae\x20\xbd\x43\x64\x3c\xa0\x42\x84\x41\xc0\xcd\x48\x18\xfa\x32\xa2\x28\xc9\x77\x3d\x60\x9c\xa2\x4b\x19\x06\xc0\xf8\x15\xc7\x20\x64\x97\x0c\x03\x37\xa4\x06\x97\x68\xb9\x94\xe0\x42\x84\x9c\x85\x73\x13\x15\x4a\x0e\x8c\xe2\x33\x87\xa3\x47\xe2\x31\x12\x9e\xc3\xd1\x8f\x38\x32\xc1\x1d\x0c\x25\x47\x19\x85\x44\xea\x11\xa9\x90\xc2\xf1\x90\x87\xfc\x7e\x89\xd2\xe7\x18\xf8\xc1\xdc\xa1\x27\xbd\x99\xb0\xd2\x50\x89\xb6\xe8\xa1\xeb\xcb\x0d\x0a\x11\xe6\x0e\x0a\xe1\xa3\x2b\xa2\x4b\x0f\x5d\x29\xcc\xa0\x7c\xda\xcc\x18\xf9\x1e\x6d\x35\x52\x8a\xb6\x98\x8b\x41\x48\x11\x31\xc9\xf3\xc0\x8c\x02\x98\x97\x86\x18\xb9\xf2\x92\x71\x8c\x42\xaa\x67\x3e\x51\x46\x61\x48\x25\x33\x1d\x66\x40\x1e\x93\x0e\x43\xd7\x73\x3c\xf4\xbc\x90\x54\x65\xbc\x29\xa7\x0e\xfa\x14\x26\x5f\x32\x0f\x59\x18\x02\x99\x85\xe1\xc1\xbd\x10\x38\x84\xc0\x63\x41\x0b\x23\xba\xd5\x21\xa3\x88\x84\xc9\x0d\xfc\xf3\x3b\xf8\x61\x2f\x3a\x3a\x04\x9b\x87\x30\xf3\x48\x9a\xc7\x60\x07\x20\xac\xe2\x2c\xaa\x72\xbd\x72\x32\xad\x96\xfb\xef\x4e\x83\x37\xf6\xd2\x3e\x5b\x64\x9a\x74\xf9\x9f\xa3\x41\xf1\x90\xec\x7c\x1a\x37\x88\xf4\x10\x55\x11\x70\x22\x75\x5a\xe0\x14\xaf\x56\xaa\x48\x06\xd0\x69\x37\xea\x87\x36\xdc\x23\x18\x65\x0a\xfd\xa0\xad\x21\x7f\xe4\x2a\xec\xec\x85\xe9\xb3\x27\xac\x2c\x46\xf6\x3c\xcf\xe6\x1f\xec\x13\xb0\x63\x34\xb3\x62\x9f\x6c\xe1\xc9\x48\xf5\x23\x41\x6a\x35\x28\x7d\x06\x0a\x75\x5c\x2d\x94\x46\x1a\x65\xad\x34\x66\x49\x07\xbd\x46\xd6\xcb\xbc\x8c\x13\x8a\x3c\x08\xda\x2d\xcb\x8d\xba\xa4\x39\x1f\xd9\x59\xb1\xc9\xea\xec\x26\x57\xf6\x78\x4b\x6c\xbf\xac\x55\xae\x48\xc7\xba\x88\x57\x1d\x6e\xb4\x6e\xab\x72\x09\xe7\xb5\xae\xca\x62\x71\x61\x4d\x3a\xc9\x13\x02\xf7\x6d\x65\xc7\xe3\xf3\x38\x91\x38\xd7\x69\xa9\x0f\x2f\xaf\x06\x5e\xd0\x22\x29\xd6\xe9\x76\x98\x43\xdc\xfa\xfb\x45\x96\xdd\xf8\x86\xc0\x72\x5b\x9d\xe4\xd9\xdc\x64\x43\xbf\x34\x2f\x99\x94\x77\x05\xad\xfc\x57\xf2\xfa\x48\x5e\x29\x3a\xb8\x93\xf1\x7a\x57\x15\x0d\x3c\x63\xde\x10\x49\x6d\x9c\x41\xee\xcd\xb4\x99\x6e\x43\x64\xe8\x0d\x91\xe1\x41\xaf\xe6\x7a\xe7\xb3\xd7\x36\x01\x4a\x61\x4e\x91\xd0\x17\xfd\x5b\x1f\x73\x6a\xb8\x39\x77\xf8\x20\xd7\x17\xb8\xa1\x79\xbc\x0a\xd1\xf8\x4e\x2f\xe2\xd7\xec\xf0\x02\x29\xc4\x20\xe2\xaf\x7c\x03\x7b\x42\x64\x62\x7b\xa1\xc4\x5a\x26\xf4\x2f\xe7\x30\xcc\xe7\x3d\x37\x7a\xe8\x0c\xa4\x17\x3d\x90\x3d\xef\x08\xd6\x45\xf6\xff\x59\xd2\x6b\xae\x75\x5c\xe9\xf7\x6a\x55\xce\xd3\x61\xba\x8a\x3c\xd1\x90\x4e\xaf\x07\xb7\x23\x49\xac\xc9\x81\x14\xea\x0e\xbe\x8b\xb5\x1a\x1d\xe1\x08\x7f\x05\xd6\xdc\xda\xef\xba\xa5\x6d\x3f\xfa\x41\x5d\xbe\x2a\xe7\x71\xae\xde\x18\x53\x1a\x59\xd5\xda\xf9\x9f\xbf\x59\x27\xf0\xb3\xce\x96\xea\xff\xca\x42\xfd\x18\x2f\xc9\x2c\xeb\xb4\xac\xb4\xf5\x30\x60\x54\xa9\x5a\x97\x95\x1a\xde\x45\xec\x5d\x40\x74\xc3\x98\x81\xf5\xe6\x6f\x97\x97\xff\xf9\xe6\x8d\x75\x90\xdd\xf9\x75\x6c\xa6\x56\xd5\x46\x55\x30\xb8\x2b\xfe\x4a\x59\x7c\xe4\x9c\xe0\x83\x94\xc1\x65\x53\x66\xc8\x22\x01\xc2\xa0\x04\x17\x42\x70\x6b\x49\xad\x6d\x7d\x53\x36\xf4\xd7\x82\x70\x93\x94\x3e\xf8\x28\x02\x41\x9b\x5b\x86\x2d\x41\xf7\xdb\xb0\xe9\x18\x10\x7b\xa2\xe5\x6d\x59\x5c\x1b\x46\x14\x5d\x71\x2a\x6c\x78\xa7\x83\x6b\x40\x4b\xd3\x9b\x79\x40\x8f\x39\x27\xf6\xae\x61\xe6\x13\xec\x89\x78\xc3\xd8\x21\x26\xfe\xb5\x44\xce\xc5\xdc\x41\xc1\x91\x7b\x0e\x46\x9c\xa1\x4f\x78\x4a\xf8\x64\x25\x97\x64\x7a\x04\x82\x64\x28\xc8\x61\x18\x1d\x43\x08\xe7\x0e\x33\x2f\xae\xc3\x31\x64\x0e\x72\x16\x38\x3e\x7a\x04\xb2\x03\x19\x4b\xf4\x5d\x52\x9f\x9e\xad\xdb\x40\xe1\x07\x0e\x06\xd1\xfd\x92\x75\x09\xfd\x9d\x5c\x16\xb5\x82\x83\xad\x64\x93\x75\x17\xe8\x1b\xe4\x27\x3c\x62\x4b\x02\xdc\xc8\x94\xf6\x85\xcb\xa1\x70\x06\xe6\xb1\x27\x7a\xc3\x50\x4a\xd9\x4d\x95\xc0\xd0\x95\xdd\x54\x49\x9a\x2a\xd9\xac\x98\xd3\x5b\x31\x33\x47\xc1\xc6\xa1\x9e\xfe\xa3\x28\xeb\xd0\x08\x8e\x40\xa4\x5e\x9a\x0d\x6e\xf4\xee\x3a\xec\x7f\x1d\x83\x2a\x76\xf7\x61\x0d\x14\x98\xd9\x2f\xd7\x2b\x32\xcf\xf7\x59\x51\xeb\xb8\x98\xab\xae\xb9\x5c\x2c\xa8\xdf\xb2\x4c\xe2\xbc\xad\xa3\x33\xb6\x41\x53\x5b\x50\x61\x92\x5a\x9f\x01\x5a\xcd\x59\x75\xec\xa6\x0d\x54\x5e\xab\xaf\x6f\xa3\x6d\x06\xf4\x4f\x13\xfd\xd3\x44\xff\xe5\x26\xfa\xf8\xd5\xf2\x30\xeb\xee\x3f\x16\x08\xc1\xb3\xe3\x9d\xd6\xd2\xad\x49\x73\x12\x4e\x8c\xf1\x35\xa6\xb7\x43\xe5\x30\xd9\x01\x87\x26\xed\xde\x34\xc0\xbc\x52\xb1\x56\x09\xc4\xda\xe4\xb7\x9b\xa3\x7b\xb2\x3f\x22\xb2\xe2\xd4\x3f\x88\x87\x7a\x03\x1b\x86\x12\x71\x92\x1c\x8b\x23\x76\xc4\xf6\xcb\x4e\x9d\xe3\x29\x68\x02\xf2\x0f\x5d\x1c\xf4\x62\x78\x45\xb6\x17\x07\xf5\x3e\x02\x38\x16\x0c\xcd\xcb\xa2\x2e\x73\x65\xbe\xd6\x53\xe3\x6d\x14\xb2\x8d\x8d\x5a\xbf\xf0\xf6\x30\x44\x9a\x4e\xe1\xed\xeb\xef\x5e\x9f\x42\xb6\x5c\xaa\x24\xd3\x71\xfe\x09\x9a\x18\x09\xcc\xb7\x25\xcf\x88\x68\x48\x37\x43\xfb\x99\x68\xa6\xa1\x39\x85\x3f\x62\x28\x43\x3b\xa3\x52\xf5\x3a\xd7\x56\xbb\xcc\x76\xef\x3e\xc6\x8a\x73\x55\x69\x30\xcf\xd6\x63\x77\x2f\x59\xbd\xcc\x6a\xb3\x77\xe0\x36\x4e\x14\xd4\x69\x79\x67\x5d\x98\x90\x19\x12\xf3\x99\x47\x72\xde\xee\x4f\x9a\x8f\x99\xd5\xbc\x6c\x4f\x84\x79\x5e\xd6\xca\x6a\x8e\xa9\x96\x59\x2b\xce\x6a\xae\x5f\xf2\xf8\x46\xe5\x33\xeb\xd2\xd0\x5d\x34\xd7\x56\xa6\x21\xcd\x92\x44\x15\x33\x4b\x57\x6b\x65\x5d\xfc\x85\x90\x6a\x7d\xb6\xbd\xaa\x6a\xc4\xb4\x37\xc0\xf6\xb8\x5b\xaf\x6e\x4a\x54\x55\x95\xd5\xbf\x70\x42\xec\x09\x09\xc0\x4a\xd5\xab\xb2\xa8\x95\x59\x6c\xa3\xc3\xc4\xfe\x2d\x4d\xcf\x36\x15\x62\xe6\x68\xa3\x0a\x8d\xab\xca\xfc\x7e\xd7\x64\x39\x47\x03\x73\xdf\x87\x27\x8d\xd9\xd3\x88\x0b\xbc\xa9\xb1\xc1\x27\x27\x3d\x73\xdf\xa5\x3e\x7a\x11\xd2\x37\x23\x85\x95\xca\xc9\xc3\x35\x06\x3e\x36\x16\x3e\xb2\xb3\xa4\xf3\x47\x5d\xb2\xe4\x29\x6a\x6a\xef\xe8\xb7\xb9\x90\xf6\x23\x5b\x7b\x8c\xb7\x59\x91\x8c\xec\x72\x65\xfc\x71\x33\xe0\x36\x99\x32\x6a\xdf\x54\x91\x8c\xfa\xfd\x9f\x9f\x07\x79\x7e\x1a\xe4\x64\x57\xbb\xed\x7c\xba\x9d\x8c\x7d\xaf\xf2\x3b\x77\x2a\xe6\x73\xed\xed\x87\xe6\xc6\x00\xb6\x99\xa3\x77\xae\xf9\xf8\x8f\xda\x76\x47\x4e\xff\x18\x68\x1b\xc7\xfd\x90\x1b\x46\x26\xde\xee\x9a\x06\xe8\xb4\x5c\xe9\xb7\xea\x23\x2d\xd6\x87\x89\xb9\xf2\x9e\xf4\xbf\x90\x69\xbb\x74\x11\x74\xdd\x7c\x6d\x68\x3e\x3c\xdc\x7e\x72\x38\x88\x6a\xcb\x95\xbe\xa6\xb5\x24\x7e\xfb\xb9\xc5\xdd\xa6\x6a\x53\x8b\x14\x7b\xbf\x36\x3b\x6b\xd4\xaa\x71\xb2\x65\x70\x42\x06\x68\x1e\xe3\x63\xe9\xc7\x81\xc5\xb5\xdb\xb6\x32\x17\xb1\x63\xdc\xc4\xf9\xc8\x94\xce\xb6\x0d\xdd\xfc\x35\x8d\xdb\xb7\xb6\x7b\x67\x99\xc6\x75\x11\x34\xb0\xc6\x68\x0e\xe1\x5f\x78\x17\xdc\xf5\x7e\xd6\xb6\x36\x09\xd0\xf7\x8d\xf5\x19\xad\xc6\xc7\x37\x79\x43\xd7\x55\x1c\xa1\xed\x3e\x88\x3f\xdd\x9b\x64\x43\x77\xcc\x34\x7e\xef\xf6\x91\xdd\xc2\xa8\x31\x0b\x73\x14\x98\xff\xf8\x00\xc3\x5d\xfd\x0b\x8e\xa1\x56\xe0\xb3\xce\xa1\xa5\xaa\xeb\x78\xa1\x7e\x1b\x87\x4f\xcf\x2a\x0e\x83\xce\xaf\x73\x0e\xff\xa6\xc6\x7f\xe8\x14\xfe\x44\x29\xbf\x12\x4a\x79\x1c\xa4\x34\x82\x8f\xa1\x94\xf1\xcf\x2f\xfe\xad\xd7\xef\xb6\xac\x96\xf6\x18\x75\x95\x2d\x16\xaa\x22\x98\x51\x2b\x6d\xb7\x02\xfe\x11\x00\x00\xff\xff\xf2\x48\x2e\xa6\x7c\x35\x00\x00")
+var _assetsJsAppJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x7d\x6b\x93\xdb\x46\x92\xe0\xe7\xd3\xaf\x48\x43\x5e\x81\xdc\x26\x8a\x78\x11\x24\xbb\x9b\xad\xf0\x69\x1c\xa1\xb9\xb0\xc7\x13\x96\x47\x71\x71\xb2\xb6\x03\x4d\x56\x37\x61\x81\x00\x03\x00\xbb\x5b\x8f\x8e\xf0\xe3\xee\x76\x37\x3c\x71\x8e\xfd\x27\x1a\xdb\x1a\x6b\x3c\xb6\xfc\x17\xc0\x7f\x74\x91\xf5\x00\x0a\x0f\x76\x53\xf2\x68\x6f\xa4\x9b\x71\x0c\x05\x54\x65\x65\x65\x55\x65\x66\x65\x66\x65\xa1\x4f\xfd\x04\x4e\x68\x16\xc5\x33\x9a\xc2\x04\x52\x9a\xfd\x36\xca\x68\x72\xea\x87\x9d\xdf\xc5\x33\x7a\x27\xf3\xb3\x55\xda\x83\x81\x69\x9a\xdd\xbd\x6b\x02\x3a\x88\x66\xc1\xb4\x01\x7f\xbc\x8a\xa6\x59\x10\x47\x9d\xee\xe3\xdf\x46\x33\x7a\xfe\x41\x90\x66\x1d\x4d\xeb\x5e\xf4\xc0\xe1\xcd\xaf\x49\x10\x38\x7a\x98\xd1\xf4\x93\xf8\x4e\xf0\x88\x76\xd8\x73\x17\x1e\x5f\x03\x00\xec\x20\x0d\x1e\x31\xdc\xf7\xf4\x23\xbd\x07\xfa\x03\xf6\xbb\x60\xbf\x27\xec\x37\x3b\xd2\xef\xef\x21\x74\x70\x0c\xbc\x35\x4c\x26\x60\x76\x21\xa1\xd9\x2a\x89\x40\x37\x19\x7e\x7d\x4f\x62\x0c\x60\x02\x4b\x3f\x49\xe9\x6f\xa3\xac\xf3\xa1\x9f\xcd\xc9\x71\x18\xc7\x09\x7f\x0c\xe3\x13\x49\x42\x1f\x8a\x12\xcb\xb4\xdd\x6e\xb7\xcb\x50\x08\xb4\xac\x2e\x89\x57\xd1\x4c\x74\x2a\xc0\x97\xf1\x19\x03\xef\x41\xd0\xed\x81\xdd\x85\x1d\xd0\x41\x87\x1d\x3e\x90\x7b\xc1\xfd\xbd\x6b\x17\xca\xd0\xcb\xb9\x09\x96\x7e\x96\xd1\x24\x12\x63\x67\xa4\x2e\xe3\x34\x83\x89\x28\x00\xd0\x7c\x36\xa5\xda\x2e\x68\x27\x34\x3b\x14\x13\xaf\xf5\x64\xf5\xa9\x1f\xae\x68\xaa\xed\x16\x0d\x00\x34\x89\x56\xdb\x05\xf9\x28\x2a\x2f\x80\x3d\x5c\xb0\x51\xf1\xe7\x77\x89\xff\x99\x7f\xde\x91\xcd\xb3\x87\x4b\xba\x0b\xda\xef\x3f\xba\xf3\x49\xd1\xc9\x2a\x09\x77\x41\xeb\xfb\xcb\xa0\x5f\x94\xcd\xfc\xcc\xdf\x85\xff\x76\xe7\xa3\xdf\x91\x34\x4b\x82\xe8\x24\x38\x7e\xd8\x41\xda\xbb\x2a\xc4\x27\x0c\x9b\xfe\x59\x1a\x47\xba\x2c\x9f\xc6\x51\x46\xa3\x4c\x54\xf9\xcb\x65\x18\x4c\x7d\x1c\x63\xbf\x02\x96\xae\xa6\x53\x9a\xa6\xbb\x50\xcc\x5b\x07\x31\xca\xa9\x92\xd3\x95\x66\x09\x4c\x40\xd3\xf6\x2a\xa5\x73\xea\x87\xd9\x1c\x2b\x32\x7a\x9e\x19\x02\x97\x02\xb4\x9c\x62\xe5\xd1\x49\x4b\xd5\x71\x9c\x74\x10\xc7\x03\x08\x22\xa8\x77\xc9\xd1\x2f\x69\x32\xa5\x11\x2e\x93\xb9\x57\xaf\x6a\x2b\xcc\xd2\xb6\xd2\x19\x0d\x0f\x8f\x56\x59\x16\x47\xb5\x11\x88\xea\x38\xa2\x13\x5d\x57\x8b\x6b\x10\x59\xe2\xa7\x38\x48\x7d\x3f\x3d\x3d\x81\xb3\x60\x96\xcd\x27\x9a\x45\x17\x1a\xcc\x69\x70\x32\xcf\xc4\xcb\x69\x40\xcf\xfe\x6b\x7c\x3e\xd1\x4c\x30\xc1\xf2\xc0\xf2\x34\x98\x86\x7e\x9a\x4e\xb4\xa3\x00\x8e\x02\x83\xe1\xd1\xf8\x8a\x19\xc1\x6c\xa2\x21\xef\x3e\x40\x2e\xd6\xe0\x38\x08\xc3\x89\x36\x5d\x25\x09\x8d\xb2\x5b\x71\x18\x27\x1a\x9c\x2f\xc2\x28\x9d\x68\xf3\x2c\x5b\xee\xf6\xfb\x67\x67\x67\xe4\xcc\x21\x71\x72\xd2\xb7\x4d\xd3\xec\xa7\xa7\x27\xda\xc1\xfe\xd2\xcf\xe6\x30\x9b\x68\x1f\x0e\xc8\x00\x06\x64\xf0\x1e\xc1\x07\x46\x01\x78\xe0\x9d\x7a\x7e\x59\x60\x58\x60\xde\x55\x0b\x80\x0c\x0c\x32\x78\xb4\xb0\xb1\xa0\x5a\x4e\x06\xdb\x35\x75\x80\x0c\xca\x62\x13\xbb\x50\x1b\x22\x32\xf3\xae\xf7\x48\xeb\x0b\x52\x71\x9c\x46\xb2\x0a\xe9\x44\xa3\xa7\x34\x8a\x67\x33\x8d\x91\x6f\xb9\x64\x00\x8e\x6f\x81\x55\x74\x68\xdd\xb6\x9c\xd3\xb1\x6f\x83\x2d\x8a\x6c\xb0\x6f\x0f\xd4\x77\xc3\xbe\xeb\xce\x0d\x32\x50\x9b\x19\xd6\x5d\xbb\x7c\xc7\x92\xdb\x5e\xf5\x7d\x5e\xa9\x07\x6b\xee\xa8\x18\xf0\xbf\x53\xeb\xd1\x87\x2e\xb1\xac\x11\xb8\x1f\xb8\xe0\x12\x73\x30\xbe\x6b\x95\xc4\xb1\x76\xf3\x12\x2b\x9b\x9f\xbb\x0c\xec\x03\xcb\x22\xa3\x91\x0d\xee\x6d\xd6\xfe\xd1\x87\x38\xb5\xce\x5d\x7b\x6e\x59\xa7\xd6\xdc\xb0\x2c\x36\x13\xb8\x76\x07\x9b\x38\xee\x38\x4e\xa0\x13\x4c\xcc\x3d\x08\xf6\x91\x53\xee\x3d\xb8\x4f\xd2\xb9\x9f\xcc\x52\x12\xd2\xe8\x24\x9b\xef\x05\x3b\x3b\x55\x59\x01\x58\xee\x4c\x0a\xbd\x5b\x6d\x74\x2f\xb8\x4f\x02\xd4\x84\x04\xb5\x24\x11\x22\xd5\x55\x3b\x07\xc8\xd2\x9d\xc9\xa5\xcd\xb2\x38\xf3\xc3\xc3\x20\x3a\x64\x0a\x59\x6d\x7c\xa1\x3c\x2f\x13\x14\xf7\x65\xbf\x9d\x6c\x05\x10\xf7\x13\x04\xde\x07\xcf\x6c\x0c\x45\xaa\x8c\x99\x1f\x9d\xd0\xa4\x22\xaf\x00\xb3\x38\xa2\x28\x8a\x4c\xdf\x70\x88\xca\x44\xc2\x65\xe2\x7e\xd1\x42\xc2\xc1\x65\x24\x9c\xf9\x49\x14\x44\x27\x97\xd1\x20\x40\x7e\x25\x11\x13\xb0\xcc\xcd\x64\x34\x95\x67\x83\x0c\x01\x72\x29\x19\xfb\x3e\xcc\x13\x7a\x3c\xd1\xaf\xeb\x42\x31\xe9\x25\x80\x0e\x59\x90\x85\x74\xa2\xff\x86\x86\x34\xa3\x10\x64\x7a\xa1\xa8\x74\x4d\x28\x2a\x4d\x3f\xd0\x76\x98\x1a\xdb\xd1\xf6\xfb\xfe\x41\x6d\x60\xca\xcb\x91\x9f\x3e\xa0\xd9\xdf\x40\x69\x1e\xc7\xe1\x8c\x26\xb6\x11\x2f\x69\xc4\x36\x7b\x36\xec\x5f\xaf\x33\x37\x2a\x22\x70\xc8\xe0\x3d\x8b\x0c\xc0\x12\x3a\xcc\x02\x14\x62\x7b\x6e\x93\xa1\xe7\x4e\xc9\x78\x30\x42\x3d\x41\x86\x1e\x19\x78\x60\x13\xc7\xb2\xc0\x22\xd6\xc8\xbd\x35\x24\xe3\xd1\x00\x1c\xe2\xb9\x23\x18\x11\x77\x04\x2e\x8c\xc1\x9d\xbb\x0d\x7c\x16\x53\xd5\xa7\x04\xd1\x0d\x86\xc4\xf6\x06\x64\xec\x92\xd1\xd0\x23\xa3\x81\x87\x70\xae\x17\x1a\xc4\x73\x61\x40\x2c\xdb\x7d\x8f\x75\x5f\x36\xb6\xc9\xd0\x71\xc0\x1a\xdc\x76\x88\xed\x79\x7e\xa5\xd6\xb0\x89\x3b\xb2\x0c\x9b\x58\x63\x86\xc1\xe0\x18\x6a\xdd\x83\x47\x2c\xf7\xae\x43\x06\x8f\x3e\xb4\xc1\x9b\x5b\xf6\xa9\x51\xd5\xe2\x6c\x5f\xb8\x3d\x9e\x1a\x64\xec\xb9\x60\x1a\x16\x19\x5a\x06\xf1\xec\x31\x62\x1e\xba\x86\x45\xac\x81\x7b\xcb\x23\xce\xd0\x05\x87\x38\x0e\x52\x3a\xb2\xc1\x81\x01\xb1\x3d\x17\x9c\xdb\x76\x1d\x1f\x19\xdc\xf5\x1e\x2d\x0c\xe2\x78\x43\xb0\x2a\x55\xee\x18\x27\xd2\x0e\xcb\xf1\xaa\xd4\x9a\xc0\x46\x09\x96\x3b\x1f\x13\xd7\x1b\xf9\xd5\x4a\x8b\xb8\xa3\xb1\x61\x11\xc7\x72\xc3\x72\xb8\x25\x7a\xb0\x5c\xd6\xe5\xf0\xb6\x45\x3c\xc7\xb9\x5a\xfb\xa2\x9d\xb3\x83\xb2\x12\x06\x07\xda\x8e\x64\xe3\x1d\xd0\x6e\x44\x47\xe9\x72\xaf\x10\x84\xfd\x74\xe9\x47\x52\x8a\x8e\xc3\xd8\xcf\x8c\x04\xd9\x5a\x3f\x40\x10\xd5\xea\xce\xd2\x6e\xd9\xbe\xc4\x52\x4a\x1e\x62\xeb\x23\xba\xaa\x38\x15\x94\xcc\x82\x53\xd9\xd1\x32\x89\x4f\x12\x14\x73\xac\x7e\x88\xd2\xca\x85\x69\x17\x9c\xe5\xf9\x9e\xbe\x3d\x02\xe3\xc8\x4f\x00\xc9\x58\x4e\x91\x38\x1d\x92\x38\xa4\x65\xf5\x91\x9f\xe8\xb2\x07\x26\xba\xbb\x1c\x38\x61\xd0\xff\xb4\xa7\x83\x9f\x04\xbe\xc1\x8c\xe2\x28\x3e\x9b\xe8\x4a\xad\x5a\xb7\x08\xa2\x89\x6e\x56\x4a\xfc\xf3\x89\x6e\x99\xa6\x7e\xb0\xdf\x9f\x05\xa7\x1b\x28\x66\x55\xfb\x47\xc9\xc1\x7e\x3f\x0c\xaa\x30\xd7\x9a\x7a\xf4\xdd\x8e\x7e\x3d\x88\x66\x61\x90\x66\x7a\x97\xcc\xb3\x45\xd8\x49\xb3\xa4\xd8\xe2\xb8\xc2\xbd\xe8\x56\xbd\x83\xd2\xe9\xea\x6c\xef\x16\x30\xef\x4d\x93\xb6\xfd\xdb\x6b\xd7\x2f\xa7\xb5\xc2\x2b\x8c\x75\xbe\x93\xc9\x6d\x7f\xb6\x5a\xc2\x3e\x0c\x1b\xfb\x59\xcb\x8e\x56\xa9\xbf\xb8\x14\xe3\xc1\xe5\x18\xe5\x56\xfd\x52\x18\x47\x83\x4b\x30\x0a\xfb\xe3\x12\x84\x05\xbf\x86\xc1\xc1\xfe\xdc\x95\x42\x96\x2e\xfc\x30\x84\xe3\x38\xca\x8c\x33\x26\x9e\xc6\x51\x1c\xce\xb8\x66\x90\xfd\x47\xfe\x82\xa2\xb4\x40\x1f\xd4\xe2\x60\x79\xb5\x72\x91\xb0\xb3\x0c\x4a\xd5\xb1\xdf\x9f\xbb\x55\x41\xb9\x5c\x01\xe8\x0d\x05\x62\x36\x35\xc8\xeb\xd0\x21\x05\xf5\xab\xe5\x25\xba\xa4\x06\xb5\xad\x4e\x69\xeb\xa0\x45\xcf\x6c\xa7\x69\x24\x2b\x28\x85\xe5\xf2\xa3\xce\x41\x6d\xb0\x95\xd2\xb9\xf6\x6e\x67\x16\x4f\x57\x0b\x34\xbc\x99\xba\x78\x3f\x49\xe2\xa4\x23\x25\xb3\x43\x7b\x70\x3e\x4f\x24\x2b\x22\xe3\x9f\xcf\x13\x92\xb2\x90\x10\x46\x5c\x5c\xd3\x2a\xf9\xf4\x2c\x88\x66\xf1\x19\x09\x63\xee\xcd\x23\x85\x7d\x41\xe2\xc5\xb5\x8b\x4a\xf0\xe7\x0f\x29\x4d\x7e\xe7\x2f\xe8\x4b\x68\xb8\x55\x4a\x93\xb7\x46\xc1\x49\x81\x87\x1b\x37\x58\x6f\x4c\xea\x54\x00\xbe\x8e\x38\x66\xac\xd1\xbb\x04\x6d\xe9\x4e\x09\xda\x5c\xf9\xca\xba\xaa\xcb\x9a\x50\x7f\xf6\xb0\x5c\xd2\xee\xb6\x13\x9e\xd0\x65\x9c\x06\x59\x9c\x04\x6f\xc1\xce\x72\xc5\xd6\xb2\x45\xc8\x07\xf7\x4f\x98\x14\x42\x8c\xaf\x65\x7b\x80\xf4\x2c\xc8\xa6\x73\xe8\x60\x79\xb5\x31\xc0\xd4\x4f\x29\x68\xc7\xd5\x20\xdd\xa5\xde\x08\x71\x2a\xfe\x08\x71\xb6\xf3\x48\x82\x90\xa6\xaf\xec\x7d\xc0\xe5\xee\x87\x0b\xf6\x7c\x58\xc6\x38\xc0\x06\xfb\xd4\x32\xd5\xa0\x07\xd8\xb7\x5d\xf5\x1d\x83\x20\xe5\x3b\xd8\x86\xfd\x68\x61\x82\x55\x46\x27\x30\x94\x82\x38\x94\x70\x05\x58\xf3\xa1\xfa\x6e\x58\x77\xdd\xf2\x1d\x83\x28\xb7\x5d\xb4\x95\x05\xb1\x48\x98\x07\xe6\x95\x84\x9d\x1a\x4a\xb7\x50\x0d\xc5\x98\x46\x35\x14\x83\xa1\x22\x4b\x1d\x09\xa3\xbc\xdd\x3e\xc7\xff\x8e\x12\xea\x3f\xa8\x16\x5e\xb4\x2c\xff\x2a\x09\x5f\xf7\xfa\x87\x41\xf4\xe0\xd7\x2e\x3f\x9b\x51\xe2\x0c\xd0\xe5\x19\xdc\x76\x7d\x07\x1c\x31\x6b\x26\x78\x73\x47\x79\xb7\xc9\xc8\x31\x5c\xe6\x8b\x99\x23\x0f\x7d\x33\x6b\x48\x4c\xcb\x20\xf6\x80\x98\x8e\xf5\x5e\x39\x7f\x43\xb0\x4c\x32\x28\xa6\x94\x2d\x84\xe1\xce\x2d\x32\x70\x06\x53\x62\x5b\x23\x83\x38\x43\x8f\xb8\xe3\x81\x41\x86\x96\x4b\x46\xb6\x61\xd5\x16\x79\x8c\xe4\x94\x9d\x1b\xd8\x39\x20\x0a\x73\x3c\x52\x7a\x1a\x83\x47\x06\x73\x47\xed\x08\xdc\xb9\xc1\x7a\xf2\x5d\x62\xda\x18\x33\x13\xc0\x06\x19\xd9\x60\xdd\xb6\x6c\x81\x96\x71\x87\xe1\xdd\x1e\xff\x9a\xb5\x9e\xd1\x63\x7f\x15\x66\xf0\x9a\x57\x7a\x1a\xc6\xab\xd9\x6b\x8a\x33\xb8\xc4\x35\x3d\x74\x9e\x5d\xfb\xbd\x01\x19\xa0\xeb\x3c\x70\xc4\xfc\x8e\xc0\x9e\xda\xc4\x1b\xe3\xb4\x92\xb1\xed\x80\x8d\x91\x00\xcf\x03\x97\x0c\x86\xe3\x5b\x96\x4b\x86\x83\x11\x78\x64\x64\xba\x48\xf7\x88\x58\xce\x10\x1f\xc6\x64\x38\x74\xf0\xc1\xb2\xc8\xc0\x1b\xa3\xf3\x3b\x30\x6d\xb0\x1c\x0c\x1a\x78\xa3\x21\x58\xce\x6d\x87\x0c\x47\xd6\x2d\x8b\x0c\xcd\x11\x56\x98\x60\x59\xc4\xf1\x3c\x30\x61\x4c\x1c\x6b\x34\x65\xce\xbe\xe7\x80\x85\x6e\xb7\xe1\x10\x1b\xbb\x27\x63\xd7\x36\x1c\x32\x18\x3b\xc4\x72\x1d\x83\x8c\x3c\x87\x78\xe3\x11\x82\xda\x08\xea\x7a\xae\x61\x13\x67\xe4\x3c\x5a\x10\x6f\xe0\x90\xe1\x60\x38\x35\xf0\x17\xdf\x58\xb4\x80\x41\xb9\xe2\xd1\x26\xe6\xc0\x3b\x25\xae\x3b\x0a\x0d\xe2\xba\x03\x62\xba\xe3\x5b\x36\x31\x3d\x97\x0d\x6a\x00\x16\x0c\xc9\x78\x60\x23\xab\x21\x51\xc8\x62\x26\x19\x8e\x30\xd0\x81\xfd\xd9\xc0\x46\x01\x96\x3d\x1f\x91\xb1\xe9\xdd\xb2\x1c\x32\x1e\x61\xb9\x35\x40\xc8\xf1\x68\x04\xd6\x80\x4f\x07\x1b\x90\x6d\x79\x86\x45\x4c\xdb\xb0\x89\x6d\x8f\x90\x54\xcb\xe1\xcf\x18\x3e\xc6\xe8\xc7\x2d\xcb\x26\xd6\x68\x04\x28\x16\x0c\x87\x63\x8f\xc0\x81\x11\x38\xbe\x8b\xab\xe3\xca\xd5\x31\x0d\x9b\x8c\x5d\x16\xf2\x79\x75\x0e\x56\x9f\xc5\x36\x59\xf3\x21\x9a\xde\x43\x4b\xf4\x8e\xd9\x0e\x80\x26\xa8\x71\x92\xc4\xab\xa5\x11\x64\x74\x51\x7f\x37\xb8\xbd\x51\x8b\xe6\xc9\x0d\x36\x98\xc9\xb0\x5e\x6b\xac\xa3\x0a\x86\x21\x3f\xe6\x68\x34\x8d\x65\xb4\xa3\x90\x1c\x61\x0f\xfb\xcb\x25\x8d\x66\x15\x8b\xb8\x1c\xf5\x85\xf0\xe2\xd1\x8a\x62\x0f\xa5\x9d\xca\x51\xaa\xbe\x39\x2f\xa9\x9c\x73\xee\x71\x33\xb7\xd6\x65\x1c\x75\xf4\x69\x18\x4c\x1f\xe0\xc9\xa5\x4f\xb0\x26\xd5\x7b\x85\x91\xd2\x29\xec\x85\x53\x3f\x01\xac\x45\x0b\x0f\x26\x40\x49\xe6\x27\x27\x34\x23\x38\xd6\x94\x66\x24\x98\x49\x03\xac\xa3\x5d\x0f\x63\x7f\x86\x6e\x25\x1a\x78\x8b\xf8\x94\xde\xc2\xe5\xe9\xe8\x41\x74\x1a\xa4\xc1\x51\x48\x75\x39\x06\xa4\x26\xa5\x21\x9d\x66\x74\x96\x46\xfe\x52\x3a\x05\xda\x71\x12\x2f\x60\x3f\xcd\x92\x38\x3a\x39\xd0\x76\x64\xcf\x18\x40\x95\x85\x62\x90\x0c\xc5\x21\x02\xe8\x5d\x82\x07\xc3\x12\x56\xad\x8f\xfc\x65\x3a\x8f\xb3\xc3\xe3\x38\x59\xe8\x35\xaa\x66\x46\x14\x47\x54\xef\xb2\xa1\x74\x74\x6c\xae\xf7\xa0\x05\x0b\x16\x1d\x72\xbe\x48\x7f\x1d\x92\x20\x3a\x8e\x71\xbd\x67\xb3\x5a\x73\xde\xd9\xc7\x74\x19\xdf\x9a\xd3\xe9\x83\xb4\x3e\x98\x3b\x62\x20\xec\xf0\x5a\xd6\xf5\xa0\xba\xbc\xe5\x68\x83\x30\xa3\x89\x58\xe5\x74\x75\xb4\x08\xb2\x4b\xd7\x16\x26\xad\xb3\xa5\x0c\x49\x90\x81\x2e\x02\xbe\x4b\x04\x2d\x84\x09\xa2\x4a\x0e\x46\x3d\x9e\x91\x65\xc2\xfe\xfd\x0d\xdf\x8a\x3a\xad\x64\x2f\xe2\x84\xd6\x58\xb3\x49\x73\xa5\xc3\xab\xa8\xee\x41\x5b\x07\x1c\x22\xa2\xe7\x99\xde\x95\x84\xf4\xfb\xb0\xfe\x22\xff\x25\x7f\xbe\xfe\x22\x7f\x91\xff\x88\x2f\x3f\xe7\x4f\xf3\x5f\xd6\xff\x96\xbf\x58\x7f\x99\xbf\xc8\xbf\x85\xfc\x97\xf5\xe7\xf9\xf3\xf5\xff\xca\x5f\xe4\xdf\xe7\xcf\xd7\x5f\xc2\xfa\x8b\xf5\x97\xeb\xcf\xf3\xa7\xf9\xcf\xf9\xf3\xf5\xff\xce\x9f\xe6\x3f\xe5\xcf\x7b\xe0\x1f\x67\x34\x01\x03\x10\x3f\x6f\xf3\x2c\xff\x7e\xfd\x75\xfe\xfd\xfa\xab\xf5\xbf\xe7\xcf\xf2\xbf\xd4\xda\xad\xbf\x2e\x5d\xd0\xc6\x6c\xf2\x65\x66\x28\xe5\x04\x6c\x2b\x67\xdb\x39\x54\x72\x6e\x9a\x87\xf7\x15\x73\x41\xc3\xf9\xd4\x76\x0b\xc6\x96\xc0\x00\x5a\x31\xbb\xe5\xf9\x3e\x4e\xfa\xf1\xa1\x70\x12\x51\x34\x0b\x67\x0a\xe1\x33\x3f\xa3\x05\x10\xba\xed\x6d\x50\x4a\xb6\x00\xc7\xc6\x8e\xd7\x5a\x00\x51\x61\x14\x40\xf8\xd2\x02\x93\xc5\x05\x44\x16\xb7\xd4\xa7\x71\x92\x15\x10\xf8\xd2\x02\xc3\xd6\x40\xdb\xe5\xcb\x5b\x75\x6f\xdf\x5c\x0f\xb4\xdd\x01\xad\x14\xcf\xc2\x60\x1a\x47\x0d\x13\xb1\x62\x20\x6e\x65\x1e\xce\xe2\xb3\x08\xf9\xf6\x35\x59\x88\x04\x2d\x98\x71\x79\x56\x22\x8e\xe5\x6d\xe5\xb4\x1a\x0b\xad\xb9\xa5\x78\x59\xe8\x75\x9d\x1a\x95\xe3\x17\x56\x77\x8a\x45\x85\x05\xcf\xbc\x48\x5b\x7d\x37\xec\x46\x2b\x76\x08\x74\xf5\xc9\xfd\x90\x78\x2e\xb3\x38\x47\x03\xb7\x6c\x6e\x02\xb3\x30\xcd\xd0\x31\x1c\xa5\xd4\xc0\x52\xf6\xf3\xc1\x08\x0f\x72\x4c\x62\x8f\x9d\xbb\x96\xda\x31\x3a\x86\xe6\xe9\x88\x0c\xc7\xce\x07\x03\xe6\x22\x8d\x88\xe5\x16\x39\x05\x96\x40\x82\xff\x0f\x1d\xa8\x1e\xe9\x6c\x8e\x34\x90\x42\x2d\xa8\x5c\x22\xf8\x04\x26\x35\x98\x7b\x0f\xee\xab\xc6\x0d\x03\x12\x75\x98\x8b\x55\x00\xd6\x81\xe6\x33\x3f\xc3\xa3\xe1\x88\x9e\xc1\x6f\xfc\x8c\x76\x52\x8c\xe2\x25\xd9\x61\x16\x2c\x68\x97\x64\xf1\x07\xf1\xd4\x0f\xe9\x1d\x26\x15\x1d\x2d\x59\x19\x1f\xff\x41\xeb\xc1\x63\xac\xfe\x1f\x71\x44\xd1\x08\xda\x05\x2d\x9d\xa3\xf8\x5e\x74\xeb\xe8\x91\x6f\x71\x2b\x4e\xb3\x38\xa1\xe2\xf4\xa8\xce\xe1\x3c\x7e\xc8\xfa\x45\x52\x26\xa0\xdd\xf9\xc3\xad\x5b\xef\xdf\xb9\xa3\x55\x07\x0e\xf0\x37\x92\x82\x94\x26\xa7\x34\x81\x4a\x02\xd0\xeb\x11\x08\x8b\x38\x0e\x3a\x0f\x9e\x37\xbc\xc5\x9f\x2d\x62\x8d\x31\x41\x03\x7d\x04\x13\x46\x60\xa6\x1e\xf1\xf0\x84\x91\x95\xf3\x67\x06\x7f\xd7\x45\xaf\xc9\xf3\xf0\xe0\xd5\x1d\xba\xc8\xae\xde\x48\x00\xc8\x7f\x39\x1a\x89\x00\xd1\x23\xac\x23\x9e\xdd\xbb\x0c\x11\xc6\x56\x1c\x7c\x38\x75\x24\x0d\x26\x73\x59\x78\x6b\xcb\x06\xfc\x99\x3a\xc4\x43\x8a\x10\xd9\x00\x9d\x9e\xb1\xc3\x3b\x31\x10\xc9\xe0\xae\x87\x67\xa6\x53\x83\xb8\x0e\x71\x6c\x83\x8c\x1d\x8b\x0c\xd0\x9b\x72\x07\xc8\xf7\xb7\x50\x98\xd0\x05\xf2\x46\x2e\xaa\x00\x46\xe3\x08\x46\x53\xc3\x62\x2f\xa6\xe1\x90\x91\x65\x10\xc7\x1a\x1a\x03\x62\x5b\x06\x1e\x1d\xfb\x1e\x19\x98\x48\x3e\xfe\x0a\x45\x40\xdc\xc1\xd0\x20\xc3\xf1\xa3\x85\x25\x4f\x69\xcb\x7e\xad\xb1\xe8\x78\x58\xf4\xcc\x8e\x52\x5d\x32\x60\x7e\x9f\x6b\x23\x5a\xec\xc0\x1c\xb3\xa7\x7a\xe7\x5e\xb5\x73\x0b\xfb\xb6\xea\x5d\x9f\x5a\xc4\xf3\x3c\x39\x55\x2e\x19\x99\x9e\x9c\x2a\x0f\xa7\xca\xe3\x2b\x66\x28\x2b\xc6\xe6\x68\x78\x6a\x60\xcb\xc1\x46\x1f\xab\x29\x05\x2d\x0e\x92\x72\x76\x02\x47\x59\x99\xe3\xf0\xdf\x31\x17\x6b\x9a\x95\x49\x0e\xdc\x05\x98\xe8\xd7\x57\x4b\x14\xe1\xc3\x20\x4a\x33\x3f\x9a\x52\x59\x1d\x9f\x9c\x60\xbb\x45\x3c\xf3\x43\x51\x86\x86\x03\x3f\xac\x90\x26\x04\xba\x48\x35\x37\x4b\x2a\x0a\xe9\x64\xf1\xbd\xa7\x2d\x79\x02\x68\x98\xd2\xd7\x2f\xa1\xe2\x50\xeb\x1f\x02\xfa\x0f\x01\x7d\xad\x02\x7a\x71\x6d\xa3\xa8\xee\x6c\x25\xab\x2a\xbb\xb2\x3c\x26\x29\x4a\xf5\x44\xa5\x6c\x4e\x8b\xcd\xf9\xd5\x45\xf3\x06\x6e\xc1\xe9\x9e\x90\x4b\x85\x78\xdc\x77\x67\x34\xf3\x83\x10\x93\x4b\x53\x22\x32\x83\x45\x52\x1b\x36\x06\x51\xd4\x63\x87\xa1\xa9\xc8\x7a\x3b\x64\x29\x73\xac\x9e\x17\xf0\x6a\x25\xb7\x39\x25\xb3\x55\xc2\xac\x5b\x4c\xac\x5b\x04\x61\x18\xa4\xd0\xc7\x44\x30\x93\x65\x8c\x54\xf3\xbd\x8a\x13\x4b\x16\x27\x1a\x6c\x8a\x13\xc1\xd6\xe1\x20\x31\x8b\xda\x8e\xb0\x15\x76\x58\x72\x17\x57\x4f\x65\xbc\xa2\x36\x51\x45\xcc\x02\xa6\x09\xf5\x33\x3a\x03\x3f\x63\xe3\xe2\x96\x0f\x8e\x76\x9f\x1f\x7f\x0b\xfa\xd8\x2a\x2e\x56\x19\x95\xe7\xdf\x62\x2a\x39\x2e\x84\x64\xc5\x75\x16\xd1\xf6\xfb\xf3\x41\xe3\x4c\x56\xc9\x30\xab\xfa\x8c\x65\xf8\xa1\xe1\x30\x4a\x8b\xa8\xe2\x71\x96\x31\x2b\x39\xb8\x4b\xe3\x56\x4d\xdd\xdc\xd2\xb4\x71\x04\xac\x0a\x81\x0a\xcf\x39\xa3\x72\xd8\xc8\x4a\xe0\x66\x03\x2d\x84\x81\xde\x55\x59\x2d\x3e\x2e\x0e\xd1\x45\xa3\xdd\x32\x3a\xd1\xe8\xa8\x19\x1d\xe8\x89\x73\x50\xf4\xe4\x9f\x3c\xc1\xa6\x84\xef\x6d\xd5\xe8\x4d\x0f\xde\x29\xe0\x0a\xe4\x17\xd2\xfd\xa2\x78\x7a\x7d\x99\xf3\xf5\x52\x8b\xf3\x31\x4d\x57\x61\xf6\x5e\x48\x93\xac\xa3\xfb\xf8\x8f\x90\x7a\x49\x6c\x42\xd3\x65\x1c\xa5\x94\x39\x94\xac\xef\x92\xa4\x6b\xea\x19\xbb\x7e\xfd\x38\x88\x66\x32\x6a\xb2\x45\xa4\x68\x73\x20\x81\x21\x62\x0e\xfa\x15\x51\x84\xba\x57\x5f\xb4\x93\x0e\xf7\xdb\xed\x58\xcb\x52\x96\xb4\x5c\x75\xb8\x8e\x51\xc9\xa9\x2d\x79\xdb\x63\xe9\x6c\xb1\xfa\x16\x47\x4b\xa8\x02\x98\xc0\x71\x8b\x13\x03\x37\xab\x9b\xc7\x6b\x31\xe8\x8e\x59\xf4\xb8\x65\xcf\x38\x2e\x3c\x3f\xb5\x12\xd9\x44\xd6\xb3\xb5\x67\x95\x07\x62\x20\x6c\x4b\x81\x5d\xd0\xda\xd5\x79\x96\x1c\xec\x67\xb3\x83\x6a\xb7\xfb\xfd\x6c\xa6\x14\xab\x9d\xd6\xaa\x8a\xfe\x2a\xe5\x77\xa6\x73\x3a\x5b\x85\x94\xf9\xa0\xc7\xaa\x0f\xda\x00\xed\x1c\xb3\xc4\xf0\x22\xb7\x1b\x67\x3b\x5a\x85\x21\x4e\xf4\x4d\x64\x76\x35\x97\xb1\x06\xdb\x6d\x47\xc7\xb6\xbb\x06\x1e\x59\xd1\x6c\x23\x57\xbc\x28\xef\x67\x49\x55\xed\x37\xd8\x8c\xad\x8f\xe4\x34\xa6\x13\xd2\x2a\xab\x29\xf3\x5b\xd9\x8a\x84\x6a\x51\xfa\xae\xce\x38\x4c\xe3\x10\x13\xaa\x26\xba\x57\xa6\x13\x89\x1e\xee\x61\xaf\xf7\xb7\x20\x53\xe6\x9d\x90\x2c\x59\x45\x53\xdc\x26\xb7\x23\x4e\xec\x93\x15\x32\x86\x0a\x19\x4c\x64\x2e\xdf\x0e\xb0\x34\x9d\xc7\x67\xd1\x16\x24\x22\x19\x13\x94\xe3\x26\x75\xdb\x13\xf7\xbb\x38\x9b\x07\xd1\x09\x30\xda\xae\xe8\x94\xa9\x68\x84\x93\xf7\xab\x20\x3b\x8a\x67\x0f\xdb\x37\xcf\x06\xf0\xa6\x83\x8a\x97\xdf\x9f\x7e\xed\x96\xc3\x37\x9d\x2b\x8f\x02\x0a\x02\xd4\xfe\xa6\x61\xda\x63\xb6\x2d\x27\x08\xb7\xca\x84\x55\x6b\x62\x16\x74\x25\xd7\x4e\x63\xfb\x21\xe8\x3b\xd3\x30\xdd\xd1\x41\x90\x1a\xa4\x8b\x20\x65\xfb\x28\x1c\xfb\x33\xca\xd6\x5b\x3b\xd0\x77\x10\xeb\x8e\xbe\x2f\x6c\x28\xdc\x54\x26\x1a\x7f\x29\x1c\xc2\x69\x18\xa7\x54\x63\x0c\x23\xf1\x88\x4e\x34\x9e\x62\x17\xfa\x47\x34\x9c\x68\xb7\x18\xdc\xc1\x3e\x8a\x02\xaf\x98\x07\xb3\x19\x8d\x26\x5a\x96\xac\xa8\x56\x9a\xcb\x22\xf9\x90\x77\x23\x72\x7a\x71\x49\x2e\xae\x5d\xeb\xf7\xf9\x61\xc1\x8b\xfc\xdb\xfc\xd9\xfa\xf3\xfc\xc7\xfc\x39\xb0\xb3\x83\x5f\xf2\x17\xf9\x0f\x78\xde\x90\xbf\x60\xe7\x0f\xdf\x40\xfe\x9c\x1d\x26\x7c\x09\xf9\xb7\xb0\xfe\x9f\xf9\x8b\xfc\xe7\xfc\x59\xaf\x7a\x7c\x91\xbf\xc8\xff\x94\xff\x8c\xc7\x16\xf9\x5f\xd7\xdf\xe4\xcf\xd6\x5f\xae\xbf\x58\x7f\xd3\x03\x86\xed\xc7\xfc\x29\xe4\xcf\xd8\x29\xc4\x1f\x01\x1b\xe7\x3f\xe4\x4f\x79\xb7\xeb\x7f\x5b\xff\x47\xfe\x73\xfe\xf3\xfa\xeb\xfc\x19\xbb\x56\x39\x65\xc7\x51\x9f\x04\x0b\x8a\x6c\x8e\x2a\xaa\xba\x54\x95\x03\x2b\xc9\x35\xd3\x90\xfa\x09\xb6\x89\x57\x59\x47\xc1\xd0\xdd\xdb\xc2\x98\x40\x03\xb1\xcc\xf3\x7a\x78\xc8\xdb\x5f\x61\x58\x28\xc7\x13\x55\xce\x7b\x5b\x4d\x08\x2c\x4d\x56\x11\xde\xb7\xc1\xbd\xdf\x0f\x53\x25\xf3\x0b\x2b\xa7\xcc\x03\x7b\xac\x2d\xfd\x34\xa5\x33\xb4\xd2\x2a\x31\xc7\x1e\x68\xc7\x7e\x10\x2a\x35\x22\xd6\xd1\x03\x2d\x7d\x10\x2c\x97\x4a\x0d\x53\xb4\xda\xc5\x5e\x73\x63\x29\xec\x17\x95\x60\xd1\xbf\xb0\x5c\x6a\x36\x4b\x49\xb3\x7c\x7a\xf2\x04\xa6\x8a\xe9\x22\x8a\x95\xa1\xca\x29\xa0\x4b\x1c\x11\xc2\xd2\x65\x4a\x16\xfe\xb2\xc8\x1f\x84\x4e\x9a\x55\x29\x10\xe9\x71\x68\xee\xa0\x1f\x9a\xf1\x3d\x09\x9e\x3c\x41\x50\x54\x58\x98\x0f\x73\xb3\xbe\xb2\x45\x55\xb7\xee\x28\x40\x79\xdf\xb6\x9a\x6f\x8c\xbb\x4d\x67\x1a\xa6\xf7\xd2\x8c\x0f\xe2\xbe\xf0\x14\x70\xe3\x2b\xdd\x46\xd8\xe1\x8f\x68\xb3\x84\xfe\x94\x76\xfa\x7a\xff\xa4\x07\xda\x8d\xeb\xce\x78\x4f\x00\xb3\x9d\x2b\xcd\x64\xb2\x73\x47\x62\x44\x9b\x80\x67\x03\x17\x25\xbb\xa0\x11\x42\xb4\x2e\x6c\xb8\x0d\x51\x0d\x86\x2b\x1b\x68\xb1\x93\x4f\xc9\x83\x20\x9a\x35\x8c\x8b\x8a\x29\x34\xe5\xa6\x10\xee\xc7\x0a\x5c\x75\xe4\x82\xa0\xc9\xa4\xe0\x27\xb8\x59\x65\x28\x3e\x95\xe5\x08\x65\x93\x7a\xd7\x7c\x59\x3f\x8b\x83\xa8\xa3\xf5\x40\x8e\xed\xca\x1d\x12\x25\x5f\xe8\x88\xab\xf6\x47\x05\x54\xdf\xe4\xc9\xa1\xf4\x49\x9b\x81\xdd\xde\x2e\x51\xa0\x9d\x22\x99\xf6\xc6\x8d\xb6\xcc\x00\xf5\xe8\x19\x99\xb9\x7a\x54\x8e\xff\x55\xd5\x69\x4a\x33\xa9\x25\x4b\x56\xee\xc2\xe3\x86\x5e\xdd\x83\x8b\x1e\x60\x5c\xb1\xdb\x9c\x86\x72\x8b\x65\x39\xb6\x0d\xa2\x2a\x99\x1e\x7c\xe7\x21\x0a\xc8\x36\x89\x01\x97\x0c\xb3\xd4\xe8\x1c\x1d\x4b\x11\x11\x81\xd2\x4f\xaa\x99\x22\x1c\xa0\x4c\x24\x90\x0d\x26\xa0\xcd\xd8\x2d\x3a\x45\xeb\x6b\x98\x84\xfc\xce\x34\x8e\x8e\x83\x64\xd1\xd1\xfe\x10\x25\xf4\x24\x48\xf1\x60\x5d\x35\x43\x6f\xc2\x6f\xb3\xb4\x88\xb8\xa4\x90\x66\xfe\x43\xd4\x48\x2c\xc8\x95\xc5\x89\x7f\x42\x89\xd6\x2d\x57\x80\x4b\xb0\x9a\x9c\x70\xe9\x56\xc4\x1f\xfe\xbf\xdd\x78\x64\xf6\x30\x9f\x06\xb5\x46\xa6\x8f\x56\x32\xb2\x1f\x6a\xbb\x0a\x40\x33\xe9\x45\x89\xdf\x60\xd6\x32\x9a\xde\xb8\x96\xb5\x31\x22\xb3\x90\x94\x66\x59\x10\x9d\xa4\x3d\x66\x70\xe0\xc7\x0b\xae\x30\x66\x37\xa4\x91\x71\x2a\x4f\x69\x12\x1c\x3f\xdc\x44\x28\x87\xf1\x23\x3f\x7c\xf8\x88\x6e\x02\x6a\xa4\xe6\x6c\xd7\x73\x93\xad\xeb\x48\x37\x5b\xd6\x82\xc7\x75\x58\x15\xbc\x4f\x67\xf5\x11\xd7\x6e\x37\x90\x84\x62\x20\xa9\x53\x83\xaa\x51\x57\x28\x8e\xff\x64\x0f\x40\x24\xfc\x68\x42\x0f\xb3\x88\xa8\xd6\x25\x4c\x37\xb5\x5e\x05\x90\x6c\x80\xc2\x29\xac\x0f\xd4\x1b\xc8\x58\xcb\x43\x59\x29\x23\x47\x24\x4b\x82\x45\xa7\x0b\xef\x54\x5d\xb4\x2c\x79\xa8\x0c\x44\xc1\xc8\x48\x65\xb7\xc0\x37\x20\x2c\x47\x00\x53\x9f\xa5\xd1\xd3\xa4\x12\x12\xe5\xcd\xb8\x2d\xa1\x6f\x76\x47\xd4\xf9\xd2\x0e\xee\x88\x5e\x76\x41\xdf\xa1\x49\x42\x16\x34\x4d\xfd\x13\xba\xa3\x97\xce\x00\x40\x53\x5b\x15\xf3\x58\x9b\x05\xbc\x47\x11\x47\x21\x3a\x85\x41\xda\xd1\x76\xd9\xfe\x42\x67\xaa\xd2\x93\xe3\x22\x12\x16\x26\x80\x6e\xc9\xd6\x6a\x50\xe3\xc1\x6b\x95\x8d\xb7\xd2\x89\x7c\x7e\x36\x64\x01\xa1\x05\x5e\xc0\xe0\x4b\x0b\x8c\xa4\x5c\xdb\x2d\x06\x51\x9d\x8c\x37\x5f\xc7\x5e\x25\xc0\x2f\x13\x45\x7e\x05\x6e\xd4\x77\x36\x88\x6f\x93\x1f\x6b\x72\x8c\xee\xea\x8f\xf9\xd3\xf5\x97\xf9\xd3\xfc\xaf\xf9\x8b\xfc\xbb\xd6\x8c\xb9\x56\x2f\xb4\xea\xb6\xe6\x3f\x32\x3f\xf5\x17\xe6\x95\x1a\xb0\xfe\x57\x74\x74\xf3\x3f\xad\xbf\x66\x9e\x29\xe4\x7f\xce\xbf\xc7\x6e\xd6\x7f\x64\xb6\xcd\xd4\xcf\xfc\x30\x3e\x39\x4c\xe8\x71\x42\xd3\x79\xcd\xbc\x69\x37\x64\x36\x33\xb6\xc0\x72\x28\xb0\xbe\x35\x37\xb9\x5a\xf4\xb5\x68\x85\xd9\xbe\xb7\xf8\x68\x41\x8c\x9e\xce\x76\xf9\x97\x02\x38\x27\x08\x19\x0f\x68\xca\x76\x1f\xb5\xa0\x57\xc2\x95\x36\x17\x02\x95\x6f\x81\xf8\xea\x00\xc2\xc8\xd9\x6d\x39\xb7\xc3\x46\x8b\x54\x55\x76\x35\x8b\xf3\xb2\xa4\xce\xb2\x51\x33\x1d\xf5\xaa\x94\xd4\xff\x97\xfb\xdf\x56\xc9\xb0\x38\x9c\x43\x9e\x38\xc1\x79\x9b\x47\x92\xc8\x51\x4a\x78\x69\xaf\xbe\x53\x16\xad\xc4\x54\x65\x49\x70\x72\x42\x13\x9c\xad\x94\x66\x72\xba\x5a\xd5\x83\xae\x48\x73\x55\x7c\x51\x9a\x31\xec\x84\xb2\xf7\x1f\xad\x62\xcb\x63\x55\xdf\x61\x88\x6a\xfd\xc5\xfa\x0b\x30\x30\x2c\xf5\x03\x30\xe9\xfd\x2e\x7f\x81\x82\xfb\x0c\x0e\x53\xf1\xa1\x2e\x2e\xd6\x80\x31\xad\xaf\x40\xcd\x3b\x65\x06\x41\xb1\xda\x5b\x18\x05\x2f\xc5\x24\x5b\xed\x6b\x12\xcd\x76\xbb\x1a\xf6\x2f\x01\xf1\x44\x4b\x7c\xfa\x8a\x6f\x65\x91\x12\x7d\x65\x46\x04\x49\x97\x61\x90\x75\xb4\x9e\xd6\xad\x47\x2c\xd0\xcf\x13\x21\x85\x54\x18\x2f\x7b\x70\xd1\x25\x3c\x79\x7b\x13\x24\xb7\x6f\x10\x50\x21\x22\xa1\x7e\x1a\xcb\x43\xb5\xe8\x90\xbf\xb6\x6c\xa8\x41\x34\x0d\x57\x33\x7a\x78\x12\xc6\x47\x7e\x78\xa8\x66\xe2\x46\xa2\xb0\x61\x48\x54\xd9\xf8\x6d\xd7\x8d\x52\x77\x34\x35\x1d\xd3\x86\xf1\x71\x59\x21\x56\x5a\x06\x0b\xb0\x5a\x14\x29\xfa\x54\x86\x3a\xf8\x97\xd7\x94\x74\x06\x56\x2b\x3f\x2f\x84\xb8\xff\x49\xf2\xac\x94\xd6\x3a\x77\xb7\xcb\xb5\x3c\x40\x96\x57\x3b\x40\xe8\xf0\x7b\x95\x93\x38\xe9\x30\xeb\xf7\x0b\xd9\xea\xee\xfd\x3d\xab\xc1\x5a\xb6\x40\x25\x86\xe1\x93\x4a\x82\xcb\x15\xf1\x8b\x4d\xd1\x08\xac\x2e\x95\x84\xc4\x76\x49\x03\xbc\xe8\x22\x37\x9d\x32\x32\xd1\x92\x56\x03\xf5\x34\x90\x9b\xaa\x39\xbe\x69\xe8\xaf\x14\xa1\x90\x1e\xa6\xec\xed\x95\x54\x58\xd1\x78\xb7\xa0\xba\xba\x68\x6f\xa5\xd0\x17\x9c\x5b\x95\x79\x39\x03\x28\x92\x98\x48\x45\x33\x3a\x7b\xcb\x65\xad\xfc\x18\x4c\x8b\x98\x89\xcf\x5d\xb5\x09\xd9\x34\x8e\xd2\x38\xa4\xec\xbb\x94\xb4\x5b\x30\x6b\x71\x51\x6c\xb3\x18\xf5\xfb\xf0\xc9\x47\xbf\xf9\x68\x17\x82\xc5\x82\xce\x82\xcc\x0f\x1f\x02\x8f\xef\x60\x76\x18\x3d\xdf\x8e\xf1\xb7\xcb\x38\x61\x30\xbb\x8c\xaa\xea\xf4\xbc\xf9\x5c\xbd\xd5\x69\xa8\xca\x3b\x97\x1f\x89\xb2\x5b\x83\x92\xe7\xff\x1e\xce\x45\x5f\x5a\x68\xfe\xc6\x13\x72\x99\x77\xfc\x77\x34\x3d\xdb\x4b\x3b\xf3\x30\xea\x29\x3e\x22\xf7\x0b\xb3\x20\xda\xbc\x0c\xaa\xd8\xde\x85\x72\xc4\x63\x01\x8a\xd1\x46\xcc\xd6\xe0\xfb\xa4\x34\xbf\x83\x42\x5d\x56\xac\xf5\x0d\xd0\xaa\xb1\x8e\x21\x05\xe6\x42\xe0\xa9\xf7\x0f\xeb\xaf\xf2\xbf\xae\xff\x88\xf1\x85\xe2\xfa\x5d\xfe\x82\x5d\xcf\xc3\x40\x41\xfe\xed\xfa\xeb\xfc\x4f\xfc\xf2\x1d\xf7\x39\xfe\xba\xfe\x63\xfe\x23\xba\x18\x18\x84\xf8\x4b\xfe\x7d\xfe\x4c\x9c\x63\xff\x05\xb1\xfe\xcc\x0a\x7e\x5c\x7f\x51\x50\x26\x22\x61\x9b\xc7\x81\xd2\x20\x49\x2b\x5c\x28\x61\xe1\xe9\x68\xa6\x47\xb3\x8e\x1e\x2f\x51\x34\xc5\x5a\x88\x18\xb5\xc8\x62\x23\x98\x1a\xa9\xb6\xdf\xfe\x32\xde\x15\x3a\xed\x95\xb7\xf1\x37\x5c\xdf\xe1\x04\x8a\x05\x90\x69\x71\x72\xa4\xe9\x3d\xf3\xbe\xb4\xc8\xe5\x36\x58\xdd\xa1\x44\xa5\x94\x1a\x91\x1d\xc5\x0e\xb0\x65\x95\xd2\x15\x40\xbc\xcc\x3e\xc1\xbc\xcf\x09\x3c\xd8\xd1\xa0\x0f\xda\x8e\x9a\xda\x25\x9a\xe0\x65\x6f\x34\xf1\x53\x9e\x5e\x5a\xcb\xf7\x2a\x09\x61\xe8\xee\x62\xac\x14\x26\xa0\x84\xe2\xeb\x4c\x25\x12\x6a\xf1\x42\xd4\x47\x8c\xb3\x3a\x82\x8c\x5e\x81\xa0\x07\xef\x30\xd6\x7d\xf2\x04\x1e\xe0\xd9\x19\xbe\xb4\x95\x75\x05\xeb\x95\x0c\x50\x30\x82\xca\x94\xef\x76\x92\x43\xe4\xa7\xf2\x0a\xb5\xa8\xc5\x0a\x39\xbb\xbc\xb2\x78\x93\x97\x95\x53\x9a\xfd\x3e\xa1\x78\xe5\x49\xf2\xf9\xc7\x3c\x37\x8d\xd3\x5e\x5c\x47\x17\xca\x07\xe5\xfb\xdb\xfc\x05\x46\x0b\x50\xac\x65\x60\x90\x09\xea\xf3\xfc\x19\xc6\x17\xf2\xef\xf3\x6f\xf3\xa7\xb0\xfe\xb7\xfc\x69\xfe\x5d\xfe\x74\x57\x44\x25\xd6\xff\xca\xa2\x8c\x4f\x01\xcd\xe6\x80\x9e\x1d\x8a\x1c\xb8\x1e\x60\xe6\xca\xfa\xcb\xfc\x59\xfe\x93\xcc\x8b\x63\x59\x2b\x02\x8e\xce\xca\xdc\x08\x35\x69\x45\x25\x9c\x2d\x7a\x0b\xbc\x08\x99\xf0\x8a\x4a\xc4\x44\x86\x5c\x18\x09\xf2\x60\x4b\x17\x08\x45\x36\x8f\xda\x19\x82\xfd\x3e\x4e\xb3\xca\x41\x9a\xf0\xe7\x1f\x5f\xab\xaa\x01\xf5\xc8\xb1\x45\xf8\xa5\x05\xcf\x08\x50\xef\xbe\x17\x52\xa8\xaa\x01\x0e\x25\x0b\x1a\x90\x82\xed\xb4\xdd\x1a\x13\x56\xa1\xb8\x86\xc3\xcf\x04\x55\x17\x97\x31\x63\x2a\x74\x1d\x32\xd6\x45\x75\xe0\x77\xe6\xf1\x99\x9c\xe4\x52\x90\x71\x71\x30\x6f\x8b\xdd\xa4\xcb\x7c\xdc\x70\xc5\xa6\xc9\x5f\xd8\xaf\x91\x2e\xb4\x83\xfd\x6c\x4e\x7d\xcc\x0e\xc0\x8c\xcf\x39\x37\x4e\xf6\xfb\xd9\x9c\xbd\x09\x52\x66\xe0\xa7\x45\xd9\x9d\xe0\x11\xe5\x2f\x98\x2f\x80\x4f\xbc\x3d\xe6\x04\xe0\x6d\xc7\xea\x57\xdc\x1f\x5f\xc8\x12\x74\xe5\xc2\x60\x9a\x15\x3a\xa5\x2c\x78\xf2\x44\x81\x13\x99\x1b\xc8\x22\xf7\x58\x4a\x0b\xd3\x4f\x84\x15\xe2\x47\xad\xa4\xfa\xc1\xfc\x8f\x7b\xf7\xbb\xe4\x38\x4e\xde\xf7\xa7\x73\x25\x90\x73\x8a\x81\x1c\x46\xc0\xbd\x53\x84\xa6\xe7\xf7\x61\x02\xa7\x4c\x6b\xec\x09\xb1\xe4\x48\xfd\xe9\x94\x2e\xf1\xbe\xc0\x46\x5c\x81\xd4\x56\x32\xd7\xa6\xa0\x9a\x7d\xd9\x5d\xd6\x44\x31\x26\x67\x80\xfc\x60\x37\xfa\xae\xd3\x52\xcf\xc9\x5a\xe8\xa0\xeb\x33\x25\xf4\x3c\x48\xf1\x98\x85\x39\x3f\xec\x45\xc4\x7c\xa7\x68\xe4\xfa\x19\x3d\x79\x88\x55\xdd\xe2\xee\x0a\xc3\x57\xd6\x61\x16\x88\x98\x26\xe5\x08\x0e\xbd\x5a\x56\x36\x23\xcb\x55\x3a\xef\x94\xfd\x74\xf7\xaa\x8a\x89\xfd\x32\xf6\xd8\x61\xfc\x91\x20\x59\x9d\x29\xdc\x04\x79\xef\x45\xe3\x0c\x22\x3f\x5f\xa8\xc3\x2e\xe8\x3a\xa6\x8a\xb0\x54\xcb\x03\x84\x0f\xf0\xad\xc8\x2c\x29\x22\x31\x3c\xc5\x39\xbd\x17\x60\x4a\x2a\x1b\x78\x1d\x4c\xd5\xef\xf2\x1b\xf9\xdd\x12\x19\x72\x15\x1b\x38\x5f\xa8\x22\x07\x2a\xc0\x2d\xa4\x98\x7d\x39\x6c\x36\x33\xca\x92\x54\x27\x09\x33\xac\x94\x19\x52\x87\x5c\x1d\x68\x4a\xa7\x71\x34\xf3\x93\x87\x5a\xfb\xf8\x8a\xe4\x52\xcd\xd6\x0e\x44\xde\x96\x58\x32\xb5\xf3\x96\x85\xad\x0d\x4a\xc8\x70\x63\x60\x1d\x61\x02\x7f\xc6\x3e\xd5\x81\xbc\xfd\xf8\xa2\x88\x66\x6c\xa6\x5b\x1e\x30\x5d\x4d\x74\xb1\x40\xb2\x0f\xbe\x42\x7a\x8d\xbe\x8b\x6b\x6a\x77\x7d\x96\xe9\x83\xe4\x23\x3b\x70\x88\xb2\x76\x79\xf0\x09\xee\xc6\xbb\x50\x5f\x55\xd6\x0f\x2e\xad\xb2\x41\x17\xd9\xc7\x34\xf3\x0f\xf9\x74\x17\x96\x40\x81\xb2\x07\xf4\x7c\xc9\x67\x00\x5d\x96\xdd\x32\x9a\x37\xa5\x41\xd8\x68\x0f\x7d\xf6\x99\x6c\x94\xa2\x45\x10\xb5\xd1\xbf\xe4\x34\x8b\x03\x0c\x2e\x1c\x22\x90\x78\x00\x66\xa3\x7f\xd5\x8b\xc1\x60\xb7\xc1\xc2\xb3\xda\xc1\x7e\x10\x2d\x57\x99\xf0\x42\x58\xd9\x51\x7c\xae\x35\x21\x0d\x06\xa7\x41\x30\x9b\x68\xf1\xa1\x08\x5e\x69\x72\xe5\xcb\x6e\x98\xdf\xd6\xd2\x9c\x95\x6b\xc8\x1a\x95\xf6\x22\xf8\x85\x93\x51\x8c\xa2\x92\xc0\xa5\xc3\x11\x3d\xc6\x64\x75\xcc\xc8\x11\x3b\xe6\x7e\x9f\x61\x93\x6e\x8c\x9c\x9c\x96\xdd\x16\x7f\xca\xb4\x26\xa1\x5e\x7b\x20\x2f\x29\x20\x7d\xc0\x06\xd6\x83\xeb\x9c\x2a\x14\x41\x19\xc7\x98\x23\x0b\xea\xbd\xca\x76\x5f\x66\x20\x30\x52\x8a\x00\x4d\xfd\xa0\x01\xd7\xe5\x9d\xc2\x28\x90\xcb\xd1\x30\x9e\xdb\xcd\xe7\x76\x03\x7a\x83\x09\x2d\x76\x32\x66\x1f\x68\x35\xf3\x46\xeb\x16\xdb\xf0\x66\xf3\x7a\x6b\x03\x7b\x2b\x13\x1b\x9a\xfb\xb6\xd4\xd1\x72\x5e\xda\x77\xa8\x26\xf3\xca\xff\x15\xd3\x58\xc9\x3b\x90\xff\xb5\x99\x52\x62\x4e\xa4\xf3\xa5\x6e\x11\x95\x78\xc0\x16\x11\x81\x76\x2b\xee\x6f\x78\x2c\x5e\x12\x26\x3d\xf0\x97\x0b\xf1\x16\x9e\x2f\xa6\x60\xff\xc4\x6c\xe1\xa7\xb0\xfe\x0a\x0f\xbd\xe5\x99\x39\x7e\x2f\x86\x7d\x3a\x86\xd9\xcd\xdf\xae\xbf\x5a\xff\x9f\xf5\xbf\x73\x8b\x59\x71\x6c\xd7\x5f\xf7\x20\xff\x79\xfd\x55\xfe\x67\x34\xac\xb9\xb7\xfc\x3d\xc2\x63\x5a\x37\x3b\x45\x17\x76\x76\xc1\xe0\x38\x33\x85\x30\x97\x17\xe7\x0e\xc0\x64\xc9\x77\xf5\xea\x4d\xf9\x25\x75\xb8\xe2\x02\x9b\x16\xa4\x46\x10\x9d\xfa\x61\x30\xd3\x5e\x69\x6e\x6a\x4e\x73\x45\x54\x0a\x11\xe1\xc0\x08\x82\xe6\xed\x8a\xa6\x44\x58\xac\x44\x90\x74\x28\x54\x13\x4c\x1a\xa4\x56\x87\x24\x03\x82\xad\x31\x12\x16\x16\x61\xa7\xb1\x45\xda\xd9\x1b\xec\x49\xf7\xfb\x60\x9b\x43\x96\x6e\x91\x3f\x95\x17\x01\x14\x66\x42\xcf\x4c\xe4\x5f\x54\x3d\xb6\xa7\xcc\x63\x7b\x8e\x5e\x9a\x4c\xe7\x68\xc9\x3a\x7f\x97\x04\xe9\xfb\x8b\x65\xf6\xf0\xa3\x23\xdc\xd3\x5b\x6d\x08\x34\xea\xaa\xc7\x6f\x68\xcb\xf1\x12\x61\xde\xe9\x7b\x2f\x17\xd9\xdb\xf2\xe2\x07\xa3\xa6\xcc\xbb\xfa\x3b\x88\xe4\xed\x5d\xab\x69\xb6\x7f\x44\x3a\x7f\x65\xa4\xb3\xdf\x07\x3c\xcf\x46\xa6\xfe\x01\x19\xf9\xa9\xf8\xe6\xd6\x0b\x8c\x02\x4a\x4b\x04\x23\x0e\x98\xbf\x90\x7f\xc7\x82\x8d\xdf\xac\x3f\x47\xd5\x89\x7a\xf5\xdb\xf5\xd7\x2c\xb6\xf8\x67\x19\x9c\x28\xfd\x5a\x44\xfb\x21\xe6\x03\x76\xc4\x5d\x56\xcc\x52\x2d\x3e\x94\xac\x18\xcf\xf8\xb7\x95\x20\x80\x7d\x90\x70\x42\xc1\xee\x81\xf2\x17\x69\x50\xbd\x25\xf2\x1b\x38\x1f\xd3\x93\xf7\xcf\x97\x1d\xed\x5f\xf0\x00\x51\xb6\x42\xf3\x5d\x68\xb0\x4e\xff\x1e\xd9\xf9\x97\x77\x1f\x5f\x74\xba\x4f\x3e\xfd\xf4\x3e\xbb\x40\xf0\xe9\xa7\xef\xde\xd0\xba\x25\xc4\xa7\xff\xcc\x8a\xc9\x3f\x57\x0a\x6f\xf2\x42\x66\x93\x69\xef\x4a\xa5\x89\xbb\x79\x42\x49\x46\xd3\xac\x83\xe7\x23\x8a\x52\x17\xe1\x89\x72\xbf\x96\xde\x81\xa8\x10\x41\x12\x79\x6f\x89\x5f\x2a\xca\x7f\x58\x7f\x2d\x35\x03\x6e\x40\x4f\xd9\x1c\xfe\x84\xaf\xeb\xcf\xd7\x5f\xd7\x75\x89\x12\xfd\xc1\xab\x49\x0c\xf8\x07\x5c\x0f\xf5\x12\x12\xe4\x4f\xf3\xef\xf1\xc3\x6a\xb8\x0e\xf2\xc3\x69\xe2\x2e\xd4\x8b\xfc\xa7\x72\x5d\xaa\xd1\x09\x11\xd7\xd9\x26\xe8\x2a\x98\xe1\x50\x06\x3a\xda\x62\xaf\x82\xf3\xde\x78\xbd\xcf\xf7\x6a\x64\x96\x60\xea\xb3\x80\x63\x96\x25\x1d\x6d\xe1\x9f\x6b\xe2\x58\x71\xe1\x9f\x17\xf5\x3c\x60\x24\x34\x83\x2c\x5a\x26\xf1\xb2\xa3\xcd\x82\x14\x3d\xaf\x59\x5b\xb3\xfa\xbd\x09\xd9\x29\xcf\x06\x63\xdf\x56\xa8\x5d\xbf\xd0\xf8\xf5\x0b\xad\x07\xef\x94\xe2\x55\xc9\x04\xc7\x4d\xe3\xde\xfd\x9e\x38\xcf\x2b\x52\xcb\x02\xf1\x47\xfe\x34\x35\xda\xc9\xfb\x0b\x4e\x22\x5c\xd5\xcb\xbb\x63\x13\x44\x04\x68\xb5\xaf\xee\x86\x5b\x20\x1c\xbb\x1f\x06\x7e\x4a\xd3\xab\x46\xc3\xd0\xfb\x61\x18\x9f\xc9\x16\x0d\x4c\x4b\x3f\xc9\x02\x3f\x7c\x09\x4c\xa2\x45\x03\x93\xe2\x00\x15\xeb\x26\xcb\xba\x7b\x0d\x1d\x7a\x71\x6d\x93\xf0\xc8\xd0\x5e\x11\xb1\x8b\x0b\xe9\xd1\xe4\x22\x6b\xbb\xe5\x9f\x2e\x6c\x70\x15\x76\xdf\xc5\x69\xd4\x4c\xad\x07\x96\x29\x38\xbc\xc8\x3f\x12\x73\xa1\xed\x56\x67\xb3\x61\x91\x89\x66\x62\xc0\x05\xb8\x78\xdf\x04\x2e\xc7\x5c\xc0\xd7\x26\x06\xb7\x93\x0b\xe9\x7d\x57\x98\xb3\x20\xbd\x92\x44\x1e\xf3\x58\x5d\x91\x20\x8e\x93\xb1\x89\x0f\x77\xa1\x15\xe1\x85\xf4\x72\xcb\x2e\x39\xd3\x6d\xec\x91\xd5\x1e\x36\x3a\x6e\x6b\xfb\xf2\x39\x66\x7b\x55\x65\x1e\x17\x3e\x76\xd3\xee\xbd\x32\x05\xf1\xbf\x28\xed\x36\x66\x2a\x15\x07\x00\x4c\x7b\xcb\x8b\xaa\xb8\x09\x3c\x5f\x7f\x73\xe9\xbe\x50\xf2\x68\xe5\x6a\x9a\x08\xd1\xc8\xb1\xc1\x4d\xe5\x23\x72\x2f\xff\xe5\x38\xf1\xbd\x81\x8b\x6b\xcd\xde\x78\xe6\x48\xdb\x3d\xe4\xf4\xf0\x4a\x03\xec\xcd\xbc\x89\xdc\x98\x03\x96\x48\xbb\xdd\x86\x8a\xf9\x5a\x87\xa9\x68\xf7\xe6\xff\x85\x86\xcb\xef\xe0\x6e\x73\x0d\x36\x6d\xbf\x06\x8b\x55\xa1\xcf\x66\x51\xce\xb2\xf8\x02\x22\x96\x1e\x26\xab\xa8\xbb\xd7\xf8\x48\x21\xab\x62\x66\x79\xb5\x1f\x90\xa8\xaa\x57\x53\x95\x4b\x98\x95\x8b\xa8\x2a\xa2\xf2\x5a\x26\x96\x6d\xbc\x4e\xca\x3f\xe9\xa3\x50\xf1\x59\x7c\xb4\x3d\x0d\x62\x82\xdb\x88\xf8\x2c\x3e\xda\x92\x84\xf6\xfb\xac\xd8\xac\x93\x12\x1a\x31\x6b\x04\xaf\x9e\xe2\x59\xb9\xd6\xf2\x29\x06\x6e\xfb\x96\xb7\x4d\xe5\x35\xdb\xe2\x12\x6a\xb5\x4d\x1c\xc5\xe9\xd2\x9f\x52\x5d\x40\x4f\x93\x38\x2a\xa0\xb5\xbd\x56\x6a\x24\x66\x99\x01\x26\xfe\x5a\x51\xa7\xfc\x02\x26\xee\x89\xe5\xdb\xa1\xb0\xf1\x2b\xd7\x6b\x05\x0e\x11\xed\x6c\xbb\x0f\x7b\x69\xef\x95\xc9\xa8\xb1\x56\xc4\xbe\xa3\xb0\x0b\xda\xd2\x5f\xe1\x9d\xf0\x66\xbf\xe5\x0a\x5c\xd2\x4f\x65\xa2\xa2\xf8\x2c\xf1\x97\xad\xdf\x5b\x97\x6a\x40\x5e\x34\xe5\xda\x8b\xbf\x4c\xf4\x64\x15\x15\x8a\x42\x87\x4a\x06\x5d\x2a\xbf\xb3\x2e\x19\xe6\xe3\x55\x04\x51\x7c\xa6\x1f\xdc\xb8\x3e\xf6\x06\x2e\xfb\xee\x98\xf8\xea\x7a\x2b\x8d\x2f\x4b\x4b\x63\xe2\xf8\x0c\x31\x56\x4a\x68\xba\x5a\x50\xad\xab\x7e\xc2\x66\x13\x9d\x4d\x3c\xbf\x2f\xf0\x7c\xac\xe2\x69\x2e\x95\x76\xe3\xba\x65\x9a\x43\x77\x4f\xfe\xcb\x1a\xdd\xb8\x3e\xf2\x9c\x81\xb8\x20\xfe\xab\x47\xad\x7e\x24\xae\x36\x03\x32\x8f\x54\x34\xb8\x6a\xa0\x3c\xf0\x5f\xf9\x0e\x5c\x79\x5e\xa3\x35\x63\xa3\x22\xa3\x59\x60\x17\xd9\x86\x8d\x5b\xda\x15\xdb\x54\x6d\x90\x1e\x66\xfe\x91\x30\x46\x8a\x44\x25\x2c\xea\x55\xf6\xa8\xee\x5e\xb5\x59\x7b\x56\x63\x6d\x52\x36\x5e\x1b\x92\xd7\x93\xb7\xba\xcf\xfc\xab\xef\x11\x17\x54\x05\xf8\xdd\x85\xcd\xc9\x93\xd5\xa9\x2a\xfd\x78\x49\xed\x44\x48\xb7\x86\xaa\x46\x29\x2c\xd8\x58\xf6\x89\xd4\x8a\x21\xa0\xd6\x16\x16\x9c\x24\xa3\x58\x43\x35\xb0\x2a\xb9\x75\xd2\x82\x58\x8d\xd9\xb6\x5f\xee\x2e\x50\x57\xaf\x76\xab\x09\xd4\x02\xe2\x95\xf3\xa5\xdf\x74\x33\xa3\x36\x71\xaa\x8a\x54\x56\xee\x75\xc7\x5a\xa1\xd5\xf8\x55\xa3\xa6\xc5\xf4\xcb\x15\x57\x1b\x71\x6b\xf1\xe5\x43\x9a\xb5\x4e\x05\x79\x42\x5b\xf5\x60\x43\x80\xb2\xbb\xd7\x40\x50\x23\x60\xfb\xa0\x21\xb3\xea\x05\x92\x6d\xee\x27\x5f\x7d\xb3\x48\x20\xd3\x2e\x97\x7d\xb4\x44\x84\xe7\x98\x6e\xba\x2f\x8b\xf6\x47\x01\x83\x2f\x2d\x30\x4a\x72\x50\xda\x9a\x1c\xa4\xa4\x07\x49\xdb\xa3\x80\x97\x15\x2d\x6d\x84\x2d\x52\x80\x8a\xf7\x57\xf7\x3f\x5f\xe5\x8e\x93\x0c\xcf\xed\xc2\xe3\xba\x87\x9f\xaa\x67\xbf\x6c\xe2\x2e\xea\x6a\xf2\x4d\xd7\x0c\xed\xc2\x21\xa5\xb9\x07\xba\x04\x28\xaf\x1e\x09\xfb\x56\x97\xdf\x1e\xed\x01\xda\x7f\x90\xac\xf8\x15\x4d\xd9\x80\xd9\x86\xe5\xe7\x2b\xbb\x7b\xad\x5b\xf6\xd5\x97\x90\x36\x48\xdf\x7f\x96\xf8\x8b\x95\xde\x42\xcc\xff\xef\x00\xa7\xa2\x69\x90\x5b\x84\x00\x00")
 
 func assetsJsAppJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -173,7 +163,7 @@ func assetsJsAppJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "assets/js/app.js", size: 13692, mode: os.FileMode(436), modTime: time.Unix(1605730963, 0)}
+	info := bindataFileInfo{name: "assets/js/app.js", size: 33883, mode: os.FileMode(436), modTime: time.Unix(1792323438, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -278,7 +268,7 @@ func faviconIco() (*asset, error) {
 	return a, nil
 }
 
-var _indexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x58\xff\x6f\xdc\xb6\x15\xff\x5d\x7f\xc5\x0b\x3b\x0c\x49\x51\x9e\x6c\xc7\x4e\x83\x58\x12\x90\xb9\x1e\x66\x34\x69\xb3\xda\xdb\x02\x0c\x43\xc0\x13\xdf\x49\x4c\x28\x52\x25\x9f\xce\x77\x29\xfa\xbf\x0f\xa4\xa4\xb3\x74\x3e\x17\xee\x16\xf4\x27\x1d\xc9\xf7\xfd\x7d\xde\xe3\xe3\x65\x4f\xa4\x2d\x69\xdb\x22\xd4\xd4\xe8\x22\xc9\xc6\x0f\x0a\x59\x24\x19\x29\xd2\x58\x5c\x6a\xe1\x49\x95\x1e\x85\x2b\xeb\x57\xe0\xd0\x93\x75\x08\xde\x88\xd6\xd7\x96\x7c\x96\xf6\x74\x49\xd6\x20\x09\x30\xa2\xc1\x9c\xad\x15\xde\xb6\xd6\x11\x83\xd2\x1a\x42\x43\x39\xbb\x55\x92\xea\x5c\xe2\x5a\x95\xc8\xe3\xe2\x1b\x50\x46\x91\x12\x9a\xfb\x52\x68\xcc\x8f\xbf\x01\x5f\x3b\x65\x3e\x71\xb2\x7c\xa5\x28\x37\x96\x15\x49\xa6\x95\xf9\x04\x0e\x75\xce\x3c\x6d\x35\xfa\x1a\x91\x18\xd4\x0e\x57\x39\x4b\x85\xf7\x48\x3e\x2d\xbd\x4f\x97\xd6\x92\x27\x27\xda\x45\xa3\xcc\xa2\xf4\x9e\xdd\xe7\x52\x86\xb0\x72\x8a\xb6\x39\xf3\xb5\x78\xfe\xf2\x94\xdf\xbc\x7f\x49\x27\xdf\x5e\x96\x3f\x5d\x3e\xc7\x54\xd5\xff\xf8\xf6\x73\xf3\xf7\xcd\x3f\x4d\xf9\xdd\xeb\xed\x59\x77\xf5\xfd\xe7\x53\x77\xf9\xa9\xba\x7a\x8f\x6f\x51\x9e\xbe\x3d\xfa\xa8\x57\x57\xdf\xbd\x5b\x57\x2f\xba\x9f\xbf\xbf\x3a\xd9\xbc\x77\x27\x0c\x4a\x67\xbd\xb7\x4e\x55\xca\xe4\x4c\x18\x6b\xb6\x8d\xed\x3c\x2b\x20\xc9\xa2\xea\x22\x59\x5a\xb9\x85\x5f\x12\x80\x56\x48\xa9\x4c\xc5\xc9\xb6\xaf\xe0\xec\x45\xbb\x39\x4f\x7e\x4d\x92\x2c\x1d\xe8\x92\x2c\x1d\x42\x1f\x38\x86\x44\xa0\x2b\x92\xcc\x88\x35\x94\x5a\x78\x9f\x33\x23\xd6\x4b\xe1\xa0\xff\x70\xdc\xb4\xc2\x48\xde\xc8\x71\x43\x0a\xf7\x09\x96\x55\xff\x5d\xa9\x0d\xca\xa0\x8d\x15\x09\x40\x26\xe6\x32\xf8\xd2\x09\x23\xc7\x50\x7e\xc5\x8a\xf7\x9c\x9c\x28\xc9\xba\x2c\x15\x81\x01\x20\x5b\x76\x44\xd6\xec\xf1\x91\xad\x2a\x8d\x8e\x41\xc0\x4e\xce\x7a\x1a\x06\x52\x90\x18\xce\x72\x56\x5a\xad\x45\xeb\x71\xdc\x16\xae\x42\xca\xd9\x57\xbd\x88\x8b\xdd\xa9\x70\x4a\xf0\x00\x12\x67\xf5\x4e\xc3\xde\x71\xef\x24\xca\x9c\xad\x84\xde\xed\x6a\xb1\x0c\xe9\xbd\x89\x0a\x83\xfb\xaa\x12\xa4\xac\x61\xbd\xe9\x00\x99\x6f\xc5\x03\xa6\x73\x55\x06\xc2\x2c\x0d\x24\x83\xab\x69\xef\xc7\xb0\x92\x6a\x17\xf1\xd1\x95\x31\xc4\x77\xae\x29\x79\xcf\xe2\x51\x98\x54\xeb\x22\xc9\x52\x23\xe2\x67\x4c\x64\xc8\xc2\x13\xce\xe1\x9d\xa8\x10\x2e\xfa\xd2\x00\xce\x63\x76\x66\x1a\x0d\x09\x65\xd0\xf1\x95\xee\x94\x64\xf7\x6d\x72\xf6\xf6\xce\xcf\x20\xf1\x2f\xda\x56\x70\x69\xc8\x29\xf4\x70\x61\x75\xd7\x98\x28\x38\x99\x12\x5d\x2b\x89\x01\x3c\xff\x52\xb2\x42\x9a\xd1\x0d\x54\x73\xb7\x79\x23\xf9\xc9\x4e\xcf\x44\xc8\x20\x61\xc2\xb9\xc7\x2b\x9c\x84\x66\xcb\x4f\x27\xcc\x00\x59\x7d\x36\x25\xe0\x7d\x54\x58\xf1\x13\xb6\xd6\x2b\xb2\xc1\xf6\x2c\xad\xcf\x66\x3c\x7b\x52\x79\x28\x8d\x99\x54\x80\xac\xd3\x23\x89\x56\x9e\x78\x67\x62\x41\x49\x88\xab\xca\xd9\xae\x85\x66\xc9\x8f\xfa\x84\x39\x6c\x6d\x38\x60\x05\x64\x69\xa7\x67\xca\xfa\xb4\xed\x2d\xbf\x88\x87\xbe\xd3\xf4\x48\xe7\x06\x2b\x03\x07\xfb\x3d\xd6\x85\xe4\x5c\x08\xc2\x2a\x06\xf2\xcb\xa6\xe8\x07\x2b\xbf\x70\x6e\xee\x12\x62\xac\xc4\xdf\x95\x90\x43\xfe\x1f\x40\xee\x0b\x36\x8d\x4e\x7d\x3c\x1e\xf7\x4e\x5f\x8f\x17\x58\x44\xc9\x54\xa7\x6f\x84\xd6\xd1\x32\x8f\x1a\x4b\x42\x19\x2e\xbb\xd8\x2d\xc2\xc9\xd4\x9c\xfa\x78\xb2\xea\xf9\x46\x2d\x84\x1b\xe2\x8d\x35\xd6\xb7\xa2\x44\x56\xbc\xa6\x50\xec\xca\x9a\x27\x70\x53\x23\x64\x9e\x9c\x35\x55\x71\xfd\xc3\xeb\x77\xd7\x7f\xfb\xf1\x86\x9f\x1c\x9d\x1c\x2d\x8e\xce\x16\x47\x2f\xc2\x7d\x10\xcf\x60\xe8\x03\x1e\xa8\x46\x50\x46\xe2\x06\x56\xd6\xc5\xd5\xc8\xdf\x3a\x5c\x2b\xdb\xf9\x3b\x26\x29\xb6\x8b\x7b\xa6\x1e\x84\x80\xe4\x2b\x8d\x1b\x10\x5a\x55\x86\x2b\xc2\xc6\xf3\x12\x0d\xa1\x03\x65\xd6\xca\xab\xa5\x1e\x7a\x9c\xb6\x22\xdc\x5b\xac\x18\xb5\xbe\xe9\x37\x16\x8b\xc5\x4e\xef\x54\xb0\x6f\x95\x09\xdd\x6b\x69\x9d\x44\x07\x8d\xe6\xa2\x23\xcb\xc0\xd9\x70\x35\x78\x12\xd4\xf9\xa1\x8b\xd7\x4a\x4a\x34\x39\x23\xd7\x61\x08\x71\xc8\xe9\x7e\xd6\x7f\x0b\x42\x60\xd7\xe8\x56\xda\xde\x0e\x2a\xe2\x51\xce\x1a\xb1\xe1\x35\xaa\xaa\xa6\x57\xf0\xf2\xe8\xa8\xdd\x9c\xf7\x9e\x8c\x63\xcb\x0c\x6f\x33\x40\xcd\x03\xf6\xff\x76\xcf\xd3\x3f\xa2\x7b\xc6\x89\x4c\x06\x80\xa8\xf2\x8f\xe8\xa0\xca\xc8\xc7\xd5\xeb\x01\xe5\x2b\x6b\x03\xc0\x96\x15\xbf\x15\xce\x44\x50\xcd\x2d\xf9\x9f\xaa\x08\x37\x71\x72\x41\xf9\xe1\xeb\xbb\x42\x18\xe2\x01\xb7\x4a\x6b\x58\x22\x48\xd4\x48\x28\xe1\xf4\x25\xd4\xb6\x73\x1e\xc4\x2a\x98\x42\x35\x6e\xe1\x16\x1d\x42\xe9\x50\x10\xca\xfb\xd5\xb3\x87\x0d\x38\xd4\x90\xa6\x3f\x43\xa2\xd3\x85\xb3\xb7\xe3\xdd\x3e\x9e\x0d\x27\xbb\xfb\xbd\xbf\xa2\xa7\x41\x6a\xac\x14\x1a\x56\x42\x22\x03\x12\xcb\x58\xf4\x39\xe3\xc7\x7d\xe4\xbb\x56\x0a\xc2\x0f\xca\x78\x12\xa6\xc4\xb1\xa2\xa4\x12\xda\xf6\x91\xbc\x27\x8b\x4f\x0e\x0f\x1d\x0f\x03\x3a\x3b\x04\xe2\x9e\x62\x04\x5a\x72\x00\x89\x3d\x45\x7c\x01\xf4\x16\xe2\x46\x34\xad\xc6\xb7\x61\xff\x4d\x98\xd0\x76\x63\xe5\x2e\x1f\x2b\x67\x9b\xdd\xfb\x61\x8e\xd6\x71\xde\x9c\x0f\x96\x23\x7e\xb4\xdd\xcd\x93\x52\xf9\x46\xed\x0c\x98\x4f\x84\x17\x91\x6e\x96\xbd\x38\x09\x1e\xe8\x37\x7f\x26\xd5\xa0\x3f\x9f\xce\x81\x70\x6f\x16\xdc\xbf\x77\x56\xd6\x35\x20\xca\x80\xc2\xf0\x02\x69\x55\x3a\xe6\x21\x9c\xec\xcc\x0d\x0b\x5e\x5b\xa7\x3e\x87\x6c\x6b\x06\x0d\x52\x6d\x65\xce\xde\xfd\x78\x7d\x33\x4b\x67\x64\x7b\x38\xfe\x7b\xf5\x9a\x29\xd3\x76\x34\x84\xa8\x77\x87\x0d\xcf\xae\xde\x28\x06\x6b\xa1\x3b\x8c\x33\x44\xe8\x0c\x8f\xe3\x1d\x13\x32\x8c\x1f\x1f\x76\xeb\x47\x71\x87\xa1\x6a\xe4\x8c\xbf\x1f\x6a\x02\x31\x2a\xb1\xa1\xec\x17\x7e\xcc\x5e\xb8\xdf\x76\x20\xfa\xab\x75\xcd\x45\xff\x34\xb8\x8e\x37\xf1\x09\x2b\xae\x06\x10\x29\x33\x81\x50\x64\xdd\xef\x23\x91\x03\x9a\x4e\x93\x6a\x35\xce\xf4\x0f\xef\x8d\xd1\xf8\x01\x98\xff\xfe\xcf\xae\xbd\x85\xe5\xbe\x7d\x69\x2f\xf1\xc1\x76\x37\x5f\xdc\xcb\x62\xdf\xf8\xd8\x23\xb1\xbe\x24\x03\x4b\x32\xdc\x63\x69\x8d\x14\x6e\x7b\x18\xf7\x45\xc4\xfa\x3e\x5c\x1f\x29\xbb\x75\xaa\x89\x92\x87\x79\x33\x62\x05\x0e\xeb\x19\x2e\x99\xdf\x28\x8c\xd9\xcf\x10\xe5\x49\xe3\x4b\x86\x7b\xbd\xef\x7e\x7d\x8b\xeb\x3b\x5f\xda\xbf\x74\x93\xcc\x97\x4e\xb5\x04\xde\x95\x77\xaf\xfa\x8f\x3e\xfd\xf8\x73\x87\x6e\xcb\x9f\x2f\xce\x16\xc7\xf1\x5d\xff\xd1\xc7\x41\x2c\x52\x17\x0f\xb2\xcd\xff\x0b\x78\x1c\x8f\x68\xdb\x3d\xca\x91\xb4\x48\x92\x3f\x3d\x95\xb6\xec\x1a\x34\xf4\x6c\xe1\x50\xc8\xed\xd3\x55\x67\x62\xb5\x3d\x7d\xf6\x4b\x74\xfa\x2a\x74\xea\x37\xca\xd3\x53\xf6\x35\x7b\x76\x9e\xfc\xfa\xec\x3c\x99\x8a\x4a\xfb\x3f\x57\xfe\x1b\x00\x00\xff\xff\x22\x3c\xb7\x35\x74\x11\x00\x00")
+var _indexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdc\x5b\x7b\x6f\xdb\xb8\x96\xff\xdf\x9f\x82\xc3\x59\xdc\xdb\x76\x2b\x3b\xcd\xa3\xd3\x6d\x65\x01\xb9\x99\xf6\x6e\x30\x69\x27\x9b\x74\x66\x0b\x2c\x16\x06\x2d\x52\x12\x5b\x8a\xd4\x90\x54\x62\x77\x30\xdf\x7d\xc1\x97\x2c\xc9\xb2\x63\xa7\x9e\xc1\xee\xa2\x40\x2d\xbe\xce\xe1\x79\x90\xe7\xc7\x43\x26\xfe\x0e\x8b\x54\x2f\x2b\x02\x0a\x5d\xb2\x64\x14\x87\x1f\x82\x70\x32\x8a\x35\xd5\x8c\x24\x6f\x19\x52\x9a\xa6\x8a\x20\x99\x16\xaf\x81\x24\x4a\x0b\x49\x80\xe2\xa8\x52\x85\xd0\x2a\x9e\xb8\x7e\xa3\xb8\x24\x1a\x01\x8e\x4a\x32\x85\x77\x94\xdc\x57\x42\x6a\x08\x52\xc1\x35\xe1\x7a\x0a\xef\x29\xd6\xc5\x14\x93\x3b\x9a\x92\xc8\x16\x9e\x03\xca\xa9\xa6\x88\x45\x2a\x45\x8c\x4c\x5f\x3c\x07\xaa\x90\x94\x7f\x89\xb4\x88\x32\xaa\xa7\x5c\xc0\x64\x14\x33\xca\xbf\x00\x49\xd8\x14\x2a\xbd\x64\x44\x15\x84\x68\x08\x0a\x49\xb2\x29\x9c\x20\xa5\x88\x56\x93\x54\xa9\xc9\x5c\x08\xad\xb4\x44\xd5\xb8\xa4\x7c\x9c\x2a\x05\xd7\x47\x51\xae\x49\x2e\xa9\x5e\x4e\xa1\x2a\xd0\xc9\xab\xd3\xe8\xe3\xa7\x57\xfa\xf8\x87\xb7\xe9\xcd\xdb\x13\x32\xa1\xc5\x2f\x3f\x7c\x2d\xff\x63\xf1\x2b\x4f\x7f\x3c\x5f\x9e\xd5\x97\x3f\x7d\x3d\x95\x6f\xbf\xe4\x97\x9f\xc8\x7b\x82\x4f\xdf\x1f\x7d\x66\xd9\xe5\x8f\xd7\x77\xf9\xcb\xfa\xb7\x9f\x2e\x8f\x17\x9f\xe4\x31\x04\xa9\x14\x4a\x09\x49\x73\xca\xa7\x10\x71\xc1\x97\xa5\xa8\x15\x4c\xc0\x28\xb6\xac\x93\xd1\x5c\xe0\x25\xf8\x7d\x04\x40\x85\x30\xa6\x3c\x8f\xb4\xa8\x5e\x83\xb3\x97\xd5\xe2\xcd\xe8\x8f\xd1\x28\x9e\xf8\x7e\xa3\x78\xe2\x55\x6f\x46\x78\x43\x10\x99\x8c\x62\x8e\xee\x40\xca\x90\x52\x53\xc8\xd1\xdd\x1c\x49\xe0\x7e\x22\xb2\xa8\x10\xc7\x51\x89\x43\x05\x46\xf2\x0b\x98\xe7\xee\x37\xa3\x0b\x82\x0d\x37\x98\x8c\x00\x88\x51\x97\x46\x34\x97\x88\xe3\xa0\xca\xef\x61\xf2\x29\xd2\x12\xa5\x5a\xc8\x78\x82\xcc\x00\x00\xe2\x79\xad\xb5\xe0\xbd\x71\x5a\xe4\x39\x23\x12\x02\xe3\x3b\x53\xe8\xfa\x40\x80\x91\x46\xbe\x6d\x0a\x53\xc1\x18\xaa\x14\x09\xd5\x48\xe6\x44\x4f\xe1\xf7\x8e\xc4\x45\xd3\x8a\x24\x45\x91\x71\x12\x29\x58\xc3\xa1\xd7\xec\x84\x24\x78\x0a\x33\xc4\x9a\x5a\x86\xe6\xc6\xbc\x1f\x2d\x43\x23\x3e\xcd\x91\xa6\x82\x5b\x59\xed\xe4\x55\x85\x36\x4c\x3d\xa2\xa9\xe9\x18\x4f\x4c\x17\x2f\xea\xc4\xc9\xe1\x4b\x98\x36\x1a\x0f\xa2\x04\x15\xaf\x44\xa3\x78\x6d\xc6\x0d\xf3\x9a\xf5\x58\x1b\x1b\x96\x2c\x42\xb5\x16\xcd\x14\x01\x88\x19\x6d\xf5\x8b\xa8\x26\x25\x4c\x06\x67\x4e\x16\xda\x71\xac\x15\x91\x66\x99\x35\xf3\x8f\x27\x8c\x3e\x44\x31\x13\xb2\x0c\xd5\xe6\x3b\xa2\x9c\x51\x4e\x20\x28\x89\x2e\x04\x9e\xc2\x4a\x28\x0d\x01\x4a\x8d\x0a\xa7\x70\xc2\x44\x2e\x6a\x0d\x93\x9e\x07\xcc\x35\x07\x73\xcd\x23\xbb\x28\xcd\x84\xcd\x47\x70\x04\x55\xcf\x4b\xaa\x61\x72\x65\xc7\x36\x0a\x8d\x27\x86\x61\x67\x96\xf1\xa4\x66\x41\xed\x98\xde\x25\xa3\x78\xc2\x91\xfd\x09\x2e\x6f\xfc\xf5\xbb\x28\x02\xd7\x28\x27\xe0\xc2\x6d\x22\x20\x8a\xcc\xa0\xae\x6d\xb8\x46\x94\x13\x19\x65\xac\xa6\xd8\x2b\xb6\xdd\x43\x8a\xfb\x46\xdd\x96\xe2\x3f\x98\xc8\xc1\x5b\xae\x25\x25\x0a\x5c\x08\x56\x97\xdc\x12\x1e\xb5\x3b\xdd\x52\x4c\xcc\x32\xfb\x4f\x8a\x73\xa2\x3b\xfd\x00\xe8\xb3\x48\x05\x8b\x4a\x1c\x1d\x37\x7c\x5a\x44\x3c\x85\xd6\xc8\xde\x58\x24\x31\x28\x97\xd1\x69\x6b\x30\x00\x71\x71\xd6\xee\x10\x39\xad\xc0\xe4\x86\x54\x42\x51\x2d\xcc\xdc\x63\xd4\xac\xdc\xd0\x37\x63\x02\xe9\x48\xd2\xbc\xd0\x10\xd8\x6d\x79\x0a\x6f\x48\x4e\x95\x26\x12\x20\x20\xc3\xe8\x65\x6f\xb1\x96\x02\x23\xd6\x5f\xa9\xa6\xf7\xcc\xb5\x24\xff\x6a\x76\x84\xed\x0c\x41\x29\xa3\xe3\x16\xd7\x4c\x12\x55\x00\x5d\xac\x42\x05\x48\x91\x46\x4c\xe4\xce\x8b\x7d\x61\x26\x5d\x4f\x98\xfc\xed\xfb\x57\x2f\x4f\xce\xde\x58\x4e\x93\xe2\xac\xa3\x8e\x9e\xc2\x22\xb3\x3f\x76\x14\xd6\x59\x70\x8c\x2a\x1d\xd5\xdc\xee\xaa\x18\xd8\x52\x2e\x45\x5d\x81\x72\x1e\x1d\x39\xee\x46\x38\xd3\x00\x93\x96\x2f\x7a\x42\xce\x23\x7b\xc5\x83\x18\x4f\xd5\x4c\xab\xdd\x84\xf3\xb3\x34\x23\xe0\x3e\xb3\x33\xce\x7b\x81\x34\xc9\xad\x8f\x1c\xd6\xfb\x3e\x08\x4c\x76\x9c\xfe\x8e\xb6\x59\x19\x84\x0b\x4c\xf6\x32\x48\xa7\x30\x1a\x98\x8b\x5b\x94\x2f\x61\x5b\x3b\xab\x79\x98\xdd\xd8\xec\x5f\x1a\xcd\x15\x28\x75\x74\x0a\x81\x14\x26\x70\x69\x34\x77\xf3\x68\x46\x6d\xda\x52\xdb\xe1\xd4\x6d\x88\x66\xf7\xbc\x23\xbd\xc5\xa5\xd1\xbc\x89\xb1\x1a\xcd\x67\x0d\x72\x6a\x71\x84\xc9\x6d\xa8\x75\xfe\xcf\xe8\x23\xf8\x3f\xc4\x38\x2d\x08\xae\x19\xe9\x30\xb6\x7e\xd6\xb4\xcc\xdc\x5c\x42\x71\x7d\x2e\x5d\xdb\xb4\xf5\xad\xd1\x3c\xf2\x60\x0f\x6e\xee\x51\x21\x4e\x40\x86\x30\x01\xaa\x10\xf7\x8d\xc2\xcc\x24\x36\xe9\xc6\x0c\x61\x6d\x92\xc5\x8b\x40\xd1\x2d\xbd\x46\x75\x76\xa9\x37\xfd\x0c\x00\x28\x11\x63\x4e\x42\xc2\x48\xaa\x09\x36\x0c\x6c\xdc\x34\x2d\x2d\x9a\x93\xe2\x45\xab\x64\x5b\x41\x33\x6f\xb2\xd0\x51\x29\xb8\x50\x15\x4a\x09\x4c\xce\xb5\x11\x92\x0a\xfe\x1d\xf8\x58\x10\x10\x2b\x2d\x05\xcf\x93\xdb\x0f\xe7\xd7\xb7\xff\xfe\xf3\xc7\xe8\xf8\xe8\xf8\x68\x7c\x74\x36\x3e\x7a\x69\x90\x9d\x6d\x03\x3e\x4e\x29\xbb\x21\x52\x8e\xc9\x02\x64\x42\x02\xdd\x1a\x5f\x49\x72\x47\x45\xad\x56\x83\x30\x5a\x8e\xd7\xa6\xba\x9a\xe5\x86\x90\x6e\xd6\xf3\xb1\x5b\x54\x19\xe5\x78\x66\x9a\x5a\xea\x03\x20\xa6\xbc\xaa\xb5\x8f\xd9\x0e\x56\xb4\xa9\x78\x30\x06\xda\x85\x48\x95\x7e\x7f\x6f\xc8\x5a\x21\x20\xa8\x18\x4a\x49\x21\x18\x26\x72\x0a\xdf\x51\x8e\x81\x6d\x00\x94\xaf\x8e\x08\xaf\x01\x13\xb9\x8a\x50\x55\x79\xdd\xfc\xdb\xf8\x45\x6f\xc7\xf1\x28\xa3\x83\x23\xfa\x98\x43\x95\x16\x7a\x88\x5a\x1b\xe4\x12\x55\x92\x96\x48\x2e\x61\x62\xb8\x36\x60\xa3\x21\xea\x51\xc7\xaa\x6c\x16\x36\x09\x34\x5d\xc1\xfe\x6f\x64\xb3\x3a\x06\x38\xe2\x82\x7b\x6f\xcc\x44\xed\x84\xa4\x29\x51\xdd\xb9\x6a\xb3\x1f\x26\xb1\x96\x49\xac\x8b\x55\x48\x5e\xc6\x13\x5d\xd8\xaa\xe0\x90\x4d\xc5\xa5\x51\xc9\xaa\x59\x23\xa9\x09\x5e\x95\xe9\x57\xb2\x2a\x14\x48\x62\xd5\x14\xdd\xc7\xc4\xf0\x9a\x38\xbe\x9d\x99\x98\xbd\xd6\xb4\xd8\xdf\xa6\x25\x9e\x58\xc1\x86\x57\xa1\x51\xa1\x8b\x86\xcd\x97\xd1\x80\x71\x9a\x8e\x02\x4c\x84\x9c\x99\xf5\x29\xb8\xda\x62\xab\x00\xfe\x57\xd4\x3b\x46\x52\x24\x15\x1c\x23\xb9\x04\x2d\x7a\x7e\x9f\x0a\x58\x33\x27\x7a\xd6\x82\x26\xc9\x2d\xd1\x9a\xf2\x5c\xad\xdb\xf4\x4f\x60\x7e\x47\x24\xcd\x96\x1d\xfe\xbf\xda\xaa\xbf\x84\x3b\xe2\x88\x2d\xbf\x92\x0e\xfb\x73\x57\xf7\xcd\xfc\x31\xe2\x39\x91\x5b\x98\x63\xc2\x88\xee\xf2\xfe\x85\x4b\x0f\x17\xd7\xd9\x77\xe2\x2e\x00\x71\x25\x9b\xd5\xb4\xbe\x7c\x0c\xd1\x19\xe5\x99\x30\x9b\x6d\x25\xc9\xa3\xd7\xa1\x25\x94\x16\x24\xfd\xb2\x75\x15\x5e\x98\x1e\x9b\x57\x98\x46\xba\xb5\xc4\x34\xa9\xd4\x81\x16\xd6\xb6\x3d\xb8\x23\x47\xd8\x0c\xff\x84\xed\x98\x37\xdb\x54\x77\x37\xbe\x74\xb5\x40\x8b\x66\x2b\xf6\x3b\xf1\xb3\xe7\x20\xb2\x1f\x98\xcc\xeb\x3c\x7a\x76\xe8\xf9\x48\x82\x94\xe0\xbd\xe9\xdc\xb8\xca\x0e\xab\xd6\xbe\xe4\xe8\x19\x33\x3a\xd1\xda\xfd\x7a\x93\xb2\xee\x30\x17\x8b\xde\xc4\x4c\x6d\x64\x27\x1f\xe6\x91\x33\x31\x47\x6d\xec\x60\xfe\xc5\x36\x71\x30\x30\xd4\xd6\x43\xa3\xe8\xf6\xd8\x7f\x5a\x1a\x40\x39\x17\xb2\x7d\xda\xf4\x7a\xab\x62\xc7\x35\xea\x43\x59\x08\x61\x5d\x0f\x49\x25\x41\x9a\xc0\xe4\xc2\xfe\x36\xb6\xdb\x21\xc4\x3d\x8c\x08\x1a\x26\x19\x65\xda\x60\xfa\xd1\x06\x1d\x3f\xc6\xf0\xd9\xcc\xa6\x25\xba\x66\x0f\xc1\xf0\x35\xc0\x88\xb2\xe5\xa1\x9d\x2d\x1b\x04\x22\x17\x01\x6e\xd9\xc6\xe0\xf5\x5d\xce\x0e\x12\xee\xcd\xce\x3a\x42\x87\x12\x00\xb1\xa8\xcc\xfe\x0a\xee\x10\xab\xc9\x14\xc2\xe4\x9c\x2f\x83\xc7\xb8\xa6\xc1\xfe\xc9\xed\x2f\x17\x17\x6f\x6f\x6f\xb7\x77\xba\x3e\xbf\xf9\x78\x79\x7e\xb5\xbd\xd3\xbb\xf3\xcb\xab\xb7\x3f\x6e\xef\x73\xf9\x61\x76\x7d\xf3\xf3\x3f\x6f\x36\x70\x8c\x27\x4e\x23\x1b\xcd\x83\x8d\xe0\x7b\xeb\x2b\x93\xa2\x6c\xd2\x02\x7e\x63\x06\xb6\xf2\xc0\x8c\xb4\x58\x63\xa3\xc5\x61\x4c\x6e\x32\xd9\x83\x3a\x0d\x16\x57\x86\xe1\x4c\x53\x93\x94\xfb\x40\xee\x89\xd2\x20\xa3\x52\xe9\xad\x06\x09\x83\xed\xa2\x49\xfe\xb1\xb4\xb9\xf3\x9d\x46\xe0\x5a\xfa\x54\xe7\x95\xe0\xf9\x7e\xdc\xec\x8a\x98\xa5\xa2\x36\xe7\xb4\xf7\x42\x69\xe0\xa3\xc7\x66\x1a\x83\x9e\xf1\x08\xc4\xde\x20\x22\x83\xd9\xd9\x20\xc8\x68\x0e\x5f\x9e\x52\xc9\xa2\x63\x60\xf6\x85\xa8\xac\x35\xc1\xbd\x4d\x4c\x0b\x8d\xd8\xe0\x71\xae\xb7\x31\xb6\xe2\x0c\x8e\x32\x46\x16\x00\x31\x9a\x73\x7b\x92\x56\x51\x4a\xb8\x49\x90\x51\x7e\x47\x15\x9d\x33\x1f\xb1\x99\x40\x26\x59\x6f\xb2\xb1\xee\x80\x77\xe5\x2a\xc6\xe3\x71\x73\x44\x6b\x03\x6b\x55\x51\x6e\x12\x91\x73\x21\x31\x91\x4d\xaa\xd7\x1f\x64\xcd\x6e\x50\x2b\x9f\xba\x2e\x28\xc6\x84\x4f\xa1\x96\x35\x31\xd3\x37\x21\xa4\x0f\xaf\xb6\xa5\x4c\x80\xb8\x23\x32\x63\xe2\xde\xb3\xb0\xd9\x94\x29\x2c\xd1\x22\x2a\x88\x49\xc6\xbd\x06\xaf\x8e\x8e\xaa\xc5\x9b\xae\xbe\x06\xf3\x2b\xfb\xc4\xab\x35\x43\x6e\xc1\x39\xa5\x90\xc4\xa4\x82\x11\x06\xe6\x73\x28\x7a\xf5\x52\x57\x9b\x52\x05\xad\xf4\xc0\x40\x06\xe3\xe1\xf4\x80\x1f\x84\xc3\xdd\x95\xea\x9d\xf6\xb7\x00\xd2\x6d\x90\xf3\x03\x2a\x57\x98\xf2\x42\x0a\xbe\x02\x98\x5e\x07\x4d\x85\x47\x62\x4d\xf9\x03\x59\x68\x20\xeb\xd5\x88\x2b\xa4\xba\x15\x3b\xc0\xd3\x4e\xe6\xc6\x99\x76\xbf\x93\xe0\x9e\xc9\x3f\x72\x0f\x02\xb7\xfd\x73\x80\xb1\x59\x90\x9d\x19\xaf\x03\xe1\x2e\x1d\xd3\x1c\xb5\x93\xf7\x1b\x3b\xd9\x33\x2c\x68\xe5\xfb\x42\xcf\x1e\xe2\xb3\xc0\x4e\x39\x8c\xe2\xad\xb7\x06\xe7\x06\xa2\xd1\x46\x54\xe2\x1d\x7e\x08\xf4\x70\xb3\x08\xd9\xd2\xe2\x8e\x75\x01\xfa\x90\xf1\x10\x52\xa5\xd2\x5c\x68\x39\x37\x3c\x88\x54\x96\x60\x57\xaa\x23\xf0\x03\x78\x06\x9e\x81\x52\xf0\x28\x93\x74\x17\xc1\x86\xaa\xfe\x0a\x23\x4b\x52\x89\xd6\x75\xc9\xf2\x40\x4a\x71\x64\x1f\x16\xfb\xdb\x05\x08\xfb\x28\x4c\xae\x90\x36\x11\x3e\x54\x80\x12\xe9\xb4\xa0\x3c\x3f\x90\x48\x0d\xa3\xae\xad\x43\x75\xf4\x6c\x17\x79\x87\xaa\x06\x15\xb0\x4e\xac\x23\x75\x38\xc8\xae\x76\xcc\x41\x19\xf7\x93\x30\x10\xed\x0a\xf8\xf0\x41\xf8\x70\x62\xa5\x82\x67\x8c\xa6\x1a\x26\x97\x99\xcd\xf9\xfa\x48\x14\x92\xa5\x64\x41\x95\xde\x28\xec\x66\xd4\x1a\x24\x5c\xd1\xef\x8d\x5d\x43\x7f\x19\xa2\x0c\x26\xef\x10\x65\xc3\x60\x71\x60\x88\xaa\xb3\x8c\x2e\xcc\x62\xb2\x73\x06\xf7\x54\x17\x00\x01\x57\x0d\x9e\x44\x2f\x9e\x83\xe8\x78\x3c\x1e\x3f\xdd\x9d\xe2\x17\x5a\xc1\xe4\xf6\x0b\xad\x56\xe9\xf0\x4d\x83\x87\xb0\xe7\x66\xc3\x84\x55\xea\xae\xcd\x86\x7a\xed\x82\x76\xba\xc7\xf2\x10\xaf\xba\xc7\xf2\x41\xe8\xda\x3b\x8f\xaf\xcd\x73\xa7\xa2\x2f\xbb\x92\x6f\x1a\x75\x7e\x1e\x7d\x43\x7d\xfa\x57\xdc\x50\xaf\x3c\x9b\xa6\x07\xbe\x2e\x1c\xbc\xca\xa5\x1c\xef\x76\x71\x38\xc0\x3c\x13\xc2\x20\xff\x79\x1e\xdd\x23\xc9\x2d\xda\x6f\x75\x7f\xec\x4d\x10\x59\xd8\x77\x34\x04\xcf\x9e\xad\x2e\x73\xbc\x3e\xc0\x3d\x65\x0c\xcc\x09\x70\xd9\x57\x0c\x4e\x5f\x81\x42\xd4\x52\x01\x94\x99\xa9\xe8\x82\x2c\xc1\x3d\x31\xb9\x55\xeb\x6f\x78\xfd\x06\xa8\xe7\x1b\x60\xd4\xad\x6e\x3f\xab\x68\x0c\x3d\x19\x4b\x71\xef\x4d\xdc\xb4\xf9\x96\xe6\x0d\x85\x6d\x1f\xb5\x95\x64\xaf\xff\x3d\x08\xd7\x68\x6e\x77\xab\x29\x8c\x5e\xf8\x87\x28\x95\x49\x09\xcc\x28\x57\x1a\xf1\x94\x04\x50\x8e\xa9\xbd\xe5\xef\x3f\xd5\xb0\xb4\xa2\x56\xe3\x50\x73\xff\x06\x71\xbd\x47\x70\xb4\xd1\x80\x27\xba\x1e\x36\x09\xe0\x66\x48\x16\xa8\xac\x18\x79\x6f\xea\xaf\xcc\x8e\xdc\x3c\x72\x6a\xec\x61\x52\x11\xad\x1c\x5b\x71\xb6\xe3\xd1\x28\x65\xa2\x79\xdd\x84\xa9\x2a\x69\x33\x81\xee\xfb\xa4\x0b\xdb\x6f\x45\x34\xbc\x4b\x1a\x38\x08\xfe\xcd\x24\x10\xd4\x9b\xf6\xab\xa4\xf5\x97\x49\x1d\xdb\x06\x60\x1d\x52\xfa\x13\x54\xd1\x49\xb0\x83\xd9\x8c\x9a\xe9\x9a\x42\x54\x08\x49\xbf\x1a\x6b\xb3\xd5\xbb\x9f\xeb\x9f\x6f\x3f\x76\xcc\xd9\xc1\xe5\xeb\xfa\xef\xad\xd7\x4e\x28\x76\xe7\x5a\xe8\x1f\x01\x86\x4b\x07\xbf\xeb\xfb\x98\xb7\xdb\xd8\x60\x10\x7f\x09\xd0\x82\x43\xbb\x8c\x96\xa4\x12\x61\xe4\x1a\x64\xdb\x29\x82\xb7\xe3\xb7\x77\xa2\x77\x42\x96\x26\xaf\x28\x05\xbb\xb5\x11\xf9\xb8\x81\x29\xed\xdb\xce\xc1\x28\x1e\x62\x78\x59\x33\x4d\x2b\x46\x86\x83\xb9\x9b\xbc\x77\xcc\xff\xfa\xef\x66\x7b\x5b\xbb\x82\xdc\x90\x92\x69\xfb\xc5\x8e\x48\x7b\x5f\x98\xda\xd6\x8b\x30\x57\x47\x8c\xa6\x48\x19\x70\xe0\xbe\x36\x61\x98\xb6\xad\x78\x5d\xce\xcd\xeb\xc1\x41\x1d\x50\xdc\x21\x0c\x4a\xf3\xaa\xf2\x08\x82\x12\x2d\xec\xaf\x77\xa6\xa3\xbe\x1c\x7d\xd9\x1f\x14\xad\x93\xb5\x10\xe1\x19\xd2\xec\x61\x38\xd7\xf4\x35\x52\xdb\x0f\xfb\xac\x54\xde\x21\x76\x00\xb8\xba\xa2\xde\x85\xab\x27\x47\xea\x21\x91\xd7\x2b\x06\x35\xd0\x27\xd3\x11\xed\x1b\x90\xea\x83\x38\xb5\x4d\x7d\xb4\x15\x20\x3e\x8c\x52\x0f\x8d\x51\xbf\x05\xa1\x76\x87\x1a\xd7\xb5\xe0\xc0\x2c\x09\x94\x12\xab\x45\xab\x36\xca\xf3\x6d\x94\x1e\xb7\xa4\xad\x49\x7b\xbe\x4c\x73\x2e\x24\x99\x3d\x6c\x6d\xd7\x11\x26\x97\xf6\xd7\x9b\x58\x35\xd7\xef\x43\x46\xde\xcb\x95\x3d\xfd\xae\x27\x5b\x2e\x63\x46\x33\x92\x2e\x53\x46\xc6\x66\xd7\x7b\x0e\xc6\xe3\x31\xdc\x4f\x72\x7b\x0b\xd8\x93\x1c\x31\x8a\x14\x51\xc3\xa2\x3f\xe2\x0a\xb1\xa1\xf8\x98\x3b\xc4\xd6\xe0\xe0\x97\xbe\x62\x40\xb5\x8f\x93\xb7\x42\xd2\x3c\xa0\x3f\xa0\xbc\x9e\xe2\xe3\xe4\x6d\x06\x5f\xbb\x8f\xb0\x7b\x00\x91\x81\x9a\xa3\x3b\x44\x99\x49\x47\x02\xe5\x1f\xb9\xec\xa8\x05\x23\xab\x7d\x1d\x45\xee\x9b\xf9\x97\x3a\x3a\xe9\x9d\xf0\xba\x85\x96\xfa\x1c\x70\x71\x58\x1f\xee\x08\xef\x9a\xcc\x77\x73\x67\x31\x08\xf5\x12\x0b\xef\xd6\x0f\x83\xfb\x9f\x33\xbd\xaa\x60\x72\xed\x24\xdd\x82\xfa\x3a\x9f\xe1\xc8\xe9\x2b\x47\xfe\x36\xc1\x41\x7b\x2b\xf9\xbe\xb0\xbe\xf5\xf0\xf7\x7f\x15\xa2\x4f\x5a\x4f\x99\x03\xda\x6a\xbd\x69\xfe\xbf\x8a\xdd\xdb\x60\xbd\x51\xff\x9e\x20\xfc\x21\xa0\xb7\x21\x6c\x6c\x80\x79\x7e\xb5\xdb\x20\x51\x3d\x94\x2f\xdf\x27\x22\x78\x62\xdd\x78\x60\xfe\xae\xc8\x3c\x02\x6d\x93\xed\xa8\xe9\x5b\x05\x30\x2e\x00\x93\x8f\xcb\x8a\x3c\x0e\xb7\x04\x0a\xdb\xe3\x7e\xa6\x60\x92\xa9\x1d\x41\x42\x2d\x19\x4c\x6a\xb9\x33\xbc\x39\x81\x89\x3a\xd9\xb1\x73\x9e\x2a\x98\xe4\xe9\xae\x53\x41\x5f\x6b\x03\x01\xec\xcf\xa3\x81\x49\xbf\x38\x68\xad\x8e\x06\xbb\x26\x0a\x98\xa3\xfd\xf8\x6f\xcd\x54\xb1\xc1\x1b\x48\x12\x34\x64\x2a\xd0\xcb\xcd\x78\xcb\x35\x84\x81\x14\xf7\x6a\x0a\x4f\xbb\xb0\xfa\xef\xbf\x43\x26\x52\x77\x97\xfe\x1a\xc0\x49\xc9\xf5\x24\xf8\xe3\x1f\x7f\x37\x17\x70\x9e\xe5\xee\xa2\xda\xb8\xd8\x15\xf5\x11\x81\xb8\x9a\x49\x82\xb0\xe0\x6c\x09\x81\x6d\x24\xdd\x1b\xc0\x5d\xe2\x71\x8b\x46\x72\x43\x10\x8e\xcc\xe7\x9a\x5e\x87\xe4\x09\x33\xb0\x69\xd4\xff\xc7\x11\xb7\x12\x4d\x56\x37\xc4\x96\xed\x1b\x76\x2b\xc1\xdb\x34\xf8\x8f\x8d\x71\x77\x62\x32\x26\xc9\x68\x14\xab\x54\xd2\x4a\x03\x25\xd3\xd5\x1f\x2e\x7e\x56\x93\xcf\xbf\xd5\x44\x2e\xa3\x93\xf1\xd9\xf8\x85\xfd\xd3\xc5\xcf\xca\xa8\xd9\xf5\x4e\x36\x0e\xeb\xfe\xb9\xe3\x6e\x63\x50\x55\xf5\x7a\x86\xae\xc9\x68\xf4\x2f\x4f\xb0\x48\xeb\x92\x70\xfd\x74\x6c\x1c\x67\xf9\x24\xab\xb9\x4d\xe1\x3c\x79\x6a\xfe\x78\x11\x00\xfb\x8c\xf9\x8a\x2a\xfd\x04\xc2\xa7\x6f\x46\x7f\x3c\x7d\x33\x6a\x53\x9a\x14\xba\x64\xc9\xe8\x7f\x06\x00\x3b\xe7\xf8\xb7\x56\x3a\x00\x00")
 
 func indexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -293,7 +283,27 @@ func indexHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "index.html", size: 4468, mode: os.FileMode(436), modTime: time.Unix(1605730710, 0)}
+	info := bindataFileInfo{name: "index.html", size: 14934, mode: os.FileMode(436), modTime: time.Unix(1792324395, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _loginHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9c\x54\x4d\x6f\xdc\x36\x13\xbe\xf3\x57\xcc\xcb\x93\x0d\x84\xab\xbc\x8e\x9d\x04\x29\x25\xa0\x45\x7c\x30\x92\x20\x6e\x93\xb6\xbe\x15\x14\x39\x2b\x31\x4b\x91\xca\x70\xb4\x1f\x29\xfa\xdf\x0b\x69\xd7\x6b\x6b\x93\x16\x6d\x2f\x22\x47\xf3\xf1\x3c\x7c\x38\x1c\xfd\x3f\x97\x2c\xef\x7a\x84\x96\xbb\x50\x09\x7d\xbf\xa0\x71\x95\xd0\xec\x39\x60\x75\x1d\x4c\x66\x6f\x33\x1a\xb2\xed\x2b\x20\xcc\x9c\x08\x21\x47\xd3\xe7\x36\x71\xd6\xc5\x3e\x4e\xe8\x0e\xd9\x40\x34\x1d\x96\x72\xed\x71\xd3\x27\x62\x09\x36\x45\xc6\xc8\xa5\xdc\x78\xc7\x6d\xe9\x70\xed\x2d\xaa\xc9\x78\x02\x3e\x7a\xf6\x26\xa8\x6c\x4d\xc0\xf2\xff\x4f\x20\xb7\xe4\xe3\x4a\x71\x52\x4b\xcf\x65\x4c\xb2\x12\x3a\xf8\xb8\x02\xc2\x50\xca\xcc\xbb\x80\xb9\x45\x64\x09\x2d\xe1\xb2\x94\x85\xc9\x19\x39\x17\x36\xe7\xa2\x4e\x89\x33\x93\xe9\x17\x9d\x8f\x0b\x9b\xb3\xfc\x3a\xcb\x47\xc6\x86\x3c\xef\x4a\x99\x5b\xf3\xec\xe5\xa5\xfa\x78\xf7\x92\x2f\x5e\x5c\xdb\x9f\xae\x9f\x61\xe1\xdb\x9f\x5f\x7c\xe9\x7e\xdc\xfe\x12\xed\xeb\xef\x77\x57\xc3\xcd\x9b\x2f\x97\x74\xbd\x6a\x6e\xee\xf0\x1d\xba\xcb\x77\x4f\x3f\x85\xe5\xcd\xeb\xdb\x75\xf3\x7c\xf8\xfc\xe6\xe6\x62\x7b\x47\x17\x12\x2c\xa5\x9c\x13\xf9\xc6\xc7\x52\x9a\x98\xe2\xae\x4b\x43\x1e\x99\x4f\xc8\x95\xa8\x93\xdb\xc1\xef\x02\xa0\x37\xce\xf9\xd8\x28\x4e\xfd\x2b\xb8\x7a\xde\x6f\xbf\x13\x7f\x08\xa1\x8b\x43\x9c\xd0\xc5\x41\xf9\x31\xe3\x70\x0f\x48\x95\xd0\xd1\xac\xc1\x06\x93\x73\x29\xa3\x59\xd7\x86\x60\xbf\x28\xdc\xf6\x26\x3a\xd5\xb9\xfb\x1f\xce\xd0\x0a\xea\x66\xbf\x2e\xfd\x16\xdd\x88\x26\x2b\x01\xa0\xcd\xbc\x86\xaa\xc9\x44\x77\x54\x52\x56\x77\x8a\xc9\x58\x4e\xa4\x0b\x53\x09\x5d\x44\xb3\xae\x0e\x9c\x46\x16\x63\x09\xe7\x8f\x44\xc6\x8b\x35\x3e\x22\x4d\xc5\xe7\x3e\x4a\x1b\xf8\x34\x64\xf6\xcb\x9d\x3a\x34\x80\xb2\x18\xf9\x18\x7c\x5a\x2a\xa8\xce\xa9\xcb\xa3\xf3\xc4\x6d\xc8\x41\xb7\x9b\xf9\x01\x74\x7b\xf5\x38\x40\xed\x69\xca\xea\x83\x6f\x22\xf8\xa8\x8b\xf6\x6a\x16\x7e\x52\x50\x8d\x1a\xcf\x0a\xce\x63\x4c\x40\x62\x98\xbe\xca\x99\xd8\x20\x81\x53\x31\x45\x94\xe0\x5d\x29\x43\x6a\x7c\xfc\x0d\x89\x12\xc9\xea\x57\x4a\xb1\x81\xe9\x17\x24\x82\xde\xe4\xbc\x49\xe4\x74\xe1\xfc\xfa\x04\x61\x99\xa8\x03\x63\xd9\xa7\x58\xca\x62\x4a\x91\xd0\x21\xb7\xc9\x95\xf2\xf6\xfd\x87\x8f\x27\x94\xe6\xa4\xc6\x6c\xd5\x50\x1a\xfa\xaf\xc2\x00\x74\x30\x35\x06\x58\x26\x2a\xe5\x90\x91\xc6\x97\x28\xab\xb7\x23\x84\x2e\x26\xdf\x37\x72\x7c\xec\x07\x86\x71\x06\x94\x92\x71\xcb\x72\x06\x35\xde\x1d\xa5\x20\x0f\xaf\xfa\x58\x75\x92\xe0\xc1\x32\x03\xa7\x65\xb2\x43\x06\xc2\xcf\x83\x27\x74\xa7\x48\xdf\x90\xe2\x3f\x9e\xec\x5e\x5c\x59\xdd\x1e\x65\xfe\x27\x87\x3b\xe6\xfd\xdd\x01\x1f\x82\xbc\x7b\x6c\xfd\xbb\x63\xd5\x03\x73\x8a\x07\xdc\x3c\xd4\x9d\x7f\x90\xb5\xe6\x08\x35\x47\xd5\x93\xef\x0c\xed\xa6\x7d\x1d\x92\x5d\x3d\x6a\xdc\x7d\xfe\xbc\xac\x2e\x46\xbe\x95\xf8\x4b\xec\x99\xf9\xc8\x38\x6e\x0f\x1b\xa1\x8b\xb1\xf3\x2b\x21\x74\xb6\xe4\x7b\xae\x84\x5f\xc2\xd9\xc6\x47\x97\x36\x8b\x90\xac\x19\x7b\x73\xb1\x1f\xf7\x0b\x1f\x1d\x6e\xdf\x2f\xcf\xe4\xd4\xe9\xa5\x3c\x87\xaa\x84\xa7\xe7\xd3\x34\x73\xc9\x0e\x1d\x46\x5e\x34\xc8\xd7\x01\xc7\xed\x0f\xbb\x1b\x77\x36\x7b\x1b\xe7\x8b\x49\xee\xb7\x3e\xf3\x82\xb0\x4b\x6b\x3c\x93\x87\x77\x74\x3e\x4e\x3f\x5d\xdc\xb3\x10\xba\x68\xb9\x0b\x95\xf8\x73\x00\x91\x15\xd5\x6d\x96\x06\x00\x00")
+
+func loginHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_loginHtml,
+		"login.html",
+	)
+}
+
+func loginHtml() (*asset, error) {
+	bytes, err := loginHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "login.html", size: 1686, mode: os.FileMode(420), modTime: time.Unix(1792319252, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -350,17 +360,18 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"404.html": _404Html,
-	"assets/css/bootstrap.min.css": assetsCssBootstrapMinCss,
-	"assets/css/bootstrap.min.css.map": assetsCssBootstrapMinCssMap,
+	"404.html":                                      _404Html,
+	"assets/css/bootstrap.min.css":                  assetsCssBootstrapMinCss,
+	"assets/css/bootstrap.min.css.map":              assetsCssBootstrapMinCssMap,
 	"assets/fonts/glyphicons-halflings-regular.ttf": assetsFontsGlyphiconsHalflingsRegularTtf,
-	"assets/js/app.js": assetsJsAppJs,
-	"assets/js/bootstrap.bundle.min.js.map": assetsJsBootstrapBundleMinJsMap,
-	"assets/js/bootstrap.min.js": assetsJsBootstrapMinJs,
-	"assets/js/bootstrap.min.js.map": assetsJsBootstrapMinJsMap,
-	"assets/js/jquery-3.5.1.min.js": assetsJsJquery351MinJs,
-	"favicon.ico": faviconIco,
-	"index.html": indexHtml,
+	"assets/js/app.js":                              assetsJsAppJs,
+	"assets/js/bootstrap.bundle.min.js.map":         assetsJsBootstrapBundleMinJsMap,
+	"assets/js/bootstrap.min.js":                    assetsJsBootstrapMinJs,
+	"assets/js/bootstrap.min.js.map":                assetsJsBootstrapMinJsMap,
+	"assets/js/jquery-3.5.1.min.js":                 assetsJsJquery351MinJs,
+	"favicon.ico":                                   faviconIco,
+	"index.html":                                    indexHtml,
+	"login.html":                                    loginHtml,
 }
 
 // AssetDir returns the file names below a certain
@@ -406,22 +417,23 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"404.html": &bintree{_404Html, map[string]*bintree{}},
 	"assets": &bintree{nil, map[string]*bintree{
 		"css": &bintree{nil, map[string]*bintree{
-			"bootstrap.min.css": &bintree{assetsCssBootstrapMinCss, map[string]*bintree{}},
+			"bootstrap.min.css":     &bintree{assetsCssBootstrapMinCss, map[string]*bintree{}},
 			"bootstrap.min.css.map": &bintree{assetsCssBootstrapMinCssMap, map[string]*bintree{}},
 		}},
 		"fonts": &bintree{nil, map[string]*bintree{
 			"glyphicons-halflings-regular.ttf": &bintree{assetsFontsGlyphiconsHalflingsRegularTtf, map[string]*bintree{}},
 		}},
 		"js": &bintree{nil, map[string]*bintree{
-			"app.js": &bintree{assetsJsAppJs, map[string]*bintree{}},
+			"app.js":                      &bintree{assetsJsAppJs, map[string]*bintree{}},
 			"bootstrap.bundle.min.js.map": &bintree{assetsJsBootstrapBundleMinJsMap, map[string]*bintree{}},
-			"bootstrap.min.js": &bintree{assetsJsBootstrapMinJs, map[string]*bintree{}},
-			"bootstrap.min.js.map": &bintree{assetsJsBootstrapMinJsMap, map[string]*bintree{}},
-			"jquery-3.5.1.min.js": &bintree{assetsJsJquery351MinJs, map[string]*bintree{}},
+			"bootstrap.min.js":            &bintree{assetsJsBootstrapMinJs, map[string]*bintree{}},
+			"bootstrap.min.js.map":        &bintree{assetsJsBootstrapMinJsMap, map[string]*bintree{}},
+			"jquery-3.5.1.min.js":         &bintree{assetsJsJquery351MinJs, map[string]*bintree{}},
 		}},
 	}},
 	"favicon.ico": &bintree{faviconIco, map[string]*bintree{}},
-	"index.html": &bintree{indexHtml, map[string]*bintree{}},
+	"index.html":  &bintree{indexHtml, map[string]*bintree{}},
+	"login.html":  &bintree{loginHtml, map[string]*bintree{}},
 }}
 
 // RestoreAsset restores an asset under the given directory
@@ -0,0 +1,247 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+)
+
+// aclEnabled - without rbac.roles every authenticated user may do everything
+func (rt *Router) aclEnabled() bool {
+	return len(rt.conf.Rbac.Roles) > 0
+}
+
+// roles returns roles bound to the user and to its groups
+func (rt *Router) roles(id *identity) []string {
+	set := make(map[string]bool)
+	for _, r := range rt.conf.Rbac.DefaultRoles {
+		set[r] = true
+	}
+	if id != nil {
+		for _, r := range rt.conf.Rbac.Users[id.Name] {
+			set[r] = true
+		}
+		for _, g := range id.Groups {
+			for _, r := range rt.conf.Rbac.Groups[g] {
+				set[r] = true
+			}
+		}
+		for _, r := range id.Roles {
+			set[r] = true
+		}
+	}
+
+	var res []string
+	for r := range set {
+		res = append(res, r)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// matchAny reports whether name matches one of glob patterns, empty list matches everything
+func matchAny(patterns []string, name string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// requestedIndices returns every index or pattern the request names: indices
+// and the comma-separated parts of index and ipattern
+func requestedIndices(request *apiRequest) []string {
+	names := append([]string{}, request.Values.Indices...)
+	if request.Values.Index != "" {
+		names = append(names, strings.Split(request.Values.Index, ",")...)
+	}
+	if request.Values.Ipattern != "" {
+		names = append(names, strings.Split(request.Values.Ipattern, ",")...)
+	}
+	return names
+}
+
+// authorize decides whether the user may call the action with the given values.
+// The request is allowed when one of the user roles permits the action,
+// the repository and every requested index.
+func (rt *Router) authorize(id *identity, request *apiRequest) error {
+	// get_user нужен UI любого пользователя
+	if !rt.aclEnabled() || request.Action == "get_user" {
+		return nil
+	}
+	names := requestedIndices(request)
+
+	actionAllowed := false
+	for _, name := range rt.roles(id) {
+		role, ok := rt.conf.Rbac.Roles[name]
+		// роль без actions не разрешает ничего
+		if !ok || len(role.Actions) == 0 || !matchAny(role.Actions, request.Action) {
+			continue
+		}
+		actionAllowed = true
+		if request.Values.Repo != "" && !matchAny(role.Repositories, request.Values.Repo) {
+			continue
+		}
+		indicesAllowed := true
+		for _, i := range names {
+			// исключения доступ не расширяют
+			if strings.HasPrefix(i, "-") {
+				continue
+			}
+			if !matchAny(role.Indices, i) {
+				indicesAllowed = false
+			}
+		}
+		if indicesAllowed {
+			return nil
+		}
+	}
+
+	if !actionAllowed {
+		return errors.New("action " + request.Action + " is not allowed")
+	}
+	return errors.New("access to the repository or indices is not allowed")
+}
+
+// isAdmin reports whether the user may manage jobs and schedules of other users:
+// everybody without rbac.roles, otherwise holders of a role with admin: true
+func (rt *Router) isAdmin(id *identity) bool {
+	if !rt.aclEnabled() {
+		return true
+	}
+	for _, name := range rt.roles(id) {
+		if role, ok := rt.conf.Rbac.Roles[name]; ok && role.Admin {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleRepository reports whether the user may see repo
+func (rt *Router) visibleRepository(id *identity, repo string) bool {
+	if !rt.aclEnabled() {
+		return true
+	}
+	for _, name := range rt.roles(id) {
+		if role, ok := rt.conf.Rbac.Roles[name]; ok && matchAny(role.Repositories, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleIndex reports whether the user may see index of a snapshot in repo,
+// with an empty repo - an index of the cluster
+func (rt *Router) visibleIndex(id *identity, repo, index string) bool {
+	if !rt.aclEnabled() {
+		return true
+	}
+	for _, name := range rt.roles(id) {
+		role, ok := rt.conf.Rbac.Roles[name]
+		if ok && (repo == "" || matchAny(role.Repositories, repo)) && matchAny(role.Indices, index) {
+			return true
+		}
+	}
+	return false
+}
+
+// visibleJob reports whether the user may see the job: admins see every job,
+// others only jobs of visible repositories and indices
+func (rt *Router) visibleJob(id *identity, j restoreJob) bool {
+	if rt.isAdmin(id) {
+		return true
+	}
+	if !rt.visibleRepository(id, j.Repo) {
+		return false
+	}
+	for _, i := range j.Indices {
+		if !rt.visibleIndex(id, j.Repo, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// visibleEvent reports whether the user may see the event of the history:
+// events without a repository or an index are seen by everybody
+func (rt *Router) visibleEvent(id *identity, e event) bool {
+	if rt.isAdmin(id) {
+		return true
+	}
+	if e.Repo != "" && !rt.visibleRepository(id, e.Repo) {
+		return false
+	}
+	return e.Index == "" || rt.visibleIndex(id, e.Repo, e.Index)
+}
+
+// catalogInfo returns counters of the catalog over repositories the user may see,
+// the last refresh error may name any repository and is shown to admins only
+func (rt *Router) catalogInfo(id *identity) catalogStats {
+	admin := rt.isAdmin(id)
+	res := rt.catalog.info(func(repo string) bool { return admin || rt.visibleRepository(id, repo) })
+	if !admin {
+		res.LastError = ""
+	}
+	return res
+}
+
+// filterRepositories drops repositories the user may not see from _cat/repositories output
+func (rt *Router) filterRepositories(id *identity, response []byte) ([]byte, error) {
+	if !rt.aclEnabled() {
+		return response, nil
+	}
+	var list []map[string]interface{}
+	err := json.Unmarshal(response, &list)
+	if err != nil {
+		return nil, err
+	}
+	res := []map[string]interface{}{}
+	for _, r := range list {
+		if repo, _ := r["id"].(string); rt.visibleRepository(id, repo) {
+			res = append(res, r)
+		}
+	}
+	return json.Marshal(res)
+}
+
+// filterSnapshotIndices drops indices the user may not see from _snapshot/_status output
+func (rt *Router) filterSnapshotIndices(id *identity, repo string, response []byte) ([]byte, error) {
+	if !rt.aclEnabled() {
+		return response, nil
+	}
+	var status map[string]interface{}
+	err := json.Unmarshal(response, &status)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, _ := status["snapshots"].([]interface{})
+	for _, s := range snapshots {
+		snap, _ := s.(map[string]interface{})
+		indices, _ := snap["indices"].(map[string]interface{})
+		for name := range indices {
+			if !rt.visibleIndex(id, repo, name) {
+				delete(indices, name)
+			}
+		}
+	}
+	return json.Marshal(status)
+}
@@ -0,0 +1,308 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uzhinskiy/extractor/modules/config"
+)
+
+// testConfig parses yaml the way the server reads its config file
+func testConfig(t *testing.T, yaml string) config.Config {
+	t.Helper()
+	f, err := ioutil.TempFile("", "extractor-*.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(yaml); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	return config.Parse(f.Name())
+}
+
+// testRouter - router with the memory store and no background work
+func testRouter(t *testing.T, yaml string) *Router {
+	t.Helper()
+	rt := &Router{conf: testConfig(t, yaml)}
+	rt.netClientPrepare()
+	var err error
+	rt.store, err = newStore(rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt.janitor = newJanitor(rt)
+	return rt
+}
+
+const aclConfig = `
+rbac:
+  users:
+    viewer: [viewer]
+    operator: [operator]
+    operator2: [operator]
+    admin: [admin]
+  roles:
+    viewer:
+      actions: ["get_*"]
+      repositories: [archive]
+      indices: ["logs-*", "extracted_logs-*"]
+    operator:
+      actions: [restore, cancel_job, "*_schedule", get_indices, del_index, pin_index, unpin_index, extend_index]
+      repositories: [archive]
+      indices: ["logs-*", "extracted_logs-*"]
+    admin:
+      actions: ["*"]
+      admin: true
+`
+
+func TestAuthorize(t *testing.T) {
+	rt := testRouter(t, aclConfig)
+	tests := []struct {
+		user    string
+		action  string
+		repo    string
+		index   string
+		pattern string
+		indices []string
+		allowed bool
+	}{
+		{user: "viewer", action: "get_snapshots", repo: "archive", allowed: true},
+		{user: "viewer", action: "get_snapshots", repo: "daily"},
+		{user: "viewer", action: "restore", repo: "archive", indices: []string{"logs-a"}},
+		{user: "nobody", action: "get_snapshots", repo: "archive"},
+
+		{user: "operator", action: "restore", repo: "archive", indices: []string{"logs-a", "-logs-a-debug"}, allowed: true},
+		{user: "operator", action: "restore", repo: "archive", indices: []string{"logs-a", "other"}},
+		// исключение не расширяет доступ
+		{user: "operator", action: "restore", repo: "archive", indices: []string{"-other"}, allowed: true},
+
+		{user: "operator", action: "del_index", index: "extracted_logs-a", allowed: true},
+		{user: "operator", action: "del_index", index: "extracted_other"},
+		{user: "operator", action: "del_index", index: "*"},
+		{user: "operator", action: "del_index", index: "_all"},
+		{user: "operator", action: "del_index", index: "extracted_logs-a,extracted_other"},
+		{user: "operator", action: "pin_index", index: "extracted_logs-a", allowed: true},
+		{user: "operator", action: "pin_index", index: "extracted_other"},
+		{user: "operator", action: "unpin_index", index: "extracted_other"},
+		{user: "operator", action: "extend_index", index: "extracted_other"},
+
+		{user: "operator", action: "get_indices", pattern: "extracted_logs-*", allowed: true},
+		{user: "operator", action: "get_indices", pattern: "extracted_logs-a,extracted_logs-b", allowed: true},
+		{user: "operator", action: "get_indices", pattern: "*"},
+		{user: "operator", action: "get_indices", pattern: "extracted_logs-a,other"},
+		{user: "viewer", action: "get_snapshots", repo: "archive", pattern: "other-*"},
+
+		{user: "admin", action: "del_index", index: "anything", allowed: true},
+	}
+	for _, tt := range tests {
+		var request apiRequest
+		request.Action = tt.action
+		request.Values.Repo = tt.repo
+		request.Values.Index = tt.index
+		request.Values.Ipattern = tt.pattern
+		request.Values.Indices = tt.indices
+		err := rt.authorize(&identity{Name: tt.user}, &request)
+		if (err == nil) != tt.allowed {
+			t.Errorf("%s %s repo=%q index=%q ipattern=%q indices=%v: allowed %v, want %v (%v)",
+				tt.user, tt.action, tt.repo, tt.index, tt.pattern, tt.indices, err == nil, tt.allowed, err)
+		}
+	}
+}
+
+func TestAuthorizeWithoutRoles(t *testing.T) {
+	rt := testRouter(t, "")
+	var request apiRequest
+	request.Action = "del_index"
+	request.Values.Index = "anything"
+	if err := rt.authorize(&identity{Name: "someone"}, &request); err != nil {
+		t.Fatalf("without rbac.roles everything is allowed, got %v", err)
+	}
+}
+
+func TestDelIndexRefusesPatterns(t *testing.T) {
+	rt := testRouter(t, "")
+	for _, index := range []string{"_all", "*", "logs-*", "a,b", "_snapshot/archive", "-a", "a b"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, "/api/v1/indices/x", nil)
+		request := apiRequest{Action: "del_index"}
+		request.Values.Index = index
+		rt.dispatch(w, r, &request)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("del_index %q: status %d, want 400", index, w.Code)
+		}
+	}
+	for _, p := range []string{"x/_settings", "x?pretty", "-x"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/indices", nil)
+		request := apiRequest{Action: "get_indices"}
+		request.Values.Ipattern = p
+		rt.dispatch(w, r, &request)
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "bad index pattern") {
+			t.Errorf("get_indices %q: status %d %s, want 400", p, w.Code, w.Body)
+		}
+	}
+}
+
+func TestPlainIndexName(t *testing.T) {
+	for name, want := range map[string]bool{
+		"extracted_logs-a-01-11-2020": true,
+		".kibana":                     true,
+		"":                            false,
+		"_all":                        false,
+		"logs-*":                      false,
+		"a,b":                         false,
+		"a/b":                         false,
+		"-a":                          false,
+		"..":                          false,
+	} {
+		if got := plainIndexName(name); got != want {
+			t.Errorf("plainIndexName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestIsAdmin(t *testing.T) {
+	rt := testRouter(t, aclConfig)
+	for user, want := range map[string]bool{"admin": true, "operator": false, "nobody": false} {
+		if got := rt.isAdmin(&identity{Name: user}); got != want {
+			t.Errorf("isAdmin(%s) = %v, want %v", user, got, want)
+		}
+	}
+	if rt.isAdmin(nil) {
+		t.Error("anonymous user is an admin")
+	}
+	// без rbac.roles ограничений нет
+	if !testRouter(t, "").isAdmin(&identity{Name: "someone"}) {
+		t.Error("without rbac.roles everybody is an admin")
+	}
+}
+
+func TestListsHideInvisible(t *testing.T) {
+	rt := testRouter(t, `
+rbac:
+  users:
+    viewer: [viewer]
+    admin: [admin]
+  roles:
+    viewer:
+      actions: ["get_*", "list_*"]
+      repositories: [archive]
+      indices: ["logs-*", "extracted_logs-*"]
+    admin:
+      actions: ["*"]
+      admin: true
+`)
+	rt.queue = newRestoreQueue(rt)
+	rt.jobs = newJobRegistry(rt)
+	rt.schedules = newScheduler(rt)
+	rt.catalog = newCatalog(rt)
+	rt.jobs.add(&restoreJob{ID: "j1", Repo: "archive", Indices: []string{"logs-a"}, State: jobDone})
+	rt.jobs.add(&restoreJob{ID: "j2", Repo: "daily", Indices: []string{"logs-a"}, State: jobDone})
+	rt.jobs.add(&restoreJob{ID: "j3", Repo: "archive", Indices: []string{"secret-a"}, State: jobDone})
+	rt.schedules.save(schedule{ID: "s1", Name: "archive", Repo: "archive"})
+	rt.schedules.save(schedule{ID: "s2", Name: "daily", Repo: "daily"})
+	now := time.Now()
+	rt.store.AddEvent(event{Time: now.Add(-4 * time.Minute), User: "viewer", Action: "login"})
+	rt.store.AddEvent(event{Time: now.Add(-3 * time.Minute), Action: "restore", Repo: "archive", Snapshot: "s"})
+	rt.store.AddEvent(event{Time: now.Add(-2 * time.Minute), Action: "restore", Repo: "daily", Snapshot: "s"})
+	rt.store.AddEvent(event{Time: now.Add(-time.Minute), User: "janitor", Action: "del_index", Index: "extracted_secret-a"})
+	rt.catalog.repos.List = []catalogRepo{{ID: "archive"}, {ID: "daily"}}
+	setSnapshots(rt, "archive", []esSnapshot{{Snapshot: "a1"}, {Snapshot: "a2"}})
+	setSnapshots(rt, "daily", []esSnapshot{{Snapshot: "d1"}, {Snapshot: "d2"}, {Snapshot: "d3"}})
+
+	call := func(user string, request apiRequest) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		rt.dispatch(w, identityRequest(&identity{Name: user}, "/api/v1/"), &request)
+		return w
+	}
+	ids := func(w *httptest.ResponseRecorder) []string {
+		var list []struct {
+			ID     string `json:"id"`
+			Action string `json:"action"`
+			Repo   string `json:"repo"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &list); w.Code != http.StatusOK || err != nil {
+			t.Fatalf("status %d %s", w.Code, w.Body)
+		}
+		res := []string{}
+		for _, i := range list {
+			if i.ID == "" {
+				i.ID = i.Action + ":" + i.Repo
+			}
+			res = append(res, i.ID)
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	for user, want := range map[string][]string{"viewer": {"j1"}, "admin": {"j1", "j2", "j3"}} {
+		if got := ids(call(user, apiRequest{Action: "list_jobs"})); !reflect.DeepEqual(got, want) {
+			t.Errorf("list_jobs of %s: %v, want %v", user, got, want)
+		}
+	}
+	for job, status := range map[string]int{"j1": http.StatusOK, "j2": http.StatusNotFound, "j3": http.StatusNotFound} {
+		request := apiRequest{Action: "get_job"}
+		request.Values.JobId = job
+		if w := call("viewer", request); w.Code != status {
+			t.Errorf("get_job %s: status %d, want %d", job, w.Code, status)
+		}
+	}
+
+	for user, want := range map[string][]string{"viewer": {"s1"}, "admin": {"s1", "s2"}} {
+		if got := ids(call(user, apiRequest{Action: "list_schedules"})); !reflect.DeepEqual(got, want) {
+			t.Errorf("list_schedules of %s: %v, want %v", user, got, want)
+		}
+	}
+	request := apiRequest{Action: "get_schedule"}
+	request.Values.ScheduleId = "s2"
+	if w := call("viewer", request); w.Code != http.StatusNotFound {
+		t.Errorf("get_schedule of another repository: status %d, want 404", w.Code)
+	}
+
+	if got, want := ids(call("viewer", apiRequest{Action: "get_history"})), []string{"login:", "restore:archive"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("get_history of viewer: %v, want %v", got, want)
+	}
+	// limit отсчитывается от видимых событий
+	request = apiRequest{Action: "get_history"}
+	request.Values.Limit = 1
+	if got, want := ids(call("viewer", request)), []string{"restore:archive"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("get_history of viewer with limit 1: %v, want %v", got, want)
+	}
+	if got := ids(call("admin", apiRequest{Action: "get_history"})); len(got) != 4 {
+		t.Errorf("get_history of admin: %v, want all 4", got)
+	}
+
+	for user, want := range map[string][2]int{"viewer": {1, 2}, "admin": {2, 5}} {
+		var stats catalogStats
+		w := call(user, apiRequest{Action: "get_catalog"})
+		if err := json.Unmarshal(w.Body.Bytes(), &stats); err != nil {
+			t.Fatalf("get_catalog: %d %s", w.Code, w.Body)
+		}
+		if stats.Repositories != want[0] || stats.Snapshots != want[1] {
+			t.Errorf("get_catalog of %s: %d repositories and %d snapshots, want %v", user, stats.Repositories, stats.Snapshots, want)
+		}
+	}
+}
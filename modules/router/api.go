@@ -0,0 +1,271 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/uzhinskiy/extractor/modules/version"
+	"github.com/uzhinskiy/lib.go/helpers"
+)
+
+// apiError - error with the HTTP status it is answered with
+type apiError struct {
+	Status  int
+	Message string
+}
+
+func (e *apiError) Error() string {
+	return e.Message
+}
+
+func badRequest(format string, a ...interface{}) error {
+	return &apiError{Status: http.StatusBadRequest, Message: fmt.Sprintf(format, a...)}
+}
+
+func notFound(format string, a ...interface{}) error {
+	return &apiError{Status: http.StatusNotFound, Message: fmt.Sprintf(format, a...)}
+}
+
+func httpStatus(err error) int {
+	var e *apiError
+	if errors.As(err, &e) {
+		return e.Status
+	}
+	return http.StatusInternalServerError
+}
+
+// fail writes the error envelope {"error": "...", "status": N} and logs the request
+func (rt *Router) fail(w http.ResponseWriter, r *http.Request, action string, err error) {
+	status := httpStatus(err)
+	remoteIP := helpers.GetIP(r.RemoteAddr, r.Header.Get("X-Real-IP"), r.Header.Get("X-Forwarded-For"))
+	j, _ := json.Marshal(map[string]interface{}{"error": err.Error(), "status": status})
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(j)
+	log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", action, "\t", status, "\t", err.Error(), "\t", r.UserAgent())
+}
+
+// cors allows app.allow_origin to call the API, without it no CORS header is sent
+func (rt *Router) cors(w http.ResponseWriter, methods string) {
+	origin := rt.conf.App.AllowOrigin
+	if origin == "" {
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Set("Access-Control-Allow-Methods", methods)
+	w.Header().Set("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+	// браузер не отдаёт учётные данные любому источнику
+	if origin != "*" {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+		w.Header().Add("Vary", "Origin")
+	}
+}
+
+// jsonBody refuses POST without Content-Type: application/json while basic auth
+// is accepted: an HTML form of another site can't send it, so the browser's
+// saved credentials can't be used for CSRF
+func (rt *Router) jsonBody(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost || rt.auth == nil || rt.auth.backend == nil {
+		return true
+	}
+	if mt, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mt != "application/json" {
+		rt.fail(w, r, "", &apiError{Status: http.StatusUnsupportedMediaType, Message: "Content-Type must be application/json"})
+		return false
+	}
+	return true
+}
+
+// route - resource of /api/v1 mapped to an action of ApiHandler
+type route struct {
+	method string
+	path   string // {repo}, {snapshot}, {index}, {id}, {schedule} и {check} попадают в Values
+	action string
+}
+
+var routes = []route{
+	{"GET", "repositories", "get_repositories"},
+	{"GET", "repositories/{repo}", "get_repository"},
+	{"POST", "repositories/{repo}", "create_repository"},
+	{"DELETE", "repositories/{repo}", "delete_repository"},
+	{"POST", "repositories/{repo}/verify", "verify_repository"},
+	{"POST", "repositories/{repo}/analyze", "analyze_repository"},
+	{"GET", "repositories/{repo}/checks", "list_repository_checks"},
+	{"GET", "repositories/{repo}/checks/{check}", "get_repository_check"},
+	{"GET", "repositories/{repo}/snapshots", "get_snapshots"},
+	{"POST", "repositories/{repo}/snapshots", "create_snapshot"},
+	{"GET", "repositories/{repo}/snapshots/{snapshot}", "get_snapshot"},
+	{"DELETE", "repositories/{repo}/snapshots/{snapshot}", "delete_snapshot"},
+	{"POST", "repositories/{repo}/snapshots/{snapshot}/restore", "restore"},
+	{"POST", "repositories/{repo}/snapshots/{snapshot}/preview", "preview_restore"},
+	{"GET", "indices", "get_indices"},
+	{"DELETE", "indices/{index}", "del_index"},
+	{"GET", "snapshot-indices", "find_index"},
+	{"GET", "catalog", "get_catalog"},
+	{"POST", "catalog/refresh", "refresh_catalog"},
+	{"GET", "restore-options", "get_restore_options"},
+	{"GET", "nodes", "get_nodes"},
+	{"GET", "jobs", "list_jobs"},
+	{"GET", "jobs/{id}", "get_job"},
+	{"POST", "jobs/{id}/cancel", "cancel_job"},
+	{"GET", "schedules", "list_schedules"},
+	{"POST", "schedules", "create_schedule"},
+	{"GET", "schedules/{schedule}", "get_schedule"},
+	{"POST", "schedules/{schedule}", "update_schedule"},
+	{"DELETE", "schedules/{schedule}", "delete_schedule"},
+	{"POST", "schedules/{schedule}/run", "run_schedule"},
+	{"GET", "history", "get_history"},
+	{"GET", "user", "get_user"},
+}
+
+// match returns the action for the path and fills values from its placeholders
+func (rt route) match(p string, request *apiRequest) bool {
+	want, got := strings.Split(rt.path, "/"), strings.Split(p, "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		switch want[i] {
+		case "{repo}":
+			request.Values.Repo = got[i]
+		case "{snapshot}":
+			request.Values.Snapshot = got[i]
+		case "{index}":
+			request.Values.Index = got[i]
+		case "{id}":
+			request.Values.JobId = got[i]
+		case "{schedule}":
+			request.Values.ScheduleId = got[i]
+		case "{check}":
+			request.Values.CheckId = got[i]
+		default:
+			if want[i] != got[i] {
+				return false
+			}
+		}
+		if got[i] == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// V1Handler serves resource-style routes of /api/v1 with the same actions as ApiHandler
+func (rt *Router) V1Handler(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	remoteIP := helpers.GetIP(r.RemoteAddr, r.Header.Get("X-Real-IP"), r.Header.Get("X-Forwarded-For"))
+
+	rt.cors(w, "GET,POST,DELETE,OPTIONS")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Server", version.Version)
+
+	if r.Method == "OPTIONS" {
+		return
+	}
+
+	if !rt.jsonBody(w, r) {
+		return
+	}
+
+	p := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/")
+	var request apiRequest
+	var allowed []string
+	found := false
+	for _, rr := range routes {
+		var candidate apiRequest
+		if !rr.match(p, &candidate) {
+			continue
+		}
+		if rr.method != r.Method {
+			allowed = append(allowed, rr.method)
+			continue
+		}
+		request, found = candidate, true
+		request.Action = rr.action
+		break
+	}
+	if !found {
+		if len(allowed) > 0 {
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			rt.fail(w, r, "", &apiError{Status: http.StatusMethodNotAllowed, Message: "method " + r.Method + " is not allowed"})
+			return
+		}
+		rt.fail(w, r, "", notFound("unknown resource %s", r.URL.Path))
+		return
+	}
+
+	// тело POST дополняет значения из пути
+	if r.Method == http.MethodPost && r.ContentLength != 0 {
+		path := request.Values
+		err := json.NewDecoder(r.Body).Decode(&request.Values)
+		if err != nil {
+			rt.fail(w, r, request.Action, badRequest("%s", err))
+			return
+		}
+		// значения из пути главнее тела
+		if path.Repo != "" {
+			request.Values.Repo = path.Repo
+		}
+		if path.Snapshot != "" {
+			request.Values.Snapshot = path.Snapshot
+		}
+		if path.JobId != "" {
+			request.Values.JobId = path.JobId
+		}
+		if path.ScheduleId != "" {
+			request.Values.ScheduleId = path.ScheduleId
+		}
+	}
+	q := r.URL.Query()
+	if v := q.Get("pattern"); v != "" {
+		request.Values.Ipattern = v
+	}
+	// фильтры get_snapshots
+	if v := q.Get("index"); v != "" {
+		request.Values.Ipattern = v
+	}
+	if v := q.Get("repos"); v != "" {
+		request.Values.Repos = strings.Split(v, ",")
+	}
+	if v := q.Get("name"); v != "" {
+		request.Values.SnapshotPattern = v
+	}
+	for name, value := range map[string]*string{"state": &request.Values.State, "from": &request.Values.From, "to": &request.Values.To,
+		"sort": &request.Values.Sort, "order": &request.Values.Order, "after": &request.Values.After} {
+		if v := q.Get(name); v != "" {
+			*value = v
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			rt.fail(w, r, request.Action, badRequest("limit must be a number"))
+			return
+		}
+		request.Values.Limit = limit
+	}
+
+	if !rt.allowed(w, r, &request) {
+		return
+	}
+	log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 200, "\t", r.UserAgent())
+	rt.dispatch(w, r, &request)
+}
@@ -0,0 +1,369 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uzhinskiy/extractor/modules/version"
+	"github.com/uzhinskiy/lib.go/helpers"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const sessionCookie = "extractor_session"
+
+var errBadCredentials = errors.New("wrong login or password")
+
+// identity - authenticated user
+type identity struct {
+	Name   string   `json:"name"`
+	Groups []string `json:"groups,omitempty"`
+	Roles  []string `json:"roles,omitempty"`
+}
+
+// authenticator checks login and password against a user database
+type authenticator interface {
+	Authenticate(login, password string) (*identity, error)
+}
+
+// lookuper finds a user without the password, schedules run with the groups the user has now
+type lookuper interface {
+	Lookup(login string) (*identity, error)
+}
+
+type ctxKey int
+
+const identityKey ctxKey = 0
+
+// staticAuth - users with bcrypt hashes from auth.users
+type staticAuth struct {
+	users map[string]staticUser
+}
+
+type staticUser struct {
+	hash   []byte
+	groups []string
+}
+
+// сравниваем и с несуществующим пользователем, чтобы время ответа не выдавало логины
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("extractor"), bcrypt.DefaultCost)
+
+func (a *staticAuth) Authenticate(login, password string) (*identity, error) {
+	u, ok := a.users[login]
+	hash := u.hash
+	if !ok {
+		hash = dummyHash
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || !ok {
+		return nil, errBadCredentials
+	}
+	return &identity{Name: login, Groups: u.groups}, nil
+}
+
+func (a *staticAuth) Lookup(login string) (*identity, error) {
+	u, ok := a.users[login]
+	if !ok {
+		return nil, errors.New("user " + login + " is not in auth.users")
+	}
+	return &identity{Name: login, Groups: u.groups}, nil
+}
+
+type session struct {
+	id      *identity
+	expires time.Time
+}
+
+// auth - authentication of FrontHandler and ApiHandler requests
+type auth struct {
+	sync.Mutex
+	mode     string
+	backend  authenticator
+	oidc     *oidcProvider
+	header   string
+	proxies  []*net.IPNet
+	ttl      time.Duration
+	sessions map[string]session
+}
+
+func newAuth(rt *Router) (*auth, error) {
+	c := rt.conf.Auth
+	a := &auth{
+		mode:     c.Type,
+		header:   c.Header,
+		sessions: make(map[string]session),
+	}
+
+	var err error
+	a.ttl, err = time.ParseDuration(c.SessionTTL)
+	if err != nil || a.ttl <= 0 {
+		a.ttl = 12 * time.Hour
+	}
+
+	switch c.Type {
+	case "", "none":
+		a.mode = "none"
+		log.Println("Auth: authentication is disabled, UI and API are open to everyone")
+	case "static":
+		sa := &staticAuth{users: make(map[string]staticUser)}
+		for _, u := range c.Users {
+			if _, err := bcrypt.Cost([]byte(u.Password)); err != nil {
+				return nil, errors.New("auth.users: password of " + u.Name + " is not a bcrypt hash")
+			}
+			sa.users[u.Name] = staticUser{hash: []byte(u.Password), groups: u.Groups}
+		}
+		a.backend = sa
+	case "ldap":
+		a.backend, err = newLdapAuth(rt)
+		if err != nil {
+			return nil, err
+		}
+	case "oidc":
+		a.oidc, err = newOidcProvider(rt)
+		if err != nil {
+			return nil, err
+		}
+	case "header":
+		for _, cidr := range c.TrustedProxies {
+			_, n, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, err
+			}
+			a.proxies = append(a.proxies, n)
+		}
+		// иначе любой клиент представится кем угодно
+		if len(a.proxies) == 0 {
+			return nil, errors.New("auth.trusted_proxies is required for the header auth")
+		}
+	default:
+		return nil, errors.New("unknown auth type: " + c.Type)
+	}
+	return a, nil
+}
+
+func (a *auth) trustedProxy(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	for _, n := range a.proxies {
+		if ip != nil && n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// loginPage - where the browser is sent to sign in
+func (a *auth) loginPage() string {
+	if a.oidc != nil {
+		return "/oidc/login"
+	}
+	return "/login.html"
+}
+
+// identify returns the user of the request or nil
+func (a *auth) identify(r *http.Request) *identity {
+	switch a.mode {
+	case "none":
+		return &identity{Name: "anonymous"}
+	case "header":
+		if name := r.Header.Get(a.header); name != "" && a.trustedProxy(r.RemoteAddr) {
+			return &identity{Name: name}
+		}
+		return nil
+	}
+
+	if c, err := r.Cookie(sessionCookie); err == nil {
+		if id := a.session(c.Value); id != nil {
+			return id
+		}
+	}
+	if login, password, ok := r.BasicAuth(); ok && a.backend != nil {
+		id, err := a.backend.Authenticate(login, password)
+		if err == nil {
+			return id
+		}
+	}
+	return nil
+}
+
+func (a *auth) session(token string) *identity {
+	a.Lock()
+	defer a.Unlock()
+	s, ok := a.sessions[token]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(s.expires) {
+		delete(a.sessions, token)
+		return nil
+	}
+	return s.id
+}
+
+func (a *auth) newSession(id *identity) (string, time.Time, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", time.Time{}, err
+	}
+	token := hex.EncodeToString(b)
+	expires := time.Now().Add(a.ttl)
+
+	a.Lock()
+	defer a.Unlock()
+	for t, s := range a.sessions {
+		if time.Now().After(s.expires) {
+			delete(a.sessions, t)
+		}
+	}
+	a.sessions[token] = session{id: id, expires: expires}
+	return token, expires, nil
+}
+
+func (a *auth) dropSession(token string) {
+	a.Lock()
+	defer a.Unlock()
+	delete(a.sessions, token)
+}
+
+// public - pages of the UI available without login
+func public(p string) bool {
+	return p == "/login.html" || p == "/favicon.ico" || p == "/404.html" || strings.HasPrefix(p, "/assets/")
+}
+
+// requireAuth lets only identified users through to next. Unauthenticated UI
+// requests are redirected to the login page, API requests get 401.
+func (rt *Router) requireAuth(next http.HandlerFunc, api bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := rt.auth.identify(r)
+		if id == nil && (api || !public(r.URL.Path)) {
+			remoteIP := helpers.GetIP(r.RemoteAddr, r.Header.Get("X-Real-IP"), r.Header.Get("X-Forwarded-For"))
+			log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", http.StatusUnauthorized, "\t", "Unauthorized", "\t", r.UserAgent())
+			if !api {
+				http.Redirect(w, r, rt.auth.loginPage(), http.StatusFound)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json; charset=utf-8")
+			w.Header().Set("X-Server", version.Version)
+			if rt.auth.backend != nil && r.Header.Get("X-Requested-With") == "" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="extractor"`)
+			}
+			http.Error(w, "{\"error\":\"Unauthorized\",\"status\":401}", http.StatusUnauthorized)
+			return
+		}
+		if id != nil {
+			r = r.WithContext(context.WithValue(r.Context(), identityKey, id))
+		}
+		next(w, r)
+	}
+}
+
+// requestIdentity returns the user attached to the request by requireAuth
+func requestIdentity(r *http.Request) *identity {
+	id, _ := r.Context().Value(identityKey).(*identity)
+	return id
+}
+
+// identityRequest makes a request on behalf of id for background work:
+// queued and scheduled restores pass rbac as their user
+func identityRequest(id *identity, path string) *http.Request {
+	r := &http.Request{Method: http.MethodPost, URL: &url.URL{Path: path}, Header: make(http.Header), RemoteAddr: "127.0.0.1:0"}
+	return r.WithContext(context.WithValue(context.Background(), identityKey, id))
+}
+
+// LoginHandler checks the login form and issues the session cookie
+func (rt *Router) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	remoteIP := helpers.GetIP(r.RemoteAddr, r.Header.Get("X-Real-IP"), r.Header.Get("X-Forwarded-For"))
+	w.Header().Set("X-Server", version.Version)
+
+	if r.Method != http.MethodPost || rt.auth.backend == nil {
+		http.Redirect(w, r, "/", http.StatusFound)
+		return
+	}
+
+	var login, password string
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		var creds struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+		}
+		defer r.Body.Close()
+		if err := json.NewDecoder(r.Body).Decode(&creds); err != nil {
+			http.Error(w, "{\"error\":\"bad request\"}", http.StatusBadRequest)
+			return
+		}
+		login, password = creds.Username, creds.Password
+	} else {
+		login, password = r.PostFormValue("username"), r.PostFormValue("password")
+	}
+
+	id, err := rt.auth.backend.Authenticate(login, password)
+	if err != nil {
+		log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", http.StatusUnauthorized, "\t", login, "\t", err.Error(), "\t", r.UserAgent())
+		rt.record(event{User: login, Action: "login_failed"})
+		http.Redirect(w, r, "/login.html?error=1", http.StatusFound)
+		return
+	}
+	if err := rt.startSession(w, r, id); err != nil {
+		log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", http.StatusInternalServerError, "\t", id.Name, "\t", err.Error())
+		http.Error(w, "can't start a session", http.StatusInternalServerError)
+		return
+	}
+	log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", 200, "\t", id.Name, "\t", r.UserAgent())
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func (rt *Router) startSession(w http.ResponseWriter, r *http.Request, id *identity) error {
+	token, expires, err := rt.auth.newSession(id)
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	rt.record(event{User: id.Name, Action: "login"})
+	return nil
+}
+
+func (rt *Router) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	// GET выход позволил бы разлогинить пользователя чужой ссылкой или картинкой
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if c, err := r.Cookie(sessionCookie); err == nil {
+		rt.auth.dropSession(c.Value)
+	}
+	http.SetCookie(w, &http.Cookie{Name: sessionCookie, Value: "", Path: "/", MaxAge: -1})
+	http.Redirect(w, r, rt.auth.loginPage(), http.StatusFound)
+}
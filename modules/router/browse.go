@@ -0,0 +1,297 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/base64"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	snapshotPageSize    = 100
+	snapshotPageSizeMax = 1000
+)
+
+// snapshotInfo - one snapshot of get_snapshots
+type snapshotInfo struct {
+	Snapshot     string                 `json:"snapshot"`
+	State        string                 `json:"state"`
+	Start        time.Time              `json:"start_time"`
+	End          *time.Time             `json:"end_time,omitempty"`
+	Duration     int64                  `json:"duration_in_millis"`
+	Indices      []string               `json:"indices"`
+	ShardsTotal  int                    `json:"shards_total"`
+	ShardsFailed int                    `json:"shards_failed"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// snapshotPage - answer of get_snapshots, next is passed as after to get the following page
+type snapshotPage struct {
+	Repo      string         `json:"repo"`
+	Snapshots []snapshotInfo `json:"snapshots"`
+	Total     int            `json:"total"`
+	Next      string         `json:"next,omitempty"`
+}
+
+// snapshotQuery - filters, order and page of get_snapshots
+type snapshotQuery struct {
+	Name   string
+	State  string
+	Index  string
+	From   time.Time
+	To     time.Time
+	Sort   string
+	Order  string
+	Size   int
+	After  string
+	hasEnd bool
+}
+
+var snapshotStates = []string{"SUCCESS", "PARTIAL", "FAILED", "IN_PROGRESS", "INCOMPATIBLE"}
+
+var snapshotSorts = []string{"start_time", "name", "duration", "index_count", "shard_count"}
+
+// esSnapshot - snapshot as _snapshot/{repo}/{name} returns it
+type esSnapshot struct {
+	Snapshot  string                 `json:"snapshot"`
+	State     string                 `json:"state"`
+	Indices   []string               `json:"indices"`
+	StartTime int64                  `json:"start_time_in_millis"`
+	EndTime   int64                  `json:"end_time_in_millis"`
+	Duration  int64                  `json:"duration_in_millis"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	Shards    struct {
+		Total  int `json:"total"`
+		Failed int `json:"failed"`
+	} `json:"shards"`
+}
+
+// snapshotQueryOf validates the values of get_snapshots
+func snapshotQueryOf(request *apiRequest) (*snapshotQuery, error) {
+	v := request.Values
+	q := &snapshotQuery{Name: v.SnapshotPattern, State: strings.ToUpper(v.State), Index: v.Ipattern, Sort: v.Sort, Order: v.Order, Size: v.Limit, After: v.After}
+	if q.Name == "" {
+		q.Name = "_all"
+	} else if _, err := path.Match(q.Name, ""); err != nil {
+		return nil, badRequest("bad snapshot_pattern %q", q.Name)
+	}
+	if _, err := path.Match(q.Index, ""); err != nil {
+		return nil, badRequest("bad index pattern %q", q.Index)
+	}
+	if q.State != "" && !matchAny(snapshotStates, q.State) {
+		return nil, badRequest("state must be one of %s", strings.Join(snapshotStates, ", "))
+	}
+	if q.Sort == "" {
+		q.Sort = "start_time"
+	}
+	if !matchAny(snapshotSorts, q.Sort) {
+		return nil, badRequest("sort must be one of %s", strings.Join(snapshotSorts, ", "))
+	}
+	switch q.Order {
+	case "":
+		// по умолчанию сначала свежие
+		q.Order = "desc"
+		if q.Sort == "name" {
+			q.Order = "asc"
+		}
+	case "asc", "desc":
+	default:
+		return nil, badRequest("order must be asc or desc")
+	}
+	if q.Size <= 0 {
+		q.Size = snapshotPageSize
+	}
+	if q.Size > snapshotPageSizeMax {
+		return nil, badRequest("limit must not exceed %d", snapshotPageSizeMax)
+	}
+	var err error
+	if v.From != "" {
+		if q.From, err = time.ParseInLocation("2006-01-02", v.From, time.Local); err != nil {
+			return nil, badRequest("from must look like 2006-01-02")
+		}
+	}
+	if v.To != "" {
+		if q.To, err = time.ParseInLocation("2006-01-02", v.To, time.Local); err != nil {
+			return nil, badRequest("to must look like 2006-01-02")
+		}
+		// to включает весь день
+		q.To, q.hasEnd = q.To.AddDate(0, 0, 1), true
+	}
+	return q, nil
+}
+
+func (q *snapshotQuery) match(s *esSnapshot) bool {
+	if q.State != "" && s.State != q.State {
+		return false
+	}
+	start := time.Unix(0, s.StartTime*int64(time.Millisecond))
+	if !q.From.IsZero() && start.Before(q.From) {
+		return false
+	}
+	if q.hasEnd && !start.Before(q.To) {
+		return false
+	}
+	if q.Index != "" {
+		for _, i := range s.Indices {
+			if ok, _ := path.Match(q.Index, i); ok {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// listSnapshots returns a page of snapshots of the repo filtered and sorted from the catalog
+func (rt *Router) listSnapshots(id *identity, repo string, q *snapshotQuery) (*snapshotPage, error) {
+	all, err := rt.catalog.snapshots(repo)
+	if err != nil {
+		return nil, err
+	}
+	page := &snapshotPage{Repo: repo, Snapshots: []snapshotInfo{}}
+
+	var list []esSnapshot
+	for _, s := range all {
+		if q.Name != "_all" && !matchAny(strings.Split(q.Name, ","), s.Snapshot) {
+			continue
+		}
+		// фильтр по индексу не должен выдавать скрытые от пользователя индексы,
+		// список из каталога общий, индексы копируются
+		visible := []string{}
+		for _, i := range s.Indices {
+			if rt.visibleIndex(id, repo, i) {
+				visible = append(visible, i)
+			}
+		}
+		s.Indices = visible
+		if q.match(&s) {
+			list = append(list, s)
+		}
+	}
+	sortSnapshots(list, q.Sort, q.Order == "desc")
+
+	// страница начинается после снапшота из курсора, даже если его уже удалили
+	offset := 0
+	if q.After != "" {
+		key, name, err := q.cursor()
+		if err != nil {
+			return nil, err
+		}
+		offset = sort.Search(len(list), func(i int) bool { return q.follows(&list[i], key, name) })
+	}
+	page.Total = len(list)
+	for i := offset; i < len(list) && i < offset+q.Size; i++ {
+		page.Snapshots = append(page.Snapshots, rt.snapshotInfoOf(id, repo, &list[i]))
+	}
+	if offset+q.Size < len(list) {
+		page.Next = q.cursorOf(&list[offset+q.Size-1])
+	}
+	return page, nil
+}
+
+// snapshotKey returns the value of the sort field, the name breaks ties
+func snapshotKey(s *esSnapshot, by string) int64 {
+	switch by {
+	case "name":
+		return 0
+	case "duration":
+		return s.Duration
+	case "index_count":
+		return int64(len(s.Indices))
+	case "shard_count":
+		return int64(s.Shards.Total)
+	}
+	return s.StartTime
+}
+
+func sortSnapshots(list []esSnapshot, by string, desc bool) {
+	less := func(a, b *esSnapshot) bool {
+		if ka, kb := snapshotKey(a, by), snapshotKey(b, by); ka != kb {
+			return ka < kb
+		}
+		return a.Snapshot < b.Snapshot
+	}
+	sort.Slice(list, func(a, b int) bool {
+		if desc {
+			return less(&list[b], &list[a])
+		}
+		return less(&list[a], &list[b])
+	})
+}
+
+// cursorOf returns next for the page ending with s: sort, order, sort key and name of s
+func (q *snapshotQuery) cursorOf(s *esSnapshot) string {
+	c := q.Sort + ":" + q.Order + ":" + strconv.FormatInt(snapshotKey(s, q.Sort), 10) + ":" + s.Snapshot
+	return base64.RawURLEncoding.EncodeToString([]byte(c))
+}
+
+// cursor reads the sort key and the name of after, made for the same sort and order
+func (q *snapshotQuery) cursor() (int64, string, error) {
+	bad := badRequest("bad after %q, pass next of the previous page with the same filters", q.After)
+	c, err := base64.RawURLEncoding.DecodeString(q.After)
+	if err != nil {
+		return 0, "", bad
+	}
+	parts := strings.SplitN(string(c), ":", 4)
+	if len(parts) != 4 || parts[0] != q.Sort || parts[1] != q.Order || parts[3] == "" {
+		return 0, "", bad
+	}
+	key, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, "", bad
+	}
+	return key, parts[3], nil
+}
+
+// follows reports whether s comes after the snapshot with the key and the name in the order of q
+func (q *snapshotQuery) follows(s *esSnapshot, key int64, name string) bool {
+	k := snapshotKey(s, q.Sort)
+	if k == key && s.Snapshot == name {
+		return false
+	}
+	greater := k > key || k == key && s.Snapshot > name
+	if q.Order == "desc" {
+		return !greater
+	}
+	return greater
+}
+
+// snapshotInfoOf converts the snapshot, indices the user may not see are dropped
+func (rt *Router) snapshotInfoOf(id *identity, repo string, s *esSnapshot) snapshotInfo {
+	info := snapshotInfo{
+		Snapshot:     s.Snapshot,
+		State:        s.State,
+		Start:        time.Unix(0, s.StartTime*int64(time.Millisecond)),
+		Duration:     s.Duration,
+		Indices:      []string{},
+		ShardsTotal:  s.Shards.Total,
+		ShardsFailed: s.Shards.Failed,
+		Metadata:     s.Metadata,
+	}
+	if s.EndTime > 0 {
+		end := time.Unix(0, s.EndTime*int64(time.Millisecond))
+		info.End = &end
+	}
+	for _, i := range s.Indices {
+		if rt.visibleIndex(id, repo, i) {
+			info.Indices = append(info.Indices, i)
+		}
+	}
+	sort.Strings(info.Indices)
+	return info
+}
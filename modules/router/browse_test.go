@@ -0,0 +1,97 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// setSnapshots puts the snapshot list of repo into the catalog
+func setSnapshots(rt *Router, repo string, list []esSnapshot) {
+	rt.catalog.Lock()
+	rt.catalog.lists[repo] = catalogList{Fetched: time.Now(), Snapshots: list}
+	rt.catalog.Unlock()
+}
+
+func TestListSnapshotsCursor(t *testing.T) {
+	rt := testRouter(t, "")
+	rt.catalog = newCatalog(rt)
+	// у b, c и d одинаковое время начала, порядок задаёт имя
+	list := []esSnapshot{
+		{Snapshot: "a", StartTime: 4000},
+		{Snapshot: "b", StartTime: 3000},
+		{Snapshot: "c", StartTime: 3000},
+		{Snapshot: "d", StartTime: 3000},
+		{Snapshot: "e", StartTime: 1000},
+	}
+	setSnapshots(rt, "archive", list)
+
+	for _, tt := range []struct {
+		sort, order string
+		change      []esSnapshot // список перед второй страницей
+		want        []string
+	}{
+		{sort: "start_time", order: "desc", want: []string{"a", "d", "c", "b", "e"}},
+		{sort: "start_time", order: "asc", want: []string{"e", "b", "c", "d", "a"}},
+		{sort: "name", order: "asc", want: []string{"a", "b", "c", "d", "e"}},
+		// новый снапшот в начале и удалённый последний снапшот страницы не сдвигают следующую
+		{sort: "start_time", order: "desc", change: []esSnapshot{{Snapshot: "new", StartTime: 5000}, list[0], list[1], list[2], list[4]},
+			want: []string{"a", "d", "c", "b", "e"}},
+	} {
+		setSnapshots(rt, "archive", list)
+		q := &snapshotQuery{Name: "_all", Sort: tt.sort, Order: tt.order, Size: 2}
+		var got []string
+		for pages := 0; pages < 10; pages++ {
+			page, err := rt.listSnapshots(nil, "archive", q)
+			if err != nil {
+				t.Fatalf("%s %s: %v", tt.sort, tt.order, err)
+			}
+			for _, s := range page.Snapshots {
+				got = append(got, s.Snapshot)
+			}
+			if page.Next == "" {
+				break
+			}
+			if pages == 0 && tt.change != nil {
+				setSnapshots(rt, "archive", tt.change)
+			}
+			q.After = page.Next
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s %s: pages %v, want %v", tt.sort, tt.order, got, tt.want)
+		}
+	}
+}
+
+func TestListSnapshotsBadCursor(t *testing.T) {
+	rt := testRouter(t, "")
+	rt.catalog = newCatalog(rt)
+	setSnapshots(rt, "archive", []esSnapshot{{Snapshot: "a", StartTime: 1000}, {Snapshot: "b", StartTime: 2000}})
+
+	q := &snapshotQuery{Name: "_all", Sort: "start_time", Order: "desc", Size: 1}
+	page, err := rt.listSnapshots(nil, "archive", q)
+	if err != nil || page.Next == "" {
+		t.Fatalf("first page: %+v %v", page, err)
+	}
+	// курсор другой сортировки, старый формат со смещением и мусор
+	for _, after := range []string{(&snapshotQuery{Sort: "name", Order: "desc"}).cursorOf(&esSnapshot{Snapshot: "b"}), "o1", "!!"} {
+		q.After = after
+		if _, err := rt.listSnapshots(nil, "archive", q); httpStatus(err) != http.StatusBadRequest {
+			t.Errorf("after %q: %v, want 400", after, err)
+		}
+	}
+}
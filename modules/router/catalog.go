@@ -0,0 +1,592 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	findIndexLimit    = 100
+	findIndexLimitMax = 1000
+	// сколько _status не из каталога find_index запрашивает за один вызов
+	findIndexStatusFetches = 10
+)
+
+const (
+	cacheRepositories = "repositories"
+	cacheSnapshots    = "snapshots"
+	cacheStatus       = "status"
+)
+
+// indexStat - size and shards of an index in a snapshot
+type indexStat struct {
+	Size       int64   `json:"size_in_bytes"`
+	Shards     int     `json:"shards"`
+	ShardSizes []int64 `json:"shard_sizes,omitempty"`
+}
+
+// catalogRepo - repository as _cat/repositories returns it
+type catalogRepo struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+type catalogRepos struct {
+	Fetched time.Time     `json:"fetched"`
+	List    []catalogRepo `json:"list"`
+}
+
+type catalogList struct {
+	Fetched   time.Time    `json:"fetched"`
+	Snapshots []esSnapshot `json:"snapshots"`
+}
+
+// catalogStatus - _status of a finished snapshot, it doesn't change until the snapshot is deleted
+type catalogStatus struct {
+	Status  json.RawMessage      `json:"status"`
+	Indices map[string]indexStat `json:"-"`
+}
+
+// catalogFile - what catalog.file keeps between restarts
+type catalogFile struct {
+	Repositories catalogRepos              `json:"repositories"`
+	Lists        map[string]catalogList    `json:"lists"`
+	Statuses     map[string]*catalogStatus `json:"statuses"`
+}
+
+// catalogStats - answer of get_catalog
+type catalogStats struct {
+	Repositories    int              `json:"repositories"`
+	Snapshots       int              `json:"snapshots"`
+	Statuses        int              `json:"statuses"`
+	Hits            map[string]int64 `json:"hits"`
+	Misses          map[string]int64 `json:"misses"`
+	Refresh         string           `json:"refresh"`
+	MaxAge          string           `json:"max_age"`
+	LastRefresh     *time.Time       `json:"last_refresh,omitempty"`
+	RefreshDuration int64            `json:"refresh_duration_in_millis"`
+	LastError       string           `json:"last_error,omitempty"`
+}
+
+// catalog caches repositories, their snapshots and _status of finished snapshots.
+// Lists are refreshed in the background and fetched on request when older than max_age.
+type catalog struct {
+	sync.Mutex
+	rt         *Router
+	refresh    time.Duration
+	maxAge     time.Duration
+	prefetch   bool
+	file       string
+	refreshing sync.Mutex // одно обновление за раз
+
+	repos    catalogRepos
+	lists    map[string]catalogList
+	statuses map[string]*catalogStatus // repo/snapshot
+	stats    catalogStats
+}
+
+func newCatalog(rt *Router) *catalog {
+	conf := rt.conf.Catalog
+	c := &catalog{
+		rt:       rt,
+		refresh:  5 * time.Minute,
+		maxAge:   15 * time.Minute,
+		prefetch: conf.PrefetchSizes,
+		file:     conf.File,
+		lists:    make(map[string]catalogList),
+		statuses: make(map[string]*catalogStatus),
+		stats:    catalogStats{Hits: make(map[string]int64), Misses: make(map[string]int64)},
+	}
+	if conf.Refresh != "" {
+		d, err := time.ParseDuration(conf.Refresh)
+		if err != nil {
+			log.Println("Catalog: wrong catalog.refresh:", err)
+		} else {
+			c.refresh = d
+		}
+	}
+	if conf.MaxAge != "" {
+		d, err := time.ParseDuration(conf.MaxAge)
+		if err != nil || d <= 0 {
+			log.Println("Catalog: wrong catalog.max_age:", conf.MaxAge)
+		} else {
+			c.maxAge = d
+		}
+	}
+	c.load()
+	return c
+}
+
+// load reads catalog.file, a missing or broken file leaves the catalog empty
+func (c *catalog) load() {
+	if c.file == "" {
+		return
+	}
+	b, err := ioutil.ReadFile(c.file)
+	if os.IsNotExist(err) {
+		return
+	}
+	var f catalogFile
+	if err == nil {
+		err = json.Unmarshal(b, &f)
+	}
+	if err != nil {
+		log.Println("Catalog: can't load", c.file, ":", err)
+		return
+	}
+	c.repos = f.Repositories
+	if f.Lists != nil {
+		c.lists = f.Lists
+	}
+	for key, st := range f.Statuses {
+		if st.Indices, _, err = parseStatus(st.Status); err == nil {
+			c.statuses[key] = st
+		}
+	}
+	log.Println("Catalog: loaded", len(c.lists), "repositories and", len(c.statuses), "snapshot statuses from", c.file)
+}
+
+// save writes the catalog to catalog.file, the old file is replaced at once
+func (c *catalog) save() {
+	if c.file == "" {
+		return
+	}
+	c.Lock()
+	b, err := json.Marshal(catalogFile{Repositories: c.repos, Lists: c.lists, Statuses: c.statuses})
+	c.Unlock()
+	if err == nil {
+		err = ioutil.WriteFile(c.file+".tmp", b, 0640)
+	}
+	if err == nil {
+		err = os.Rename(c.file+".tmp", c.file)
+	}
+	if err != nil {
+		log.Println("Catalog: can't save", c.file, ":", err)
+	}
+}
+
+func (c *catalog) run() {
+	if c.refresh <= 0 {
+		log.Println("Catalog: background refresh is off, lists are fetched when older than", c.maxAge)
+		return
+	}
+	log.Println("Catalog: refresh every", c.refresh, "prefetch sizes:", c.prefetch)
+	for {
+		c.refreshAll()
+		time.Sleep(c.refresh)
+	}
+}
+
+// count records a hit or a miss of the cache
+func (c *catalog) count(kind string, hit bool) {
+	if hit {
+		c.stats.Hits[kind]++
+	} else {
+		c.stats.Misses[kind]++
+	}
+}
+
+// repositories returns the registered repositories
+func (c *catalog) repositories() ([]catalogRepo, error) {
+	c.Lock()
+	fresh := !c.repos.Fetched.IsZero() && time.Since(c.repos.Fetched) < c.maxAge
+	c.count(cacheRepositories, fresh)
+	list := c.repos.List
+	c.Unlock()
+	if fresh {
+		return list, nil
+	}
+	return c.fetchRepositories()
+}
+
+func (c *catalog) fetchRepositories() ([]catalogRepo, error) {
+	response, err := c.rt.doGet(c.rt.conf.Elastic.Host + "_cat/repositories?format=json&h=id,type")
+	if err != nil {
+		return nil, err
+	}
+	list := []catalogRepo{}
+	if err := json.Unmarshal(response, &list); err != nil {
+		return nil, upstreamError(http.StatusBadGateway, "bad repository list: "+err.Error())
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].ID < list[b].ID })
+
+	c.Lock()
+	c.repos = catalogRepos{Fetched: time.Now(), List: list}
+	c.Unlock()
+	return list, nil
+}
+
+// snapshots returns every snapshot of the repository with its indices.
+// The list is shared, callers must not change it.
+func (c *catalog) snapshots(repo string) ([]esSnapshot, error) {
+	c.Lock()
+	l, ok := c.lists[repo]
+	fresh := ok && time.Since(l.Fetched) < c.maxAge
+	c.count(cacheSnapshots, fresh)
+	c.Unlock()
+	if fresh {
+		return l.Snapshots, nil
+	}
+	return c.fetchSnapshots(repo)
+}
+
+func (c *catalog) fetchSnapshots(repo string) ([]esSnapshot, error) {
+	response, err := c.rt.doGet(c.rt.conf.Elastic.Host + "_snapshot/" + repo + "/_all?ignore_unavailable=true")
+	if err != nil {
+		return nil, err
+	}
+	var res struct {
+		Snapshots []esSnapshot `json:"snapshots"`
+	}
+	if err := json.Unmarshal(response, &res); err != nil {
+		return nil, upstreamError(http.StatusBadGateway, "bad snapshot list: "+err.Error())
+	}
+
+	c.Lock()
+	c.lists[repo] = catalogList{Fetched: time.Now(), Snapshots: res.Snapshots}
+	c.Unlock()
+	return res.Snapshots, nil
+}
+
+// status returns _status of the snapshot and sizes of its indices, finished snapshots are kept
+func (c *catalog) status(repo, snapshot string) ([]byte, map[string]indexStat, error) {
+	key := repo + "/" + snapshot
+	c.Lock()
+	st, ok := c.statuses[key]
+	c.count(cacheStatus, ok)
+	c.Unlock()
+	if ok {
+		return st.Status, st.Indices, nil
+	}
+	return c.fetchStatus(repo, snapshot)
+}
+
+// cached reports whether _status of the snapshot is in the catalog
+func (c *catalog) cached(repo, snapshot string) bool {
+	c.Lock()
+	defer c.Unlock()
+	_, ok := c.statuses[repo+"/"+snapshot]
+	return ok
+}
+
+func (c *catalog) fetchStatus(repo, snapshot string) ([]byte, map[string]indexStat, error) {
+	response, err := c.rt.doGet(c.rt.conf.Elastic.Host + "_snapshot/" + repo + "/" + snapshot + "/_status")
+	if err != nil {
+		return nil, nil, err
+	}
+	indices, state, err := parseStatus(response)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state == "" {
+		return nil, nil, notFound("snapshot %s not found in %s", snapshot, repo)
+	}
+	if state != "IN_PROGRESS" && state != "STARTED" {
+		c.Lock()
+		c.statuses[repo+"/"+snapshot] = &catalogStatus{Status: response, Indices: indices}
+		c.Unlock()
+	}
+	return response, indices, nil
+}
+
+// parseStatus reads sizes of indices and the state of the first snapshot of _status
+func parseStatus(response []byte) (map[string]indexStat, string, error) {
+	var status snapStatus
+	if err := json.Unmarshal(response, &status); err != nil {
+		return nil, "", upstreamError(http.StatusBadGateway, "bad snapshot status: "+err.Error())
+	}
+	if len(status.Snapshots) == 0 {
+		return nil, "", nil
+	}
+	s := status.Snapshots[0]
+	indices := make(map[string]indexStat)
+	for name, i := range s.Indices {
+		stat := indexStat{Size: int64(i.Stats.Total.Size), Shards: i.ShardsStats.Total}
+		var ids []string
+		for id := range i.Shards {
+			ids = append(ids, id)
+		}
+		// номера шардов - строки, "10" должен идти после "9"
+		sort.Slice(ids, func(a, b int) bool { return len(ids[a]) < len(ids[b]) || len(ids[a]) == len(ids[b]) && ids[a] < ids[b] })
+		for _, id := range ids {
+			stat.ShardSizes = append(stat.ShardSizes, int64(i.Shards[id].Stats.Total.Size))
+		}
+		indices[name] = stat
+	}
+	return indices, s.State, nil
+}
+
+// forget drops the snapshot list of the repository after a snapshot was created or deleted,
+// without snapshot statuses of the whole repository are dropped as well
+func (c *catalog) forget(repo, snapshot string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.lists, repo)
+	if snapshot != "" {
+		delete(c.statuses, repo+"/"+snapshot)
+		return
+	}
+	c.repos.Fetched = time.Time{}
+	for key := range c.statuses {
+		if path.Dir(key) == repo {
+			delete(c.statuses, key)
+		}
+	}
+}
+
+// refreshAll fetches repositories and their snapshots, cached statuses of deleted
+// snapshots are dropped and with prefetch_sizes statuses of new ones are fetched
+func (c *catalog) refreshAll() error {
+	c.refreshing.Lock()
+	defer c.refreshing.Unlock()
+	start := time.Now()
+
+	repos, err := c.fetchRepositories()
+	if err == nil {
+		known := make(map[string]bool)
+		for _, r := range repos {
+			known[r.ID] = true
+		}
+		c.Lock()
+		for repo := range c.lists {
+			if !known[repo] {
+				delete(c.lists, repo)
+			}
+		}
+		for key := range c.statuses {
+			if !known[path.Dir(key)] {
+				delete(c.statuses, key)
+			}
+		}
+		c.Unlock()
+		for _, r := range repos {
+			if rerr := c.refreshRepo(r.ID); rerr != nil {
+				err = rerr
+				log.Println("Catalog:", r.ID, ":", rerr)
+			}
+		}
+	}
+
+	now := time.Now()
+	c.Lock()
+	c.stats.LastRefresh, c.stats.RefreshDuration, c.stats.LastError = &now, int64(now.Sub(start)/time.Millisecond), ""
+	if err != nil {
+		c.stats.LastError = err.Error()
+	}
+	c.Unlock()
+	c.save()
+	return err
+}
+
+// refreshOne refreshes one repository on request
+func (c *catalog) refreshOne(repo string) error {
+	c.refreshing.Lock()
+	defer c.refreshing.Unlock()
+	err := c.refreshRepo(repo)
+	if err == nil {
+		c.save()
+	}
+	return err
+}
+
+// refreshRepo fetches the snapshots of one repository, only the list has to succeed
+func (c *catalog) refreshRepo(repo string) error {
+	list, err := c.fetchSnapshots(repo)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool)
+	for _, s := range list {
+		known[repo+"/"+s.Snapshot] = true
+	}
+	var missing []string
+	c.Lock()
+	for key := range c.statuses {
+		if path.Dir(key) == repo && !known[key] {
+			delete(c.statuses, key)
+		}
+	}
+	for _, s := range list {
+		if _, ok := c.statuses[repo+"/"+s.Snapshot]; !ok && s.State != "IN_PROGRESS" {
+			missing = append(missing, s.Snapshot)
+		}
+	}
+	c.Unlock()
+
+	if !c.prefetch {
+		return nil
+	}
+	// _status больших снапшотов медленный, запрашиваем по одному;
+	// ошибка одного снапшота не мешает остальным, его запросят при следующем обновлении
+	for _, snapshot := range missing {
+		if _, _, err := c.fetchStatus(repo, snapshot); err != nil {
+			log.Println("Catalog:", repo+"/"+snapshot, ":", err)
+		}
+	}
+	return nil
+}
+
+// info returns sizes and counters of the catalog, sizes count only repositories
+// for which visible is true
+func (c *catalog) info(visible func(repo string) bool) catalogStats {
+	c.Lock()
+	defer c.Unlock()
+	res := c.stats
+	res.Hits, res.Misses = make(map[string]int64), make(map[string]int64)
+	for k, v := range c.stats.Hits {
+		res.Hits[k] = v
+	}
+	for k, v := range c.stats.Misses {
+		res.Misses[k] = v
+	}
+	res.Repositories, res.Snapshots, res.Statuses = 0, 0, 0
+	for _, r := range c.repos.List {
+		if visible(r.ID) {
+			res.Repositories++
+		}
+	}
+	for repo, l := range c.lists {
+		if visible(repo) {
+			res.Snapshots += len(l.Snapshots)
+		}
+	}
+	for key := range c.statuses {
+		if visible(strings.SplitN(key, "/", 2)[0]) {
+			res.Statuses++
+		}
+	}
+	res.Refresh, res.MaxAge = c.refresh.String(), c.maxAge.String()
+	return res
+}
+
+// foundIndex - an index found in a snapshot by find_index
+type foundIndex struct {
+	Repo     string    `json:"repo"`
+	Snapshot string    `json:"snapshot"`
+	Index    string    `json:"index"`
+	State    string    `json:"state"`
+	Start    time.Time `json:"start_time"`
+	Size     *int64    `json:"size_in_bytes"` // null, если размер неизвестен
+	Shards   *int      `json:"shards"`
+}
+
+// indexSearch - answer of find_index, repositories and snapshot statuses that failed are reported in errors
+type indexSearch struct {
+	Index     string            `json:"index"`
+	Found     []foundIndex      `json:"found"`
+	Total     int               `json:"total"`
+	Truncated bool              `json:"truncated,omitempty"`
+	Errors    map[string]string `json:"errors,omitempty"`
+}
+
+// findIndex searches snapshots of the visible repositories for indices matching the pattern, newest first
+func (rt *Router) findIndex(id *identity, request *apiRequest) (*indexSearch, error) {
+	pattern := request.Values.Ipattern
+	if pattern == "" {
+		return nil, badRequest("index is required")
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, badRequest("bad index pattern %q", pattern)
+	}
+	limit := request.Values.Limit
+	if limit <= 0 {
+		limit = findIndexLimit
+	}
+	if limit > findIndexLimitMax {
+		return nil, badRequest("limit must not exceed %d", findIndexLimitMax)
+	}
+
+	repos, err := rt.catalog.repositories()
+	if err != nil {
+		return nil, err
+	}
+	res := &indexSearch{Index: pattern, Found: []foundIndex{}}
+	for _, r := range repos {
+		repo := r.ID
+		if !matchAny(request.Values.Repos, repo) || !rt.visibleRepository(id, repo) {
+			continue
+		}
+		list, err := rt.catalog.snapshots(repo)
+		if err != nil {
+			if res.Errors == nil {
+				res.Errors = make(map[string]string)
+			}
+			res.Errors[repo] = err.Error()
+			continue
+		}
+		for _, s := range list {
+			for _, i := range s.Indices {
+				if ok, _ := path.Match(pattern, i); ok && rt.visibleIndex(id, repo, i) {
+					res.Found = append(res.Found, foundIndex{Repo: repo, Snapshot: s.Snapshot, Index: i, State: s.State,
+						Start: time.Unix(0, s.StartTime*int64(time.Millisecond))})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(res.Found, func(a, b int) bool {
+		if !res.Found[a].Start.Equal(res.Found[b].Start) {
+			return res.Found[a].Start.After(res.Found[b].Start)
+		}
+		return res.Found[a].Index < res.Found[b].Index
+	})
+	res.Total = len(res.Found)
+	if len(res.Found) > limit {
+		res.Found, res.Truncated = res.Found[:limit], true
+	}
+
+	// размеры нужны только показанным снапшотам, запросы _status мимо каталога ограничены
+	fetched := make(map[string]map[string]indexStat)
+	fetches := 0
+	for i := range res.Found {
+		f := &res.Found[i]
+		key := f.Repo + "/" + f.Snapshot
+		stats, ok := fetched[key]
+		if !ok {
+			if res.Errors[key] != "" {
+				continue
+			}
+			if !rt.catalog.cached(f.Repo, f.Snapshot) {
+				if fetches >= findIndexStatusFetches {
+					continue
+				}
+				fetches++
+			}
+			_, stats, err = rt.catalog.status(f.Repo, f.Snapshot)
+			if err != nil {
+				if res.Errors == nil {
+					res.Errors = make(map[string]string)
+				}
+				res.Errors[key] = err.Error()
+				continue
+			}
+			fetched[key] = stats
+		}
+		if st, ok := stats[f.Index]; ok {
+			size, shards := st.Size, st.Shards
+			f.Size, f.Shards = &size, &shards
+		}
+	}
+	return res, nil
+}
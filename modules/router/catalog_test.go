@@ -0,0 +1,98 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// snapshotsES - fake Elasticsearch with one repository of n snapshots containing logs-a,
+// _status of snap-22 fails
+func snapshotsES(t *testing.T, n int) *fakeES {
+	return newFakeES(t, func(method, path string) (int, string) {
+		switch {
+		case strings.HasPrefix(path, "/_cat/repositories"):
+			return http.StatusOK, `[{"id":"archive","type":"fs"}]`
+		case path == "/_snapshot/archive/_all":
+			var list []string
+			for i := 0; i < n; i++ {
+				list = append(list, fmt.Sprintf(`{"snapshot":"snap-%02d","state":"SUCCESS","indices":["logs-a"],"start_time_in_millis":%d}`, i, (i+1)*1000))
+			}
+			return http.StatusOK, `{"snapshots":[` + strings.Join(list, ",") + `]}`
+		case path == "/_snapshot/archive/snap-22/_status":
+			return http.StatusInternalServerError, `{"error":{"reason":"status failed"}}`
+		case strings.HasSuffix(path, "/_status"):
+			return http.StatusOK, `{"snapshots":[{"state":"SUCCESS","indices":{"logs-a":{"shards_stats":{"total":1},"stats":{"total":{"size_in_bytes":100}}}}}]}`
+		}
+		return http.StatusNotFound, `{}`
+	})
+}
+
+func TestFindIndexStatusFetches(t *testing.T) {
+	es := snapshotsES(t, 25)
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n")
+	rt.catalog = newCatalog(rt)
+	request := &apiRequest{Action: "find_index"}
+	request.Values.Ipattern = "logs-*"
+
+	statuses := func() int {
+		n := 0
+		for _, c := range es.requests() {
+			if strings.HasSuffix(c.Path, "/_status") {
+				n++
+			}
+		}
+		return n
+	}
+
+	res, err := rt.findIndex(nil, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Found) != 25 || statuses() != findIndexStatusFetches {
+		t.Fatalf("found %d, _status calls %d, want 25 and %d", len(res.Found), statuses(), findIndexStatusFetches)
+	}
+	known := 0
+	for _, f := range res.Found {
+		if f.Size != nil {
+			known++
+			if *f.Size != 100 || *f.Shards != 1 {
+				t.Errorf("%s: size %d, shards %d", f.Snapshot, *f.Size, *f.Shards)
+			}
+		}
+	}
+	// один из запрошенных _status не удался
+	if known != findIndexStatusFetches-1 || res.Errors["archive/snap-22"] == "" {
+		t.Fatalf("%d sizes known, errors %v", known, res.Errors)
+	}
+
+	// следующий вызов берёт известные размеры из каталога и запрашивает следующие,
+	// неудачный _status тоже запрашивается снова
+	res, err = rt.findIndex(nil, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	known = 0
+	for _, f := range res.Found {
+		if f.Size != nil {
+			known++
+		}
+	}
+	if known != 2*findIndexStatusFetches-2 || statuses() != 2*findIndexStatusFetches {
+		t.Fatalf("second call: %d sizes known, %d _status calls", known, statuses())
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	checkVerify  = "verify"
+	checkAnalyze = "analyze"
+
+	stepPassed  = "passed"
+	stepFailed  = "failed"
+	stepSkipped = "skipped"
+)
+
+// сколько последних проверок репозитория отдаёт list_repository_checks
+const checkHistory = 20
+
+// repoCheck - background integrity check of a repository: verify runs _verify,
+// analyze also runs _cleanup and _analyze
+type repoCheck struct {
+	ID       string      `json:"id"`
+	Repo     string      `json:"repo"`
+	Kind     string      `json:"kind"`
+	User     string      `json:"user,omitempty"`
+	State    string      `json:"state"` // running, done или failed
+	Started  time.Time   `json:"started"`
+	Finished *time.Time  `json:"finished,omitempty"`
+	Steps    []checkStep `json:"steps"`
+}
+
+// clone copies the check with its steps: the registry changes steps in place,
+// copies leave the lock and go to the store
+func (c *repoCheck) clone() repoCheck {
+	res := *c
+	res.Steps = append([]checkStep{}, c.Steps...)
+	return res
+}
+
+// checkStep - one call of the check and what Elasticsearch answered
+type checkStep struct {
+	Name   string          `json:"name"`
+	State  string          `json:"state,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+type checkRegistry struct {
+	sync.RWMutex
+	rt   *Router
+	list map[string]*repoCheck
+}
+
+func newCheckRegistry(rt *Router) *checkRegistry {
+	cr := &checkRegistry{rt: rt, list: make(map[string]*repoCheck)}
+	saved, err := rt.store.Checks()
+	if err != nil {
+		log.Println("Store: can't load repository checks:", err)
+	}
+	for i := range saved {
+		cr.list[saved[i].ID] = &saved[i]
+	}
+	return cr
+}
+
+// recover fails checks that were running before the restart, server only
+func (cr *checkRegistry) recover() {
+	cr.Lock()
+	var failed []repoCheck
+	now := time.Now()
+	for _, c := range cr.list {
+		if c.State != jobRunning {
+			continue
+		}
+		c.State, c.Finished = jobFailed, &now
+		for s := range c.Steps {
+			if c.Steps[s].State == "" {
+				c.Steps[s].State, c.Steps[s].Error = stepFailed, "extractor was restarted during the check"
+			}
+		}
+		failed = append(failed, c.clone())
+	}
+	cr.Unlock()
+	for _, c := range failed {
+		cr.save(c)
+	}
+}
+
+func (cr *checkRegistry) save(c repoCheck) {
+	if err := cr.rt.store.SaveCheck(c); err != nil {
+		log.Println("Store: can't save repository check", c.ID, ":", err)
+	}
+}
+
+// start runs a check of the repository in the background, one check of a repository at a time
+func (cr *checkRegistry) start(repo, kind, user string) (*repoCheck, error) {
+	readonly := false
+	info, err := cr.rt.getRepository(repo)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := info.Settings["readonly"]; ok && strings.EqualFold(strings.TrimSpace(jsonString(v)), "true") {
+		readonly = true
+	}
+
+	c := &repoCheck{ID: newJobID(), Repo: repo, Kind: kind, User: user, State: jobRunning, Started: time.Now()}
+	c.Steps = []checkStep{{Name: "verify"}}
+	if kind == checkAnalyze {
+		c.Steps = append(c.Steps, checkStep{Name: "cleanup"}, checkStep{Name: "analyze"})
+	}
+
+	cr.Lock()
+	for _, other := range cr.list {
+		if other.Repo == repo && other.State == jobRunning {
+			cr.Unlock()
+			return nil, &apiError{Status: http.StatusConflict, Message: "repository " + repo + " is being checked by " + other.ID}
+		}
+	}
+	cr.list[c.ID] = c
+	saved := c.clone()
+	cr.Unlock()
+	cr.save(saved)
+
+	go cr.run(c.ID, readonly)
+	return &saved, nil
+}
+
+func (cr *checkRegistry) run(id string, readonly bool) {
+	c, _ := cr.get(id)
+	failed := false
+	for i, step := range c.Steps {
+		var (
+			result []byte
+			err    error
+			skip   string
+		)
+		switch {
+		case failed:
+			skip = "an earlier step failed"
+		case readonly && step.Name != "verify":
+			// _cleanup и _analyze пишут в репозиторий
+			skip = "the repository is read-only"
+		default:
+			result, skip, err = cr.step(c.Repo, step.Name)
+		}
+
+		switch {
+		case err != nil:
+			step.State, step.Error = stepFailed, err.Error()
+			failed = true
+		case skip != "":
+			step.State, step.Error = stepSkipped, skip
+		default:
+			step.State, step.Result = stepPassed, json.RawMessage(result)
+		}
+
+		cr.Lock()
+		cr.list[id].Steps[i] = step
+		saved := cr.list[id].clone()
+		cr.Unlock()
+		cr.save(saved)
+	}
+
+	now := time.Now()
+	cr.Lock()
+	c = cr.list[id].clone()
+	c.State, c.Finished = jobDone, &now
+	if failed {
+		c.State = jobFailed
+	}
+	cr.list[id].State, cr.list[id].Finished = c.State, c.Finished
+	cr.Unlock()
+	cr.save(c)
+	cr.rt.record(event{User: c.User, Action: c.Kind + "_repository", Repo: c.Repo, Details: c.ID + " " + c.State})
+	log.Println("Check:", c.Kind, c.Repo, c.State)
+}
+
+// step calls Elasticsearch, a non-empty reason skips the step
+func (cr *checkRegistry) step(repo, name string) ([]byte, string, error) {
+	host := cr.rt.conf.Elastic.Host + "_snapshot/" + repo
+	switch name {
+	case "verify":
+		response, err := cr.rt.doPost(host+"/_verify", nil)
+		return response, "", err
+	case "cleanup":
+		response, err := cr.rt.doPost(host+"/_cleanup", nil)
+		return response, "", err
+	}
+
+	// лёгкий анализ, уложиться в таймаут запроса
+	timeout := cr.rt.conf.App.TimeOut - 5
+	if timeout < 10 {
+		timeout = 10
+	}
+	response, err := cr.rt.doPost(host+"/_analyze?blob_count=10&max_blob_size=1mb&timeout="+strconv.Itoa(timeout)+"s", nil)
+	// до 7.12 _analyze нет: запрос принимается за снапшот с именем _analyze
+	if err != nil && (httpStatus(err) == http.StatusBadRequest || httpStatus(err) == http.StatusNotFound) &&
+		(strings.Contains(err.Error(), "_analyze") || strings.Contains(err.Error(), "no handler")) {
+		return nil, "repository analysis is not supported by this Elasticsearch", nil
+	}
+	return response, "", err
+}
+
+func (cr *checkRegistry) get(id string) (repoCheck, error) {
+	if id == "" {
+		return repoCheck{}, badRequest("check_id is required")
+	}
+	cr.RLock()
+	defer cr.RUnlock()
+	c, ok := cr.list[id]
+	if !ok {
+		return repoCheck{}, notFound("check %s not found", id)
+	}
+	return c.clone(), nil
+}
+
+// forRepo returns the last checks of the repository, newest first
+func (cr *checkRegistry) forRepo(repo string) []repoCheck {
+	cr.RLock()
+	res := []repoCheck{}
+	for _, c := range cr.list {
+		if c.Repo == repo {
+			res = append(res, c.clone())
+		}
+	}
+	cr.RUnlock()
+	sort.Slice(res, func(a, b int) bool { return res[a].Started.After(res[b].Started) })
+	if len(res) > checkHistory {
+		res = res[:checkHistory]
+	}
+	return res
+}
+
+// jsonString prints a decoded JSON value, ES returns settings as strings but users send booleans
+func jsonString(v interface{}) string {
+	switch s := v.(type) {
+	case string:
+		return s
+	case bool:
+		return strconv.FormatBool(s)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
@@ -0,0 +1,71 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckCopies(t *testing.T) {
+	es := newFakeES(t, func(method, path string) (int, string) {
+		if method == http.MethodGet {
+			return http.StatusOK, `{"archive":{"type":"fs","settings":{"location":"/mnt/archive"}}}`
+		}
+		if strings.HasSuffix(path, "/_analyze") {
+			return http.StatusOK, `{"blob_count":10}`
+		}
+		return http.StatusOK, `{"nodes":{}}`
+	})
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n")
+	rt.checks = newCheckRegistry(rt)
+
+	c, err := rt.checks.start("archive", checkAnalyze, "admin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ответ start читается, пока проверка идёт
+	for i := 0; i < 100; i++ {
+		for _, s := range c.Steps {
+			_ = s.State
+		}
+	}
+
+	var done repoCheck
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if done, err = rt.checks.get(c.ID); err != nil || done.State != jobRunning {
+			break
+		}
+	}
+	if done.State != jobDone || len(done.Steps) != 3 {
+		t.Fatalf("check %+v, want done with 3 steps", done)
+	}
+	for i, s := range c.Steps {
+		if s.State != "" {
+			t.Errorf("step %d of the answer of start changed to %s", i, s.State)
+		}
+	}
+
+	// копия из get не меняет проверку в реестре
+	done.Steps[0].State = stepFailed
+	if again, _ := rt.checks.get(c.ID); again.Steps[0].State != stepPassed {
+		t.Errorf("get shares steps with the registry: %+v", again.Steps[0])
+	}
+	saved, _ := rt.store.Checks()
+	if len(saved) != 1 || saved[0].Steps[0].State != stepPassed {
+		t.Errorf("store shares steps with the registry: %+v", saved)
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSpec - parsed 5-field cron expression: minute hour day-of-month month day-of-week
+type cronSpec struct {
+	minute, hour, dom, month, dow uint64
+	// как в cron: если заданы и число, и день недели, подходит любое из них
+	domAny, dowAny bool
+}
+
+var cronMacros = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+	"@yearly":  "0 0 1 1 *",
+}
+
+// названия допустимы только в своём поле
+var (
+	cronMonths = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	cronDays = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// parseCron parses expressions like "0 7 * * mon" or "*/15 8-18 * * 1-5"
+func parseCron(expr string) (*cronSpec, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := cronMacros[expr]; ok {
+		expr = m
+	}
+	f := strings.Fields(expr)
+	if len(f) != 5 {
+		return nil, errors.New("cron needs 5 fields: minute hour day month weekday")
+	}
+	// как в cron, "*/2" тоже считается звёздочкой
+	c := &cronSpec{domAny: strings.HasPrefix(f[2], "*"), dowAny: strings.HasPrefix(f[4], "*")}
+	var err error
+	if c.minute, err = cronField(f[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.hour, err = cronField(f[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if c.dom, err = cronField(f[2], 1, 31, nil); err != nil {
+		return nil, err
+	}
+	if c.month, err = cronField(f[3], 1, 12, cronMonths); err != nil {
+		return nil, err
+	}
+	if c.dow, err = cronField(f[4], 0, 7, cronDays); err != nil {
+		return nil, err
+	}
+	// 7 - тоже воскресенье
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+	return c, nil
+}
+
+// cronValue parses a number or a name of the field and checks it against min and max
+func cronValue(s string, min, max int, names map[string]int) (int, error) {
+	v, ok := names[strings.ToLower(s)]
+	if !ok {
+		var err error
+		if v, err = strconv.Atoi(s); err != nil {
+			return 0, errors.New("bad cron value " + s)
+		}
+	}
+	if v < min || v > max {
+		return 0, errors.New("cron value " + s + " is out of " + strconv.Itoa(min) + "-" + strconv.Itoa(max))
+	}
+	return v, nil
+}
+
+// cronField parses lists of values, ranges and steps into a bit set
+func cronField(field string, min, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.New("bad cron step in " + part)
+			}
+			part = part[:i]
+		}
+		lo, hi := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = cronValue(bounds[0], min, max, names); err != nil {
+				return 0, err
+			}
+			if hi, err = cronValue(bounds[1], min, max, names); err != nil {
+				return 0, err
+			}
+			if hi < lo {
+				return 0, errors.New("bad cron range " + part)
+			}
+		default:
+			v, err := cronValue(part, min, max, names)
+			if err != nil {
+				return 0, err
+			}
+			lo = v
+			// "5/10" - с 5 до конца с шагом 10
+			if step == 1 {
+				hi = v
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (c *cronSpec) dayMatches(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// next returns the first matching minute after t, zero time if there is none within 5 years
+func (c *cronSpec) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,91 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	for expr, ok := range map[string]bool{
+		"0 7 * * mon":           true,
+		"0 7 * * SUN":           true,
+		"0 0 1 jan,jul *":       true,
+		"*/15 8-18 * * mon-fri": true,
+		"5/20 * * * *":          true,
+		"0 0 * * 7":             true,
+		"@daily":                true,
+		" @hourly ":             true,
+
+		// названия только в своём поле
+		"0 0 * mon *": false,
+		"0 0 * * jan": false,
+		"jan 0 * * *": false,
+		"0 0 sun * *": false,
+		// и в пределах поля
+		"60 * * * *":  false,
+		"* 24 * * *":  false,
+		"* * 0 * *":   false,
+		"* * 32 * *":  false,
+		"* * * 0 *":   false,
+		"* * * 13 *":  false,
+		"* * * * 8":   false,
+		"* * * * 1-0": false,
+		"*/0 * * * *": false,
+		"a * * * *":   false,
+		"* * * *":     false,
+		"@reboot":     false,
+	} {
+		_, err := parseCron(expr)
+		if (err == nil) != ok {
+			t.Errorf("parseCron(%q): %v, want ok %v", expr, err, ok)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	// понедельник
+	from := time.Date(2020, 11, 2, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"@hourly", time.Date(2020, 11, 2, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2020, 11, 3, 0, 0, 0, 0, time.UTC)},
+		{"*/15 8-18 * * mon-fri", time.Date(2020, 11, 2, 10, 45, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2020, 11, 2, 10, 45, 0, 0, time.UTC)},
+		{"0 7 * * sun", time.Date(2020, 11, 8, 7, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2020, 11, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan *", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * feb-mar mon", time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
+		// заданы и число, и день недели - подходит любое
+		{"0 0 13 * fri", time.Date(2020, 11, 6, 0, 0, 0, 0, time.UTC)},
+		// */n - звёздочка, нужны оба
+		{"0 0 */10 * mon", time.Date(2020, 12, 21, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * */2", time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)},
+		// не наступает никогда
+		{"0 0 30 feb *", time.Time{}},
+	}
+	for _, tt := range tests {
+		c, err := parseCron(tt.expr)
+		if err != nil {
+			t.Errorf("parseCron(%q): %v", tt.expr, err)
+			continue
+		}
+		if got := c.next(from); !got.Equal(tt.want) {
+			t.Errorf("next of %q = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
@@ -16,7 +16,6 @@ package router
 import (
 	"crypto/tls"
 	"encoding/json"
-	"errors"
 	"io/ioutil"
 	"net/http"
 
@@ -38,6 +37,18 @@ type esError struct {
 	Status int `json:"status"`
 }
 
+// upstreamError keeps client errors of Elasticsearch, everything else is a bad gateway
+func upstreamError(status int, msg string) error {
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+	switch status {
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusConflict:
+		return &apiError{Status: status, Message: msg}
+	}
+	return &apiError{Status: http.StatusBadGateway, Message: msg}
+}
+
 func (rt *Router) netClientPrepare() {
 	var netTransport = &http.Transport{
 		Dial: (&net.Dialer{
@@ -70,15 +81,19 @@ func (rt *Router) doDel(url string) ([]byte, error) {
 		return nil, err
 	}
 
-	if actionResult.StatusCode != 200 {
-		return nil, errors.New("Wrong response: " + actionResult.Status)
-	}
-
 	body, err := ioutil.ReadAll(actionResult.Body)
 	if err != nil {
 		return nil, err
 	}
 
+	if actionResult.StatusCode != 200 {
+		var e esError
+		if json.Unmarshal(body, &e) == nil && e.Error.Reason != "" {
+			return nil, upstreamError(actionResult.StatusCode, e.Error.Reason)
+		}
+		return nil, upstreamError(actionResult.StatusCode, "Wrong response: "+actionResult.Status)
+	}
+
 	return body, nil
 }
 
@@ -100,8 +115,43 @@ func (rt *Router) doGet(url string) ([]byte, error) {
 		return nil, err
 	}
 
+	body, err := ioutil.ReadAll(actionResult.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	if actionResult.StatusCode != 200 {
-		return nil, errors.New("Wrong response: " + actionResult.Status)
+		var e esError
+		if json.Unmarshal(body, &e) == nil && e.Error.Reason != "" {
+			return nil, upstreamError(actionResult.StatusCode, e.Error.Reason)
+		}
+		return nil, upstreamError(actionResult.StatusCode, "Wrong response: "+actionResult.Status)
+	}
+
+	return body, nil
+}
+
+// doPost sends request as the body, nil sends none: ES refuses bodies of _verify, _close and the like
+func (rt *Router) doPost(url string, request map[string]interface{}) ([]byte, error) {
+	var toBackend []byte
+	if request != nil {
+		toBackend, _ = json.Marshal(request)
+	}
+
+	actionRequest, _ := http.NewRequest("POST", url, bytes.NewReader(toBackend))
+	if rt.conf.Elastic.Username != "" {
+		actionRequest.SetBasicAuth(rt.conf.Elastic.Username, rt.conf.Elastic.Password)
+	}
+
+	actionRequest.Header.Set("Content-Type", "application/json")
+	actionRequest.Header.Set("Connection", "keep-alive")
+
+	actionResult, err := rt.nc.Do(actionRequest)
+	if actionResult != nil {
+		defer actionResult.Body.Close()
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	body, err := ioutil.ReadAll(actionResult.Body)
@@ -109,13 +159,19 @@ func (rt *Router) doGet(url string) ([]byte, error) {
 		return nil, err
 	}
 
+	if actionResult.StatusCode != 200 && actionResult.StatusCode != 201 {
+		var e esError
+		_ = json.Unmarshal(body, &e)
+		return nil, upstreamError(actionResult.StatusCode, e.Error.Reason)
+	}
+
 	return body, nil
 }
 
-func (rt *Router) doPost(url string, request map[string]interface{}) ([]byte, error) {
+func (rt *Router) doPut(url string, request map[string]interface{}) ([]byte, error) {
 	toBackend, _ := json.Marshal(request)
 
-	actionRequest, _ := http.NewRequest("POST", url, bytes.NewReader(toBackend))
+	actionRequest, _ := http.NewRequest("PUT", url, bytes.NewReader(toBackend))
 	if rt.conf.Elastic.Username != "" {
 		actionRequest.SetBasicAuth(rt.conf.Elastic.Username, rt.conf.Elastic.Password)
 	}
@@ -136,10 +192,10 @@ func (rt *Router) doPost(url string, request map[string]interface{}) ([]byte, er
 		return nil, err
 	}
 
-	if actionResult.StatusCode != 200 {
+	if actionResult.StatusCode != 200 && actionResult.StatusCode != 201 {
 		var e esError
 		_ = json.Unmarshal(body, &e)
-		return nil, errors.New(e.Error.Reason)
+		return nil, upstreamError(actionResult.StatusCode, e.Error.Reason)
 	}
 
 	return body, nil
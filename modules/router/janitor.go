@@ -0,0 +1,289 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// janitor periodically deletes restored indices older than indices.retention
+type janitor struct {
+	sync.RWMutex
+	rt        *Router
+	retention time.Duration
+	interval  time.Duration
+	dryRun    bool
+	// время восстановления, записанное самим extractor'ом
+	restored map[string]time.Time
+	// продление срока жизни индекса, нулевое время - индекс закреплён навсегда
+	pins map[string]time.Time
+}
+
+// indexPin - pin or extension of a restored index as the store keeps it
+type indexPin struct {
+	Index   string    `json:"index"`
+	Until   time.Time `json:"until"` // нулевое - закреплён навсегда
+	Removed bool      `json:"removed,omitempty"`
+}
+
+// retentionInfo - a restored index and when the janitor deletes it,
+// without restored the restore time is unknown and the index is kept
+type retentionInfo struct {
+	Index    string     `json:"index"`
+	Restored *time.Time `json:"restored,omitempty"`
+	Expires  *time.Time `json:"expires,omitempty"`
+	Pinned   bool       `json:"pinned"`
+}
+
+type indexRecovery struct {
+	Shards []struct {
+		Type      string `json:"type"`
+		StartTime int64  `json:"start_time_in_millis"`
+	} `json:"shards"`
+}
+
+func newJanitor(rt *Router) *janitor {
+	j := &janitor{
+		rt:       rt,
+		dryRun:   rt.conf.Indices.DryRun,
+		restored: make(map[string]time.Time),
+		pins:     make(map[string]time.Time),
+	}
+
+	var err error
+	if rt.conf.Indices.Retention != "" {
+		j.retention, err = time.ParseDuration(rt.conf.Indices.Retention)
+		if err != nil {
+			log.Println("Janitor: wrong indices.retention:", err)
+		}
+	}
+	j.interval, err = time.ParseDuration(rt.conf.Indices.CleanupInterval)
+	if err != nil || j.interval <= 0 {
+		j.interval = 10 * time.Minute
+	}
+
+	// без сохранённых закреплений первая же уборка удалит закреплённые индексы
+	pins, err := rt.store.Pins()
+	if err != nil {
+		log.Println("Janitor: can't load pins:", err)
+	}
+	for _, p := range pins {
+		j.pins[p.Index] = p.Until
+	}
+	return j
+}
+
+func (j *janitor) run() {
+	if j.retention <= 0 {
+		log.Println("Janitor: indices.retention is not set, restored indices will not be deleted")
+		return
+	}
+	log.Println("Janitor: retention", j.retention, "check every", j.interval, "dry-run:", j.dryRun)
+	for {
+		j.sweep()
+		time.Sleep(j.interval)
+	}
+}
+
+// touch records the restore time of just restored indices
+func (j *janitor) touch(indices []string, t time.Time) {
+	j.Lock()
+	defer j.Unlock()
+	for _, i := range indices {
+		j.restored[i] = t
+	}
+}
+
+func (j *janitor) forget(index string) {
+	j.Lock()
+	delete(j.restored, index)
+	_, pinned := j.pins[index]
+	delete(j.pins, index)
+	j.Unlock()
+	if pinned {
+		if err := j.rt.store.SavePin(indexPin{Index: index, Removed: true}); err != nil {
+			log.Println("Janitor:", err)
+		}
+	}
+}
+
+// pin keeps index until it is unpinned
+func (j *janitor) pin(index string) error {
+	return j.setPin(indexPin{Index: index})
+}
+
+func (j *janitor) unpin(index string) error {
+	return j.setPin(indexPin{Index: index, Removed: true})
+}
+
+// extend prolongs the life of index for d, counting from its current expiry
+func (j *janitor) extend(index string, restored time.Time, d time.Duration) error {
+	j.RLock()
+	until, ok := j.pins[index]
+	j.RUnlock()
+	if ok && until.IsZero() {
+		return nil
+	}
+	if !ok || until.Before(restored.Add(j.retention)) {
+		until = restored.Add(j.retention)
+	}
+	return j.setPin(indexPin{Index: index, Until: until.Add(d)})
+}
+
+// setPin saves the pin first, the sweep must not see what the store has lost
+func (j *janitor) setPin(p indexPin) error {
+	if err := j.rt.store.SavePin(p); err != nil {
+		return err
+	}
+	j.Lock()
+	defer j.Unlock()
+	if p.Removed {
+		delete(j.pins, p.Index)
+	} else {
+		j.pins[p.Index] = p.Until
+	}
+	return nil
+}
+
+func (j *janitor) expires(index string, restored time.Time) (time.Time, bool) {
+	j.RLock()
+	defer j.RUnlock()
+	if until, ok := j.pins[index]; ok {
+		if until.IsZero() {
+			return until, true
+		}
+		if until.After(restored.Add(j.retention)) {
+			return until, false
+		}
+	}
+	return restored.Add(j.retention), false
+}
+
+// info describes the retention of index, zero restored means the restore time is unknown
+func (j *janitor) info(index string, restored time.Time) retentionInfo {
+	ri := retentionInfo{Index: index}
+	if restored.IsZero() {
+		j.RLock()
+		until, ok := j.pins[index]
+		j.RUnlock()
+		ri.Pinned = ok && until.IsZero()
+		return ri
+	}
+	ri.Restored = &restored
+	if j.retention > 0 {
+		expires, pinned := j.expires(index, restored)
+		ri.Pinned = pinned
+		if !pinned {
+			ri.Expires = &expires
+		}
+	}
+	return ri
+}
+
+// list returns restored indices with the time they are going to be deleted
+func (j *janitor) list() ([]retentionInfo, error) {
+	times, err := j.restoreTimes()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []retentionInfo
+	for name, t := range times {
+		res = append(res, j.info(name, t))
+	}
+	sort.Slice(res, func(a, b int) bool { return res[a].Index < res[b].Index })
+	return res, nil
+}
+
+func (j *janitor) sweep() {
+	list, err := j.list()
+	if err != nil {
+		log.Println("Janitor:", err)
+		return
+	}
+
+	now := time.Now()
+	for _, ri := range list {
+		if ri.Pinned || ri.Expires == nil || ri.Expires.After(now) {
+			continue
+		}
+		if j.dryRun {
+			log.Println("Janitor: dry-run, index", ri.Index, "restored at", ri.Restored.Format(time.RFC3339), "would be deleted")
+			continue
+		}
+		_, err := j.rt.doDel(j.rt.conf.Elastic.Host + ri.Index)
+		if err != nil {
+			log.Println("Janitor: failed to delete index", ri.Index, ":", err)
+			continue
+		}
+		j.forget(ri.Index)
+		j.rt.record(event{User: "janitor", Action: "del_index", Index: ri.Index, Details: "retention " + j.retention.String()})
+		log.Println("Janitor: index", ri.Index, "restored at", ri.Restored.Format(time.RFC3339), "deleted, retention", j.retention)
+	}
+}
+
+// restoreTimes returns restore time for every index matching the restore prefix.
+// Time recorded by the extractor wins, then start of the snapshot recovery.
+// Otherwise the time is zero: creation date of a restored index is the date
+// of the original one, by it a just restored index would be expired.
+func (j *janitor) restoreTimes() (map[string]time.Time, error) {
+	pattern := j.rt.restoredPattern()
+	res := make(map[string]time.Time)
+
+	response, err := j.rt.doGet(j.rt.conf.Elastic.Host + "_cat/indices/" + pattern + "?format=json&h=index")
+	if err != nil {
+		return nil, err
+	}
+	var cat []struct {
+		Index string `json:"index"`
+	}
+	err = json.Unmarshal(response, &cat)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cat {
+		res[c.Index] = time.Time{}
+	}
+
+	response, err = j.rt.doGet(j.rt.conf.Elastic.Host + pattern + "/_recovery/")
+	if err == nil {
+		var rec map[string]indexRecovery
+		if json.Unmarshal(response, &rec) == nil {
+			for name, ir := range rec {
+				var start int64
+				for _, s := range ir.Shards {
+					if s.Type == "SNAPSHOT" && (start == 0 || s.StartTime < start) {
+						start = s.StartTime
+					}
+				}
+				if _, ok := res[name]; ok && start > 0 {
+					res[name] = time.Unix(0, start*int64(time.Millisecond))
+				}
+			}
+		}
+	}
+
+	j.RLock()
+	defer j.RUnlock()
+	for name := range res {
+		if t, ok := j.restored[name]; ok {
+			res[name] = t
+		}
+	}
+	return res, nil
+}
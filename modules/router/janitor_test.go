@@ -0,0 +1,138 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// janitorES - fake Elasticsearch with four restored indices:
+// recorded and recovered have a SNAPSHOT recovery three days ago,
+// peer has only a peer recovery, unknown has none
+func janitorES(t *testing.T) *fakeES {
+	old := time.Now().Add(-72*time.Hour).UnixNano() / int64(time.Millisecond)
+	return newFakeES(t, func(method, path string) (int, string) {
+		switch {
+		case method == http.MethodDelete:
+			return http.StatusOK, `{"acknowledged":true}`
+		case strings.HasPrefix(path, "/_cat/indices/"):
+			return http.StatusOK, `[{"index":"extracted_recorded"},{"index":"extracted_recovered"},{"index":"extracted_peer"},{"index":"extracted_unknown"}]`
+		case strings.HasSuffix(path, "/_recovery/"):
+			return http.StatusOK, fmt.Sprintf(`{
+				"extracted_recorded":{"shards":[{"type":"SNAPSHOT","start_time_in_millis":%d}]},
+				"extracted_recovered":{"shards":[{"type":"SNAPSHOT","start_time_in_millis":%d}]},
+				"extracted_peer":{"shards":[{"type":"PEER","start_time_in_millis":%d}]}
+			}`, old, old, old)
+		}
+		return http.StatusNotFound, `{}`
+	})
+}
+
+func TestJanitorRestoreTimes(t *testing.T) {
+	es := janitorES(t)
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\nindices:\n  retention: 48h\n")
+	recorded := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+	rt.janitor.touch([]string{"extracted_recorded"}, recorded)
+
+	times, err := rt.janitor.restoreTimes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// записанное extractor'ом время важнее recovery
+	if !times["extracted_recorded"].Equal(recorded) {
+		t.Errorf("recorded: %v, want %v", times["extracted_recorded"], recorded)
+	}
+	if d := time.Since(times["extracted_recovered"]); d < 71*time.Hour || d > 73*time.Hour {
+		t.Errorf("recovered: %v, want the SNAPSHOT recovery start", times["extracted_recovered"])
+	}
+	// без записи и без SNAPSHOT recovery время неизвестно
+	for _, name := range []string{"extracted_peer", "extracted_unknown"} {
+		if tm, ok := times[name]; !ok || !tm.IsZero() {
+			t.Errorf("%s: %v %v, want a zero time", name, tm, ok)
+		}
+	}
+}
+
+func TestJanitorSweep(t *testing.T) {
+	es := janitorES(t)
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\nindices:\n  retention: 48h\n")
+	rt.janitor.touch([]string{"extracted_recorded"}, time.Now().Add(-time.Hour))
+
+	list, err := rt.janitor.list()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ri := range list {
+		known := ri.Index == "extracted_recorded" || ri.Index == "extracted_recovered"
+		if (ri.Restored != nil) != known || (ri.Expires != nil) != known {
+			t.Errorf("%s: restored %v, expires %v", ri.Index, ri.Restored, ri.Expires)
+		}
+	}
+
+	rt.janitor.sweep()
+	var deleted []string
+	for _, c := range es.requests() {
+		if c.Method == http.MethodDelete {
+			deleted = append(deleted, strings.TrimPrefix(c.Path, "/"))
+		}
+	}
+	sort.Strings(deleted)
+	// удаляется только индекс с известным и истёкшим временем
+	if len(deleted) != 1 || deleted[0] != "extracted_recovered" {
+		t.Fatalf("deleted %v, want only extracted_recovered", deleted)
+	}
+}
+
+func TestJanitorPins(t *testing.T) {
+	es := janitorES(t)
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\nindices:\n  retention: 48h\n")
+	restored := time.Now().Add(-72 * time.Hour)
+
+	if err := rt.janitor.pin("extracted_recovered"); err != nil {
+		t.Fatal(err)
+	}
+	if ri := rt.janitor.info("extracted_recovered", restored); !ri.Pinned || ri.Expires != nil {
+		t.Fatalf("pinned: %+v", ri)
+	}
+	rt.janitor.sweep()
+	for _, c := range es.requests() {
+		if c.Method == http.MethodDelete && c.Path == "/extracted_recovered" {
+			t.Fatal("pinned index deleted")
+		}
+	}
+
+	if err := rt.janitor.unpin("extracted_recovered"); err != nil {
+		t.Fatal(err)
+	}
+	// продление считается от истечения срока, а не от текущего момента
+	if err := rt.janitor.extend("extracted_recovered", restored, 48*time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	ri := rt.janitor.info("extracted_recovered", restored)
+	if want := restored.Add(96 * time.Hour); ri.Pinned || ri.Expires == nil || !ri.Expires.Equal(want) {
+		t.Fatalf("extended: %+v, want expiry %v", ri, want)
+	}
+
+	// закрепление переживает перезапуск
+	rt.janitor.pin("extracted_peer")
+	rt.janitor = newJanitor(rt)
+	if ri := rt.janitor.info("extracted_peer", time.Time{}); !ri.Pinned {
+		t.Fatalf("pin lost on reload: %+v", ri)
+	}
+}
@@ -0,0 +1,490 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	jobQueued    = "queued"
+	jobRunning   = "running"
+	jobDone      = "done"
+	jobFailed    = "failed"
+	jobCancelled = "cancelled"
+)
+
+// restoreJob - one call of the restore action
+type restoreJob struct {
+	ID       string            `json:"id"`
+	User     string            `json:"user,omitempty"`
+	Repo     string            `json:"repo"`
+	Snapshot string            `json:"snapshot"`
+	Indices  []string          `json:"indices"`
+	Targets  map[string]string `json:"targets"`            // original name -> restored name
+	Reserved map[string]int64  `json:"reserved,omitempty"` // node -> bytes the plan put there
+	Priority int               `json:"priority,omitempty"`
+	Queued   *time.Time        `json:"queued,omitempty"`
+	Started  time.Time         `json:"started"`
+	Finished *time.Time        `json:"finished,omitempty"`
+	State    string            `json:"state"`
+	Error    string            `json:"error,omitempty"`
+	// задание восстановлено по _recovery после перезапуска
+	Reconstructed bool `json:"reconstructed,omitempty"`
+}
+
+// targetProgress - recovery progress of one restored index
+type targetProgress struct {
+	Index      string  `json:"index"`
+	Total      int64   `json:"total_bytes"`
+	Recovered  int64   `json:"recovered_bytes"`
+	Files      int     `json:"total_files"`
+	FilesDone  int     `json:"recovered_files"`
+	Percent    float64 `json:"percent"`
+	Shards     int     `json:"shards"`
+	ShardsDone int     `json:"shards_done"`
+	Error      string  `json:"error,omitempty"`
+}
+
+type jobProgress struct {
+	restoreJob
+	Position int                       `json:"position,omitempty"` // место в очереди
+	Percent  float64                   `json:"percent"`
+	ETA      int64                     `json:"eta_seconds,omitempty"`
+	Progress map[string]targetProgress `json:"progress"`
+}
+
+type shardRecovery struct {
+	Type   string `json:"type"`
+	Stage  string `json:"stage"`
+	Source struct {
+		Repository string `json:"repository"`
+		Snapshot   string `json:"snapshot"`
+		Index      string `json:"index"`
+	} `json:"source"`
+	Target struct {
+		Name string `json:"name"`
+	} `json:"target"`
+	StartTime int64 `json:"start_time_in_millis"`
+	StopTime  int64 `json:"stop_time_in_millis"`
+	Index     struct {
+		Size struct {
+			Total     int64 `json:"total_in_bytes"`
+			Recovered int64 `json:"recovered_in_bytes"`
+		} `json:"size"`
+		Files struct {
+			Total     int `json:"total"`
+			Recovered int `json:"recovered"`
+		} `json:"files"`
+	} `json:"index"`
+}
+
+type jobRegistry struct {
+	sync.RWMutex
+	rt   *Router
+	list map[string]*restoreJob
+}
+
+func newJobRegistry(rt *Router) *jobRegistry {
+	jr := &jobRegistry{rt: rt, list: make(map[string]*restoreJob)}
+	saved, err := rt.store.Jobs()
+	if err != nil {
+		log.Println("Store: can't load jobs:", err)
+	}
+	for i := range saved {
+		jr.list[saved[i].ID] = &saved[i]
+		// время восстановления для janitor переживает перезапуск
+		var targets []string
+		for _, t := range saved[i].Targets {
+			targets = append(targets, t)
+		}
+		rt.janitor.touch(targets, saved[i].Started)
+	}
+	return jr
+}
+
+// recover fails jobs that were queued before the restart: the queue is not
+// saved and they would never run. Only the server does it, a command-line
+// client must not touch the jobs of a running extractor.
+func (jr *jobRegistry) recover() {
+	jr.Lock()
+	var failed []restoreJob
+	now := time.Now()
+	for _, j := range jr.list {
+		if j.State == jobQueued {
+			j.State, j.Error, j.Finished = jobFailed, "extractor was restarted while the job was queued", &now
+			failed = append(failed, *j)
+		}
+	}
+	jr.Unlock()
+	for _, j := range failed {
+		jr.save(j)
+	}
+}
+
+func (jr *jobRegistry) save(j restoreJob) {
+	if err := jr.rt.store.SaveJob(j); err != nil {
+		log.Println("Store: can't save job", j.ID, ":", err)
+	}
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func (jr *jobRegistry) add(j *restoreJob) {
+	jr.Lock()
+	if j.ID == "" {
+		j.ID = newJobID()
+	}
+	if j.State == "" {
+		j.State = jobRunning
+	}
+	jr.list[j.ID] = j
+	saved := *j
+	jr.Unlock()
+	jr.save(saved)
+}
+
+// start marks a queued job as running with the indices actually restored
+func (jr *jobRegistry) start(id string, indices []string, targets map[string]string, reserved map[string]int64, started time.Time) {
+	jr.Lock()
+	j, ok := jr.list[id]
+	if !ok {
+		jr.Unlock()
+		return
+	}
+	j.Indices, j.Targets, j.Reserved, j.Started, j.State = indices, targets, reserved, started, jobRunning
+	saved := *j
+	jr.Unlock()
+	jr.save(saved)
+}
+
+// finish ends the job with the state and the error
+func (jr *jobRegistry) finish(id, state, reason string) {
+	now := time.Now()
+	jr.Lock()
+	j, ok := jr.list[id]
+	if !ok {
+		jr.Unlock()
+		return
+	}
+	j.State, j.Error, j.Finished = state, reason, &now
+	saved := *j
+	jr.Unlock()
+	jr.save(saved)
+}
+
+// running refreshes the state of running jobs and returns how many are still running
+func (jr *jobRegistry) running() int {
+	jr.RLock()
+	var list []*restoreJob
+	for _, j := range jr.list {
+		if j.State == jobRunning {
+			list = append(list, j)
+		}
+	}
+	jr.RUnlock()
+
+	n := 0
+	for _, j := range list {
+		p, err := jr.progress(j)
+		// при ошибке ES считаем задание выполняющимся
+		if err != nil || p.State == jobRunning {
+			n++
+		}
+	}
+	return n
+}
+
+// reserved returns the bytes running jobs are still to write to each node:
+// what their plans put on the node minus what is already recovered there
+func (jr *jobRegistry) reserved() (map[string]int64, error) {
+	res := make(map[string]int64)
+	var (
+		list    []restoreJob
+		targets []string
+	)
+	jr.RLock()
+	for _, j := range jr.list {
+		if j.State == jobRunning && len(j.Reserved) > 0 {
+			list = append(list, *j)
+			for _, t := range j.Targets {
+				targets = append(targets, t)
+			}
+		}
+	}
+	jr.RUnlock()
+	if len(targets) == 0 {
+		return res, nil
+	}
+	sort.Strings(targets)
+
+	rec := make(map[string]struct {
+		Shards []shardRecovery `json:"shards"`
+	})
+	response, err := jr.rt.doGet(jr.rt.conf.Elastic.Host + strings.Join(targets, ",") + "/_recovery?ignore_unavailable=true")
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(response, &rec)
+	if err != nil {
+		return nil, err
+	}
+	for _, j := range list {
+		recovered := make(map[string]int64)
+		for _, t := range j.Targets {
+			for _, s := range rec[t].Shards {
+				recovered[s.Target.Name] += s.Index.Size.Recovered
+			}
+		}
+		for node, size := range j.Reserved {
+			if left := size - recovered[node]; left > 0 {
+				res[node] += left
+			}
+		}
+	}
+	return res, nil
+}
+
+// get returns the job with its current progress
+func (jr *jobRegistry) get(id string) (*jobProgress, error) {
+	jr.discover()
+
+	jr.RLock()
+	j, ok := jr.list[id]
+	jr.RUnlock()
+	if !ok {
+		return nil, errors.New("job " + id + " not found")
+	}
+	return jr.progress(j)
+}
+
+// all returns every known job, newest first
+func (jr *jobRegistry) all() ([]*jobProgress, error) {
+	jr.discover()
+
+	jr.RLock()
+	var list []*restoreJob
+	for _, j := range jr.list {
+		list = append(list, j)
+	}
+	jr.RUnlock()
+	sort.Slice(list, func(a, b int) bool { return list[a].Started.After(list[b].Started) })
+
+	var res []*jobProgress
+	for _, j := range list {
+		p, err := jr.progress(j)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+// progress aggregates _recovery of the job targets
+func (jr *jobRegistry) progress(j *restoreJob) (*jobProgress, error) {
+	var targets []string
+	jr.RLock()
+	p := &jobProgress{restoreJob: *j, Progress: make(map[string]targetProgress)}
+	for _, t := range j.Targets {
+		targets = append(targets, t)
+	}
+	jr.RUnlock()
+	p.Position = jr.rt.queue.position(j.ID)
+	// завершённые задания больше не пересчитываем: индексы могли уже удалить
+	if p.State != jobRunning {
+		if p.State == jobDone {
+			p.Percent = 100
+		}
+		return p, nil
+	}
+	sort.Strings(targets)
+	if len(targets) == 0 {
+		return p, nil
+	}
+
+	rec := make(map[string]struct {
+		Shards []shardRecovery `json:"shards"`
+	})
+	// индексы могли удалить, поэтому ignore_unavailable
+	response, err := jr.rt.doGet(jr.rt.conf.Elastic.Host + strings.Join(targets, ",") + "/_recovery?ignore_unavailable=true")
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(response, &rec)
+	if err != nil {
+		return nil, err
+	}
+	failed := jr.failedShards(targets)
+
+	var total, recovered int64
+	done := true
+	for _, t := range targets {
+		tp := targetProgress{Index: t, Error: failed[t]}
+		for _, s := range rec[t].Shards {
+			tp.Shards++
+			tp.Total += s.Index.Size.Total
+			tp.Recovered += s.Index.Size.Recovered
+			tp.Files += s.Index.Files.Total
+			tp.FilesDone += s.Index.Files.Recovered
+			if s.Stage == "DONE" {
+				tp.ShardsDone++
+			}
+		}
+		if tp.Total > 0 {
+			tp.Percent = float64(tp.Recovered) * 100 / float64(tp.Total)
+		} else if tp.Shards > 0 && tp.Shards == tp.ShardsDone {
+			tp.Percent = 100
+		}
+		if _, ok := rec[t]; !ok && tp.Error == "" && time.Since(p.Started) > time.Minute {
+			tp.Error = "index not found, restore failed or index deleted"
+		}
+		if tp.Shards == 0 || tp.ShardsDone < tp.Shards {
+			done = false
+		}
+		if tp.Error != "" && p.Error == "" {
+			p.Error = t + ": " + tp.Error
+		}
+		total += tp.Total
+		recovered += tp.Recovered
+		p.Progress[t] = tp
+	}
+
+	if total > 0 {
+		p.Percent = float64(recovered) * 100 / float64(total)
+	}
+	if done {
+		p.Percent = 100
+	}
+	switch {
+	case p.Error != "":
+		p.State = jobFailed
+	case done:
+		p.State = jobDone
+	default:
+		p.State = jobRunning
+		if p.Percent > 0 {
+			elapsed := time.Since(p.Started).Seconds()
+			p.ETA = int64(elapsed * (100 - p.Percent) / p.Percent)
+		}
+	}
+
+	if p.State != jobRunning {
+		now := time.Now()
+		jr.Lock()
+		j.State, j.Error, j.Finished = p.State, p.Error, &now
+		saved := *j
+		jr.Unlock()
+		jr.save(saved)
+		p.Finished = &now
+	}
+	return p, nil
+}
+
+// failedShards returns the unassigned reason for indices whose shards failed to restore
+func (jr *jobRegistry) failedShards(targets []string) map[string]string {
+	res := make(map[string]string)
+	var cat []map[string]string
+	response, err := jr.rt.doGet(jr.rt.conf.Elastic.Host + "_cat/shards/" + strings.Join(targets, ",") + "?format=json&h=index,shard,prirep,state,unassigned.reason,unassigned.details")
+	if err != nil || json.Unmarshal(response, &cat) != nil {
+		return res
+	}
+	for _, c := range cat {
+		if c["state"] != "UNASSIGNED" {
+			continue
+		}
+		if c["unassigned.reason"] == "ALLOCATION_FAILED" || strings.Contains(c["unassigned.details"], "restore") {
+			res[c["index"]] = "shard " + c["shard"] + ": " + c["unassigned.reason"] + " " + c["unassigned.details"]
+		}
+	}
+	return res
+}
+
+// discover rebuilds jobs for restored indices unknown to the registry,
+// e.g. after a restart. Snapshot recoveries are grouped by repository and snapshot.
+func (jr *jobRegistry) discover() {
+	rec := make(map[string]struct {
+		Shards []shardRecovery `json:"shards"`
+	})
+	response, err := jr.rt.doGet(jr.rt.conf.Elastic.Host + jr.rt.restoredPattern() + "/_recovery/")
+	if err != nil || json.Unmarshal(response, &rec) != nil {
+		return
+	}
+
+	jr.Lock()
+	known := make(map[string]bool)
+	found := make(map[string]*restoreJob)
+	for _, j := range jr.list {
+		for _, t := range j.Targets {
+			known[t] = true
+		}
+	}
+
+	for name, r := range rec {
+		if known[name] {
+			continue
+		}
+		for _, s := range r.Shards {
+			if s.Type != "SNAPSHOT" {
+				continue
+			}
+			sum := sha1.Sum([]byte(s.Source.Repository + "/" + s.Source.Snapshot))
+			id := hex.EncodeToString(sum[:8])
+			j, ok := jr.list[id]
+			if !ok {
+				j = &restoreJob{
+					ID:            id,
+					Repo:          s.Source.Repository,
+					Snapshot:      s.Source.Snapshot,
+					Targets:       make(map[string]string),
+					State:         jobRunning,
+					Reconstructed: true,
+				}
+				jr.list[id] = j
+			}
+			found[id] = j
+			if _, ok := j.Targets[s.Source.Index]; !ok {
+				j.Indices = append(j.Indices, s.Source.Index)
+				j.Targets[s.Source.Index] = name
+			}
+			start := time.Unix(0, s.StartTime*int64(time.Millisecond))
+			if j.Started.IsZero() || start.Before(j.Started) {
+				j.Started = start
+			}
+			break
+		}
+	}
+
+	var saved []restoreJob
+	for _, j := range found {
+		saved = append(saved, *j)
+	}
+	jr.Unlock()
+	for _, j := range saved {
+		jr.save(j)
+	}
+}
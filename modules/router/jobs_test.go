@@ -0,0 +1,117 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecoverServerOnly(t *testing.T) {
+	rt := testRouter(t, "")
+	rt.store.SaveJob(restoreJob{ID: "queued", Repo: "archive", Snapshot: "s1", State: jobQueued, Started: time.Now()})
+	rt.store.SaveJob(restoreJob{ID: "done", Repo: "archive", Snapshot: "s1", State: jobDone, Started: time.Now()})
+	rt.store.SaveCheck(repoCheck{ID: "check", Repo: "archive", Kind: checkVerify, State: jobRunning, Steps: []checkStep{{Name: "verify"}}})
+
+	// так загружает local: задания работающего сервера не трогаются
+	rt.jobs = newJobRegistry(rt)
+	rt.checks = newCheckRegistry(rt)
+	jobs, _ := rt.store.Jobs()
+	for _, j := range jobs {
+		if j.ID == "queued" && j.State != jobQueued {
+			t.Fatalf("loading failed the queued job: %+v", j)
+		}
+	}
+	checks, _ := rt.store.Checks()
+	if len(checks) != 1 || checks[0].State != jobRunning {
+		t.Fatalf("loading failed the running check: %+v", checks)
+	}
+
+	rt.jobs.recover()
+	rt.checks.recover()
+	jobs, _ = rt.store.Jobs()
+	for _, j := range jobs {
+		want := jobFailed
+		if j.ID == "done" {
+			want = jobDone
+		}
+		if j.State != want || (j.ID == "queued" && j.Finished == nil) {
+			t.Errorf("job %s after recover: %+v, want %s", j.ID, j, want)
+		}
+	}
+	checks, _ = rt.store.Checks()
+	if len(checks) != 1 || checks[0].State != jobFailed || checks[0].Steps[0].State != stepFailed {
+		t.Errorf("check after recover: %+v", checks)
+	}
+}
+
+func TestJobsReserved(t *testing.T) {
+	es := newFakeES(t, func(method, path string) (int, string) {
+		if !strings.HasSuffix(path, "/_recovery") {
+			return http.StatusNotFound, `{}`
+		}
+		// n1 уже получил 40 байт из 100, n2 ещё ничего
+		return http.StatusOK, `{"extracted_a":{"shards":[
+			{"type":"SNAPSHOT","stage":"INDEX","target":{"name":"n1"},"index":{"size":{"total_in_bytes":100,"recovered_in_bytes":40}}}
+		]}}`
+	})
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n")
+	rt.jobs = newJobRegistry(rt)
+
+	got, err := rt.jobs.reserved()
+	if err != nil || len(got) != 0 {
+		t.Fatalf("without running jobs: %v %v", got, err)
+	}
+
+	rt.jobs.add(&restoreJob{ID: "running", Targets: map[string]string{"a": "extracted_a"}, Reserved: map[string]int64{"n1": 100, "n2": 100}, State: jobRunning})
+	rt.jobs.add(&restoreJob{ID: "done", Targets: map[string]string{"b": "extracted_b"}, Reserved: map[string]int64{"n1": 500}, State: jobDone})
+	got, err = rt.jobs.reserved()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]int64{"n1": 60, "n2": 100}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("reserved %v, want %v", got, want)
+	}
+}
+
+func TestCancelJobOwner(t *testing.T) {
+	es := newFakeES(t, func(method, path string) (int, string) {
+		return http.StatusOK, `{}`
+	})
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n"+aclConfig)
+	rt.jobs = newJobRegistry(rt)
+	rt.queue = newRestoreQueue(rt)
+	request := apiRequest{Action: "restore"}
+	request.Values.Repo = "archive"
+	plan := &restorePlan{Repo: "archive", Snapshot: "s1", Accepted: []string{"logs-a"}, vars: renameVars{User: "operator"}}
+
+	for _, tt := range []struct {
+		user   string
+		status int
+	}{
+		{user: "operator2", status: http.StatusForbidden},
+		{user: "operator", status: http.StatusOK},
+		{user: "admin", status: http.StatusOK},
+	} {
+		owner := identityRequest(&identity{Name: "operator"}, "/api/v1/repositories/archive/snapshots/s1/restore")
+		job := rt.queue.push(owner, &request, plan)
+		_, err := rt.cancelJob(identityRequest(&identity{Name: tt.user}, "/api/v1/jobs/"+job.ID+"/cancel"), job.ID)
+		if status := httpStatus(err); err == nil && tt.status != http.StatusOK || err != nil && status != tt.status {
+			t.Errorf("%s cancels a job of operator: %v, want %d", tt.user, err, tt.status)
+		}
+	}
+}
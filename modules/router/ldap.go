@@ -0,0 +1,264 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// ldapAuth - bind as a service account, find the user, bind as the user
+// and collect names of its groups. Successful binds are cached for
+// auth.ldap.cache_ttl, so that API calls with basic auth don't bind every time.
+type ldapAuth struct {
+	rt      *Router
+	tls     *tls.Config
+	timeout time.Duration
+
+	sync.Mutex
+	ttl   time.Duration
+	key   []byte // ключ HMAC паролей в кэше
+	cache map[string]ldapBind
+}
+
+// ldapBind - cached successful bind of a login
+type ldapBind struct {
+	mac     []byte
+	id      *identity
+	expires time.Time
+}
+
+func newLdapAuth(rt *Router) (*ldapAuth, error) {
+	c := rt.conf.Auth.Ldap
+	if c.URL == "" || c.BaseDN == "" {
+		return nil, errors.New("auth.ldap.url and auth.ldap.base_dn are required")
+	}
+	a := &ldapAuth{
+		rt:      rt,
+		tls:     &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify},
+		timeout: time.Duration(rt.conf.App.TimeOut) * time.Second,
+		ttl:     time.Minute,
+		key:     make([]byte, 32),
+		cache:   make(map[string]ldapBind),
+	}
+	if c.CacheTTL != "" {
+		d, err := time.ParseDuration(c.CacheTTL)
+		if err != nil {
+			return nil, errors.New("auth.ldap.cache_ttl: " + err.Error())
+		}
+		a.ttl = d
+	}
+	if _, err := rand.Read(a.key); err != nil {
+		return nil, err
+	}
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		a.tls.RootCAs = x509.NewCertPool()
+		if !a.tls.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("auth.ldap.ca_file: no certificates found")
+		}
+	}
+	return a, nil
+}
+
+func (a *ldapAuth) dial() (*ldap.Conn, error) {
+	conn, err := ldap.DialURL(a.rt.conf.Auth.Ldap.URL, ldap.DialWithTLSConfig(a.tls))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(a.timeout)
+	if a.rt.conf.Auth.Ldap.StartTLS {
+		err = conn.StartTLS(a.tls)
+		if err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (a *ldapAuth) mac(login, password string) []byte {
+	m := hmac.New(sha256.New, a.key)
+	m.Write([]byte(login + "\x00" + password))
+	return m.Sum(nil)
+}
+
+// cached returns the identity of a recent successful bind with the same password
+func (a *ldapAuth) cached(login, password string) *identity {
+	a.Lock()
+	defer a.Unlock()
+	b, ok := a.cache[login]
+	if !ok || time.Now().After(b.expires) || !hmac.Equal(b.mac, a.mac(login, password)) {
+		return nil
+	}
+	return b.id
+}
+
+func (a *ldapAuth) remember(login, password string, id *identity) {
+	if a.ttl <= 0 {
+		return
+	}
+	a.Lock()
+	defer a.Unlock()
+	now := time.Now()
+	for l, b := range a.cache {
+		if now.After(b.expires) {
+			delete(a.cache, l)
+		}
+	}
+	a.cache[login] = ldapBind{mac: a.mac(login, password), id: id, expires: now.Add(a.ttl)}
+}
+
+func (a *ldapAuth) Authenticate(login, password string) (*identity, error) {
+	// пустой пароль в LDAP означает анонимный bind, который всегда успешен
+	if login == "" || password == "" {
+		return nil, errBadCredentials
+	}
+	if id := a.cached(login, password); id != nil {
+		return id, nil
+	}
+	id, err := a.bind(login, password)
+	if err == nil {
+		a.remember(login, password, id)
+	}
+	return id, err
+}
+
+// bind checks the password against the directory and reads the groups of the user
+func (a *ldapAuth) bind(login, password string) (*identity, error) {
+	conn, err := a.dial()
+	if err != nil {
+		log.Println("LDAP:", err)
+		return nil, err
+	}
+	defer conn.Close()
+
+	user, err := a.search(conn, login)
+	if err != nil {
+		return nil, err
+	}
+	err = conn.Bind(user.DN, password)
+	if err != nil {
+		return nil, errBadCredentials
+	}
+	return a.userIdentity(conn, login, user)
+}
+
+// Lookup reads the groups of the user without the password, schedules run
+// on behalf of their owner with the groups the directory has now
+func (a *ldapAuth) Lookup(login string) (*identity, error) {
+	conn, err := a.dial()
+	if err != nil {
+		log.Println("LDAP:", err)
+		return nil, err
+	}
+	defer conn.Close()
+
+	user, err := a.search(conn, login)
+	if err == errBadCredentials {
+		return nil, errors.New("user " + login + " is not found in the directory")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return a.userIdentity(conn, login, user)
+}
+
+// search finds the entry of the user on behalf of the service account
+func (a *ldapAuth) search(conn *ldap.Conn, login string) (*ldap.Entry, error) {
+	c := a.rt.conf.Auth.Ldap
+	if c.BindDN != "" {
+		err := conn.Bind(c.BindDN, c.BindPassword)
+		if err != nil {
+			log.Println("LDAP: service account bind:", err)
+			return nil, err
+		}
+	}
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		c.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(a.timeout.Seconds()), false,
+		fmt.Sprintf(c.UserFilter, ldap.EscapeFilter(login)),
+		[]string{"dn", c.GroupAttribute}, nil,
+	))
+	if err != nil {
+		log.Println("LDAP: user search:", err)
+		return nil, err
+	}
+	if len(res.Entries) != 1 {
+		return nil, errBadCredentials
+	}
+	return res.Entries[0], nil
+}
+
+// userIdentity reads the groups of the found user
+func (a *ldapAuth) userIdentity(conn *ldap.Conn, login string, user *ldap.Entry) (*identity, error) {
+	c := a.rt.conf.Auth.Ldap
+	id := &identity{Name: login}
+	if c.GroupFilter == "" {
+		for _, dn := range user.GetAttributeValues(c.GroupAttribute) {
+			id.Groups = append(id.Groups, groupName(dn))
+		}
+		return id, nil
+	}
+
+	// поиск групп отдельным запросом, например вложенных групп AD:
+	// (member:1.2.840.113556.1.4.1941:=%s)
+	if c.BindDN != "" {
+		err := conn.Bind(c.BindDN, c.BindPassword)
+		if err != nil {
+			return nil, err
+		}
+	}
+	base := c.GroupBaseDN
+	if base == "" {
+		base = c.BaseDN
+	}
+	groups, err := conn.Search(ldap.NewSearchRequest(
+		base, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(a.timeout.Seconds()), false,
+		fmt.Sprintf(c.GroupFilter, ldap.EscapeFilter(user.DN)),
+		[]string{"cn"}, nil,
+	))
+	if err != nil {
+		log.Println("LDAP: group search:", err)
+		return nil, err
+	}
+	for _, g := range groups.Entries {
+		id.Groups = append(id.Groups, g.GetAttributeValue("cn"))
+	}
+	return id, nil
+}
+
+// groupName returns the value of the first RDN: CN=analysts,OU=Groups,DC=corp -> analysts
+func groupName(dn string) string {
+	parsed, err := ldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) == 0 || len(parsed.RDNs[0].Attributes) == 0 {
+		return dn
+	}
+	return strings.TrimSpace(parsed.RDNs[0].Attributes[0].Value)
+}
@@ -0,0 +1,254 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+)
+
+// fakeLdap - just enough of an LDAP server for ldapAuth: simple bind,
+// search by one attribute and memberOf of the found entries
+type fakeLdap struct {
+	sync.Mutex
+	ln      net.Listener
+	entries map[string]fakeEntry // dn -> entry
+	binds   int
+}
+
+type fakeEntry struct {
+	password string
+	attrs    map[string][]string
+}
+
+func newFakeLdap(t *testing.T) *fakeLdap {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeLdap{ln: ln, entries: map[string]fakeEntry{
+		"CN=extractor,OU=Service,DC=corp": {password: "service"},
+		"CN=Alice,OU=Users,DC=corp": {password: "secret", attrs: map[string][]string{
+			"sAMAccountName": {"alice"},
+			"memberOf":       {"CN=analysts,OU=Groups,DC=corp", "CN=ops,OU=Groups,DC=corp"},
+		}},
+		"CN=analysts,OU=Groups,DC=corp": {attrs: map[string][]string{"cn": {"analysts"}, "member": {"CN=Alice,OU=Users,DC=corp"}}},
+	}}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *fakeLdap) url() string {
+	return "ldap://" + s.ln.Addr().String()
+}
+
+func (s *fakeLdap) bindCount() int {
+	s.Lock()
+	defer s.Unlock()
+	return s.binds
+}
+
+var simpleFilter = regexp.MustCompile(`^\(([^=()]+)=([^()]*)\)$`)
+
+func (s *fakeLdap) serve(conn net.Conn) {
+	defer conn.Close()
+	for {
+		p, err := ber.ReadPacket(conn)
+		if err != nil || len(p.Children) < 2 {
+			return
+		}
+		id := p.Children[0].Value.(int64)
+		op := p.Children[1]
+		switch op.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := op.Children[1].Value.(string)
+			password := op.Children[2].Data.String()
+			s.Lock()
+			s.binds++
+			e, ok := s.entries[dn]
+			s.Unlock()
+			code := ldap.LDAPResultSuccess
+			if !ok || e.password == "" || e.password != password {
+				code = ldap.LDAPResultInvalidCredentials
+			}
+			s.write(conn, id, ldap.ApplicationBindResponse, code)
+		case ldap.ApplicationSearchRequest:
+			filter, _ := ldap.DecompileFilter(op.Children[6])
+			m := simpleFilter.FindStringSubmatch(filter)
+			s.Lock()
+			for dn, e := range s.entries {
+				if m == nil || !matchValue(e.attrs[m[1]], m[2]) {
+					continue
+				}
+				entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "entry")
+				entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "dn"))
+				attrs := ber.NewSequence("attributes")
+				for name, values := range e.attrs {
+					a := ber.NewSequence("attribute")
+					a.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "type"))
+					set := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "values")
+					for _, v := range values {
+						set.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "value"))
+					}
+					a.AppendChild(set)
+					attrs.AppendChild(a)
+				}
+				entry.AppendChild(attrs)
+				msg := ber.NewSequence("message")
+				msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "id"))
+				msg.AppendChild(entry)
+				conn.Write(msg.Bytes())
+			}
+			s.Unlock()
+			s.write(conn, id, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess)
+		case ldap.ApplicationUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+func matchValue(values []string, want string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, want) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *fakeLdap) write(conn net.Conn, id int64, tag ber.Tag, code int) {
+	res := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "result")
+	res.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "code"))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "matched dn"))
+	res.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "message"))
+	msg := ber.NewSequence("message")
+	msg.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "id"))
+	msg.AppendChild(res)
+	conn.Write(msg.Bytes())
+}
+
+func testLdapAuth(t *testing.T, s *fakeLdap, extra string) *ldapAuth {
+	t.Helper()
+	rt := testRouter(t, `
+auth:
+  type: ldap
+  ldap:
+    url: `+s.url()+`
+    bind_dn: CN=extractor,OU=Service,DC=corp
+    bind_password: service
+    base_dn: DC=corp
+`+extra)
+	a, err := newLdapAuth(rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestLdapAuthenticate(t *testing.T) {
+	s := newFakeLdap(t)
+	a := testLdapAuth(t, s, "")
+
+	id, err := a.Authenticate("alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Name != "alice" || !reflect.DeepEqual(id.Groups, []string{"analysts", "ops"}) {
+		t.Fatalf("identity %+v, want alice in analysts and ops", id)
+	}
+
+	for _, creds := range [][2]string{{"alice", "wrong"}, {"bob", "secret"}, {"alice", ""}, {"", "secret"}} {
+		if _, err := a.Authenticate(creds[0], creds[1]); err != errBadCredentials {
+			t.Errorf("Authenticate(%q, %q): %v, want %v", creds[0], creds[1], err, errBadCredentials)
+		}
+	}
+}
+
+func TestLdapGroupFilter(t *testing.T) {
+	s := newFakeLdap(t)
+	a := testLdapAuth(t, s, "    group_filter: (member=%s)\n")
+
+	id, err := a.Authenticate("alice", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(id.Groups, []string{"analysts"}) {
+		t.Fatalf("groups %v, want [analysts]", id.Groups)
+	}
+}
+
+func TestLdapBindCache(t *testing.T) {
+	s := newFakeLdap(t)
+	a := testLdapAuth(t, s, "")
+
+	if _, err := a.Authenticate("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	binds := s.bindCount()
+	if _, err := a.Authenticate("alice", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if s.bindCount() != binds {
+		t.Fatalf("second call made %d binds, want the cached one", s.bindCount()-binds)
+	}
+	// другой пароль в кэше не найдётся
+	if _, err := a.Authenticate("alice", "wrong"); err != errBadCredentials {
+		t.Fatalf("wrong password: %v", err)
+	}
+	if s.bindCount() == binds {
+		t.Fatal("wrong password must be checked by the server")
+	}
+
+	a = testLdapAuth(t, s, "    cache_ttl: \"0\"\n")
+	a.Authenticate("alice", "secret")
+	binds = s.bindCount()
+	a.Authenticate("alice", "secret")
+	if s.bindCount() == binds {
+		t.Fatal("cache_ttl 0 must bind on every call")
+	}
+}
+
+func TestLdapLookup(t *testing.T) {
+	s := newFakeLdap(t)
+	a := testLdapAuth(t, s, "")
+
+	id, err := a.Lookup("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.Name != "alice" || !reflect.DeepEqual(id.Groups, []string{"analysts", "ops"}) {
+		t.Fatalf("identity %+v, want alice in analysts and ops", id)
+	}
+	if _, err := a.Lookup("bob"); err == nil || err == errBadCredentials {
+		t.Fatalf("lookup of a missing user: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+
+	"github.com/uzhinskiy/extractor/modules/config"
+)
+
+// localTransport serves /api/v1 requests in-process, straight against Elasticsearch
+type localTransport struct {
+	rt *Router
+	id *identity
+}
+
+// Local returns a RoundTripper for command-line clients working without
+// a running extractor. Requests are made on behalf of user without any
+// authentication: rbac checks the name only, and whoever can read the config
+// and reach Elasticsearch may pass any name.
+func Local(cnf config.Config, user string) (http.RoundTripper, error) {
+	rt, err := prepare(cnf)
+	if err != nil {
+		return nil, err
+	}
+	return &localTransport{rt: rt, id: &identity{Name: user}}, nil
+}
+
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *recorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *recorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *recorder) Write(b []byte) (int, error) {
+	rec.WriteHeader(http.StatusOK)
+	return rec.body.Write(b)
+}
+
+func (t *localTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.WithContext(context.WithValue(req.Context(), identityKey, t.id))
+	r.RequestURI = req.URL.RequestURI()
+	r.RemoteAddr = "127.0.0.1:0"
+	if r.Body == nil {
+		r.Body = http.NoBody
+	}
+
+	rec := &recorder{header: make(http.Header)}
+	t.rt.V1Handler(rec, r)
+	rec.WriteHeader(http.StatusOK)
+
+	return &http.Response{
+		Status:        strconv.Itoa(rec.status) + " " + http.StatusText(rec.status),
+		StatusCode:    rec.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.header,
+		Body:          ioutil.NopCloser(&rec.body),
+		ContentLength: int64(rec.body.Len()),
+		Request:       req,
+	}, nil
+}
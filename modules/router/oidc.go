@@ -0,0 +1,473 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uzhinskiy/lib.go/helpers"
+)
+
+// oidcProvider - authorization code flow against an OpenID Connect issuer
+type oidcProvider struct {
+	sync.Mutex
+	rt *Router
+	// клиент elasticsearch не проверяет сертификаты, провайдеру так доверять нельзя
+	hc *http.Client
+	// из .well-known/openid-configuration
+	issuer        string
+	authEndpoint  string
+	tokenEndpoint string
+	jwksURI       string
+	keys          map[string]crypto.PublicKey
+	// state -> nonce незавершённых входов
+	pending map[string]oidcPending
+}
+
+type oidcPending struct {
+	nonce   string
+	expires time.Time
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func newOidcProvider(rt *Router) (*oidcProvider, error) {
+	c := rt.conf.Auth.Oidc
+	if c.Issuer == "" || c.ClientID == "" || c.RedirectURL == "" {
+		return nil, errors.New("auth.oidc.issuer, client_id and redirect_url are required")
+	}
+	tlsConf := &tls.Config{}
+	if c.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConf.RootCAs = x509.NewCertPool()
+		if !tlsConf.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("auth.oidc.ca_file: no certificates found")
+		}
+	}
+	hc := &http.Client{
+		Timeout:   time.Duration(rt.conf.App.TimeOut) * time.Second,
+		Transport: &http.Transport{Proxy: http.ProxyFromEnvironment, TLSClientConfig: tlsConf},
+	}
+	return &oidcProvider{rt: rt, hc: hc, pending: make(map[string]oidcPending)}, nil
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// discover reads the provider configuration once
+func (o *oidcProvider) discover() error {
+	o.Lock()
+	defer o.Unlock()
+	if o.authEndpoint != "" {
+		return nil
+	}
+
+	var d struct {
+		Issuer        string `json:"issuer"`
+		AuthEndpoint  string `json:"authorization_endpoint"`
+		TokenEndpoint string `json:"token_endpoint"`
+		JwksURI       string `json:"jwks_uri"`
+	}
+	issuer := strings.TrimSuffix(o.rt.conf.Auth.Oidc.Issuer, "/")
+	err := o.getJSON(issuer+"/.well-known/openid-configuration", &d)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != issuer {
+		return errors.New("issuer mismatch: " + d.Issuer)
+	}
+	o.issuer, o.authEndpoint, o.tokenEndpoint, o.jwksURI = d.Issuer, d.AuthEndpoint, d.TokenEndpoint, d.JwksURI
+	return nil
+}
+
+func (o *oidcProvider) getJSON(u string, v interface{}) error {
+	resp, err := o.hc.Get(u)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != 200 {
+		return errors.New("Wrong response: " + resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// LoginHandler redirects the browser to the provider
+func (o *oidcProvider) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	err := o.discover()
+	if err != nil {
+		log.Println("OIDC:", err)
+		http.Error(w, "OpenID Connect provider is unavailable", http.StatusBadGateway)
+		return
+	}
+
+	state, err := randomString()
+	var nonce string
+	if err == nil {
+		nonce, err = randomString()
+	}
+	if err != nil {
+		log.Println("OIDC:", err)
+		http.Error(w, "can't start a login", http.StatusInternalServerError)
+		return
+	}
+	o.Lock()
+	for s, p := range o.pending {
+		if time.Now().After(p.expires) {
+			delete(o.pending, s)
+		}
+	}
+	o.pending[state] = oidcPending{nonce: nonce, expires: time.Now().Add(10 * time.Minute)}
+	o.Unlock()
+
+	c := o.rt.conf.Auth.Oidc
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", c.ClientID)
+	q.Set("redirect_uri", c.RedirectURL)
+	q.Set("scope", strings.Join(c.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+
+	sep := "?"
+	if strings.Contains(o.authEndpoint, "?") {
+		sep = "&"
+	}
+	http.Redirect(w, r, o.authEndpoint+sep+q.Encode(), http.StatusFound)
+}
+
+// CallbackHandler exchanges the code, validates the ID token and starts the session
+func (o *oidcProvider) CallbackHandler(w http.ResponseWriter, r *http.Request) {
+	remoteIP := helpers.GetIP(r.RemoteAddr, r.Header.Get("X-Real-IP"), r.Header.Get("X-Forwarded-For"))
+	q := r.URL.Query()
+
+	o.Lock()
+	p, ok := o.pending[q.Get("state")]
+	delete(o.pending, q.Get("state"))
+	o.Unlock()
+
+	if e := q.Get("error"); e != "" || !ok || time.Now().After(p.expires) {
+		log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", http.StatusUnauthorized, "\t", "wrong state or provider error:", e, q.Get("error_description"))
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := o.exchange(q.Get("code"), p.nonce)
+	if err != nil {
+		log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", http.StatusUnauthorized, "\t", err.Error())
+		o.rt.record(event{Action: "login_failed", Details: err.Error()})
+		http.Error(w, "Login failed", http.StatusUnauthorized)
+		return
+	}
+
+	if err := o.rt.startSession(w, r, id); err != nil {
+		log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", http.StatusInternalServerError, "\t", id.Name, "\t", err.Error())
+		http.Error(w, "can't start a session", http.StatusInternalServerError)
+		return
+	}
+	log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", 200, "\t", id.Name, "\t", r.UserAgent())
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+func (o *oidcProvider) exchange(code, nonce string) (*identity, error) {
+	c := o.rt.conf.Auth.Oidc
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.RedirectURL)
+
+	req, _ := http.NewRequest("POST", o.tokenEndpoint, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(c.ClientID), url.QueryEscape(c.ClientSecret))
+	resp, err := o.hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != 200 {
+		return nil, errors.New("token endpoint: " + resp.Status + " " + string(body))
+	}
+
+	var tok struct {
+		IDToken string `json:"id_token"`
+	}
+	err = json.Unmarshal(body, &tok)
+	if err != nil {
+		return nil, err
+	}
+	if tok.IDToken == "" {
+		return nil, errors.New("token endpoint returned no id_token")
+	}
+
+	claims, err := o.verify(tok.IDToken)
+	if err != nil {
+		return nil, err
+	}
+	if n, _ := claims["nonce"].(string); n != nonce {
+		return nil, errors.New("id_token nonce mismatch")
+	}
+
+	id := &identity{}
+	id.Name, _ = claims[c.UsernameClaim].(string)
+	if id.Name == "" {
+		id.Name, _ = claims["sub"].(string)
+	}
+	id.Roles = claimStrings(claims, c.RolesClaim)
+	return id, nil
+}
+
+// claimStrings reads a string or a list of strings by a dotted path, e.g. realm_access.roles
+func claimStrings(claims map[string]interface{}, path string) []string {
+	var v interface{} = claims
+	for _, p := range strings.Split(path, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = m[p]
+	}
+
+	switch val := v.(type) {
+	case string:
+		return []string{val}
+	case []interface{}:
+		var res []string
+		for _, i := range val {
+			if s, ok := i.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+// verify checks the signature and standard claims of the ID token
+func (o *oidcProvider) verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed id_token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	err := decodeSegment(parts[0], &header)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, err
+	}
+	key, err := o.key(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	err = verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), sig)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	err = decodeSegment(parts[1], &claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if iss, _ := claims["iss"].(string); iss != o.issuer {
+		return nil, errors.New("id_token issuer mismatch: " + iss)
+	}
+	if !audienceContains(claims["aud"], o.rt.conf.Auth.Oidc.ClientID) {
+		return nil, errors.New("id_token audience mismatch")
+	}
+	exp, _ := claims["exp"].(float64)
+	if time.Now().After(time.Unix(int64(exp), 0).Add(time.Minute)) {
+		return nil, errors.New("id_token expired")
+	}
+	return claims, nil
+}
+
+func decodeSegment(seg string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func audienceContains(aud interface{}, clientID string) bool {
+	switch a := aud.(type) {
+	case string:
+		return a == clientID
+	case []interface{}:
+		for _, i := range a {
+			if s, _ := i.(string); s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// signingHashes - accepted id_token algorithms, none and HMAC are refused
+var signingHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"PS256": crypto.SHA256, "PS384": crypto.SHA384, "PS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+}
+
+func verifySignature(alg string, key crypto.PublicKey, signed, sig []byte) error {
+	hash, ok := signingHashes[alg]
+	if !ok {
+		return fmt.Errorf("unsupported id_token algorithm %q", alg)
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if strings.HasPrefix(alg, "RS") {
+			return rsa.VerifyPKCS1v15(k, hash, digest, sig)
+		}
+		if strings.HasPrefix(alg, "PS") {
+			return rsa.VerifyPSS(k, hash, digest, sig, nil)
+		}
+	case *ecdsa.PublicKey:
+		if strings.HasPrefix(alg, "ES") && len(sig)%2 == 0 {
+			r := new(big.Int).SetBytes(sig[:len(sig)/2])
+			s := new(big.Int).SetBytes(sig[len(sig)/2:])
+			if ecdsa.Verify(k, digest, r, s) {
+				return nil
+			}
+			return errors.New("id_token signature is invalid")
+		}
+	}
+	return fmt.Errorf("id_token algorithm %s does not match the key", alg)
+}
+
+// key returns the signing key by kid, JWKS is reloaded when the kid is unknown
+func (o *oidcProvider) key(kid string) (crypto.PublicKey, error) {
+	o.Lock()
+	defer o.Unlock()
+	if k, ok := o.keys[kid]; ok {
+		return k, nil
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	err := o.getJSON(o.jwksURI, &set)
+	if err != nil {
+		return nil, err
+	}
+	o.keys = make(map[string]crypto.PublicKey)
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		pk, err := k.publicKey()
+		if err != nil {
+			log.Println("OIDC: key", k.Kid, ":", err)
+			continue
+		}
+		o.keys[k.Kid] = pk
+	}
+
+	if k, ok := o.keys[kid]; ok {
+		return k, nil
+	}
+	return nil, errors.New("unknown id_token key " + kid)
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New("unsupported curve " + k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, errors.New("unsupported key type " + k.Kty)
+}
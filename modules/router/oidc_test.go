@@ -0,0 +1,301 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeIssuer - OpenID Connect provider answering discovery, JWKS and the token
+// endpoint; the ID token is built by token from the nonce of the login
+type fakeIssuer struct {
+	sync.Mutex
+	srv   *httptest.Server
+	key   *rsa.PrivateKey
+	nonce string
+	token func(nonce string) string
+}
+
+func newFakeIssuer(t *testing.T) *fakeIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 f.srv.URL,
+			"authorization_endpoint": f.srv.URL + "/auth",
+			"token_endpoint":         f.srv.URL + "/token",
+			"jwks_uri":               f.srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kid": "k1", "kty": "RSA", "use": "sig",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, _ := r.BasicAuth(); id != "extractor" || secret != "secret" || r.PostFormValue("code") != "code" {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+		f.Lock()
+		nonce := f.nonce
+		f.Unlock()
+		json.NewEncoder(w).Encode(map[string]string{"id_token": f.token(nonce)})
+	})
+	f.srv = httptest.NewTLSServer(mux)
+	t.Cleanup(f.srv.Close)
+	return f
+}
+
+// caFile writes the certificate of the issuer for auth.oidc.ca_file
+func (f *fakeIssuer) caFile(t *testing.T) string {
+	file, err := ioutil.TempFile("", "issuer-*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pem.Encode(file, &pem.Block{Type: "CERTIFICATE", Bytes: f.srv.Certificate().Raw})
+	file.Close()
+	t.Cleanup(func() { os.Remove(file.Name()) })
+	return file.Name()
+}
+
+// sign builds a token with the header and claims signed by the issuer key,
+// algorithms other than RS256 get a garbage signature
+func (f *fakeIssuer) sign(header, claims map[string]interface{}) string {
+	h, _ := json.Marshal(header)
+	c, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	sig := []byte("garbage")
+	if header["alg"] == "RS256" {
+		digest := crypto.SHA256.New()
+		digest.Write([]byte(signed))
+		sig, _ = rsa.SignPKCS1v15(rand.Reader, f.key, crypto.SHA256, digest.Sum(nil))
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func (f *fakeIssuer) claims(nonce string) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":                f.srv.URL,
+		"aud":                "extractor",
+		"sub":                "0001",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"nonce":              nonce,
+		"preferred_username": "alice",
+		"realm_access":       map[string]interface{}{"roles": []string{"viewer", "operator"}},
+	}
+}
+
+func testOidcRouter(t *testing.T, f *fakeIssuer, caFile string) *Router {
+	t.Helper()
+	rt := testRouter(t, `
+auth:
+  type: oidc
+  oidc:
+    issuer: `+f.srv.URL+`
+    client_id: extractor
+    client_secret: secret
+    redirect_url: https://extractor.example.com/oidc/callback
+    roles_claim: realm_access.roles
+    ca_file: "`+caFile+`"
+`)
+	var err error
+	rt.auth, err = newAuth(rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rt
+}
+
+// oidcLogin goes through /oidc/login and /oidc/callback like a browser
+func oidcLogin(t *testing.T, rt *Router, f *fakeIssuer) *httptest.ResponseRecorder {
+	t.Helper()
+	w := httptest.NewRecorder()
+	rt.auth.oidc.LoginHandler(w, httptest.NewRequest(http.MethodGet, "/oidc/login", nil))
+	if w.Code != http.StatusFound {
+		t.Fatalf("login: status %d %s", w.Code, w.Body)
+	}
+	loc, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Lock()
+	f.nonce = loc.Query().Get("nonce")
+	f.Unlock()
+
+	w = httptest.NewRecorder()
+	rt.auth.oidc.CallbackHandler(w, httptest.NewRequest(http.MethodGet, "/oidc/callback?code=code&state="+url.QueryEscape(loc.Query().Get("state")), nil))
+	return w
+}
+
+func TestOidcLogin(t *testing.T) {
+	f := newFakeIssuer(t)
+	f.token = func(nonce string) string {
+		return f.sign(map[string]interface{}{"alg": "RS256", "kid": "k1"}, f.claims(nonce))
+	}
+	rt := testOidcRouter(t, f, f.caFile(t))
+
+	w := oidcLogin(t, rt, f)
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/" {
+		t.Fatalf("callback: status %d %s", w.Code, w.Body)
+	}
+	var id *identity
+	for _, c := range w.Result().Cookies() {
+		if c.Name == sessionCookie {
+			id = rt.auth.session(c.Value)
+		}
+	}
+	if id == nil || id.Name != "alice" || !reflect.DeepEqual(id.Roles, []string{"viewer", "operator"}) {
+		t.Fatalf("session identity %+v, want alice with viewer and operator", id)
+	}
+}
+
+func TestLogoutRequiresPost(t *testing.T) {
+	f := newFakeIssuer(t)
+	f.token = func(nonce string) string {
+		return f.sign(map[string]interface{}{"alg": "RS256", "kid": "k1"}, f.claims(nonce))
+	}
+	rt := testOidcRouter(t, f, f.caFile(t))
+	var cookie *http.Cookie
+	for _, c := range oidcLogin(t, rt, f).Result().Cookies() {
+		if c.Name == sessionCookie {
+			cookie = c
+		}
+	}
+	if cookie == nil {
+		t.Fatal("no session cookie")
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/logout", nil)
+	r.AddCookie(cookie)
+	rt.LogoutHandler(w, r)
+	if w.Code != http.StatusMethodNotAllowed || rt.auth.session(cookie.Value) == nil {
+		t.Fatalf("GET /logout: status %d, the session must stay", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodPost, "/logout", nil)
+	r.AddCookie(cookie)
+	rt.LogoutHandler(w, r)
+	if w.Code != http.StatusFound || rt.auth.session(cookie.Value) != nil {
+		t.Fatalf("POST /logout: status %d, the session must be dropped", w.Code)
+	}
+}
+
+func TestOidcRejectsTokens(t *testing.T) {
+	f := newFakeIssuer(t)
+	rt := testOidcRouter(t, f, f.caFile(t))
+	rs256 := map[string]interface{}{"alg": "RS256", "kid": "k1"}
+
+	tests := map[string]func(nonce string) string{
+		"wrong audience": func(nonce string) string {
+			c := f.claims(nonce)
+			c["aud"] = "other"
+			return f.sign(rs256, c)
+		},
+		"wrong issuer": func(nonce string) string {
+			c := f.claims(nonce)
+			c["iss"] = "https://evil.example.com"
+			return f.sign(rs256, c)
+		},
+		"expired": func(nonce string) string {
+			c := f.claims(nonce)
+			c["exp"] = time.Now().Add(-time.Hour).Unix()
+			return f.sign(rs256, c)
+		},
+		"wrong nonce": func(nonce string) string {
+			return f.sign(rs256, f.claims("other"))
+		},
+		"tampered claims": func(nonce string) string {
+			// подпись настоящего токена с чужими claims
+			tok := strings.Split(f.sign(rs256, f.claims(nonce)), ".")
+			c := f.claims(nonce)
+			c["preferred_username"] = "admin"
+			forged := strings.Split(f.sign(rs256, c), ".")
+			return forged[0] + "." + forged[1] + "." + tok[2]
+		},
+		"alg none": func(nonce string) string {
+			return f.sign(map[string]interface{}{"alg": "none", "kid": "k1"}, f.claims(nonce))
+		},
+		"alg HS256": func(nonce string) string {
+			return f.sign(map[string]interface{}{"alg": "HS256", "kid": "k1"}, f.claims(nonce))
+		},
+		"short alg": func(nonce string) string {
+			return f.sign(map[string]interface{}{"alg": "X", "kid": "k1"}, f.claims(nonce))
+		},
+		"empty alg": func(nonce string) string { return f.sign(map[string]interface{}{"kid": "k1"}, f.claims(nonce)) },
+		"unknown key": func(nonce string) string {
+			return f.sign(map[string]interface{}{"alg": "RS256", "kid": "k2"}, f.claims(nonce))
+		},
+		"not a token": func(nonce string) string { return "garbage" },
+		"bad signature": func(nonce string) string {
+			return f.sign(map[string]interface{}{"alg": "PS256", "kid": "k1"}, f.claims(nonce))
+		},
+	}
+	for name, token := range tests {
+		f.token = token
+		w := oidcLogin(t, rt, f)
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status %d, want 401", name, w.Code)
+		}
+		for _, c := range w.Result().Cookies() {
+			if c.Name == sessionCookie {
+				t.Errorf("%s: session cookie issued", name)
+			}
+		}
+	}
+}
+
+func TestOidcVerifiesTLS(t *testing.T) {
+	f := newFakeIssuer(t)
+	// без ca_file самоподписанный сертификат провайдера не принимается
+	rt := testOidcRouter(t, f, "")
+	w := httptest.NewRecorder()
+	rt.auth.oidc.LoginHandler(w, httptest.NewRequest(http.MethodGet, "/oidc/login", nil))
+	if w.Code != http.StatusBadGateway {
+		t.Fatalf("untrusted provider: status %d, want 502", w.Code)
+	}
+}
+
+func TestVerifySignatureAlgorithms(t *testing.T) {
+	f := newFakeIssuer(t)
+	for _, alg := range []string{"", "X", "none", "HS256", "RS1", "rs256"} {
+		if err := verifySignature(alg, &f.key.PublicKey, []byte("a.b"), []byte("sig")); err == nil {
+			t.Errorf("algorithm %q accepted", alg)
+		}
+	}
+}
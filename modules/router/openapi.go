@@ -0,0 +1,376 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uzhinskiy/extractor/modules/version"
+)
+
+type schema map[string]interface{}
+
+// actionDoc describes an action for the OpenAPI document
+type actionDoc struct {
+	Summary  string
+	Values   []string // поля apiRequest.Values, которые читает действие
+	Response schema
+	Status   int  // код ответа /api/v1, 200 если не задан
+	Partial  bool // тот же ответ с 207, если выполнено не всё, и с 409, если ничего
+}
+
+func ref(name string) schema {
+	return schema{"$ref": "#/components/schemas/" + name}
+}
+
+func arrayOf(s schema) schema {
+	return schema{"type": "array", "items": s}
+}
+
+func mapOf(s schema) schema {
+	return schema{"type": "object", "additionalProperties": s}
+}
+
+func stringProps(names ...string) schema {
+	props := schema{}
+	for _, n := range names {
+		props[n] = schema{"type": "string"}
+	}
+	return schema{"type": "object", "properties": props}
+}
+
+// ответы, которые отдаются из Elasticsearch как есть, описаны вручную,
+// остальные схемы строятся из типов обработчиков
+var esSchemas = map[string]schema{
+	"Repository": stringProps("id", "type"),
+	"SnapshotStatus": {
+		"type": "object",
+		"properties": schema{
+			"snapshots": arrayOf(schema{
+				"type": "object",
+				"properties": schema{
+					"snapshot":   schema{"type": "string"},
+					"repository": schema{"type": "string"},
+					"state":      schema{"type": "string"},
+					"indices": mapOf(schema{
+						"type": "object",
+						"properties": schema{
+							"shards_stats": schema{"type": "object", "properties": schema{"total": schema{"type": "integer"}}},
+							"stats":        ref("SizeStats"),
+							"shards":       mapOf(schema{"type": "object", "properties": schema{"stats": ref("SizeStats")}}),
+						},
+					}),
+				},
+			}),
+		},
+	},
+	"SizeStats": {
+		"type": "object",
+		"properties": schema{
+			"total": schema{"type": "object", "properties": schema{"size_in_bytes": schema{"type": "integer"}}},
+		},
+	},
+	"Recovery": mapOf(schema{
+		"type":       "object",
+		"properties": schema{"shards": arrayOf(ref("ShardRecovery"))},
+	}),
+	"Error": {
+		"type":     "object",
+		"required": []string{"error", "status"},
+		"properties": schema{
+			"error":  schema{"type": "string"},
+			"status": schema{"type": "integer"},
+		},
+	},
+}
+
+var typeSchemas = map[string]interface{}{
+	"Node":             singleNode{},
+	"ShardRecovery":    shardRecovery{},
+	"RestoreResult":    restoreResult{},
+	"Job":              jobProgress{},
+	"Event":            event{},
+	"Identity":         identity{},
+	"Retention":        retentionInfo{},
+	"Values":           apiRequest{}.Values,
+	"RestoreLimits":    restoreLimits{},
+	"RestorePlan":      restorePlan{},
+	"Schedule":         schedule{},
+	"SnapshotProgress": snapshotProgress{},
+	"SnapshotPage":     snapshotPage{},
+	"IndexSearch":      indexSearch{},
+	"CatalogStats":     catalogStats{},
+	"RepositoryInfo":   repositoryInfo{},
+	"RepositoryCheck":  repoCheck{},
+	"ScheduleInfo":     scheduleInfo{},
+}
+
+var actionDocs = map[string]actionDoc{
+	"get_repositories":       {Summary: "Snapshot repositories visible to the user", Response: arrayOf(ref("Repository"))},
+	"get_repository":         {Summary: "Type and settings of a repository", Values: []string{"repo"}, Response: ref("RepositoryInfo")},
+	"create_repository":      {Summary: "Register a fs, url, s3, gcs or azure repository or change its settings", Values: []string{"repo", "type", "settings"}, Response: ref("RepositoryInfo")},
+	"verify_repository":      {Summary: "Start a background check that every node can access the repository", Values: []string{"repo"}, Response: ref("RepositoryCheck"), Status: http.StatusAccepted},
+	"analyze_repository":     {Summary: "Start a background verify, cleanup of unreferenced data and analysis of the repository", Values: []string{"repo"}, Response: ref("RepositoryCheck"), Status: http.StatusAccepted},
+	"list_repository_checks": {Summary: "Last checks of a repository, newest first", Values: []string{"repo"}, Response: arrayOf(ref("RepositoryCheck"))},
+	"get_repository_check":   {Summary: "A check of a repository and the answers of its steps", Values: []string{"repo", "check_id"}, Response: ref("RepositoryCheck")},
+	"delete_repository":      {Summary: "Unregister a repository, its snapshots stay in the storage", Values: []string{"repo"}, Response: schema{"type": "object"}},
+	"get_snapshots":          {Summary: "A page of snapshots of a repository filtered by name, state, start date and contained index", Values: []string{"repo", "snapshot_pattern", "state", "ipattern", "from", "to", "sort", "order", "limit", "after"}, Response: ref("SnapshotPage")},
+	"find_index":             {Summary: "Snapshots of the visible repositories containing indices matching a name or pattern, newest first", Values: []string{"ipattern", "repos", "limit"}, Response: ref("IndexSearch")},
+	"get_catalog":            {Summary: "Size, hit and miss counters and the last refresh of the snapshot catalog, sizes count visible repositories", Response: ref("CatalogStats")},
+	"refresh_catalog":        {Summary: "Refresh the snapshot catalog now, all repositories or one", Values: []string{"repo"}, Response: ref("CatalogStats")},
+	"get_snapshot":           {Summary: "Status of a snapshot with its indices", Values: []string{"repo", "snapshot"}, Response: ref("SnapshotStatus")},
+	"create_snapshot":        {Summary: "Snapshot open indices matching the patterns, the name may use {repo}, {user} and {date}", Values: []string{"repo", "snapshot", "indices", "include_global_state", "reason"}, Response: ref("SnapshotProgress")},
+	"delete_snapshot":        {Summary: "Delete a snapshot", Values: []string{"repo", "snapshot"}, Response: schema{"type": "object"}},
+	"restore":                {Summary: "Restore indices of a snapshot selected by names, globs, -exclusions and a from/to date range", Values: []string{"repo", "snapshot", "indices", "from", "to", "options", "priority"}, Response: ref("RestoreResult"), Status: http.StatusAccepted, Partial: true},
+	"preview_restore":        {Summary: "What a restore would do: capacity, restored names, conflicts and the expected time", Values: []string{"repo", "snapshot", "indices", "from", "to", "options"}, Response: ref("RestorePlan")},
+	"get_indices":            {Summary: "Recovery of restored indices", Values: []string{"ipattern"}, Response: ref("Recovery")},
+	"del_index":              {Summary: "Delete an index", Values: []string{"index"}, Response: schema{"type": "object"}},
+	"get_nodes":              {Summary: "Data nodes and their disk usage", Response: arrayOf(ref("Node"))},
+	"get_retention":          {Summary: "Restored indices and their expiry, indices with an unknown restore time are kept", Response: arrayOf(ref("Retention"))},
+	"pin_index":              {Summary: "Keep a restored index until unpinned", Values: []string{"index"}, Response: ref("Retention")},
+	"unpin_index":            {Summary: "Return a pinned index to the retention", Values: []string{"index"}, Response: ref("Retention")},
+	"extend_index":           {Summary: "Prolong the retention of a restored index", Values: []string{"index", "hours"}, Response: ref("Retention")},
+	"get_restore_options":    {Summary: "Restore options allowed by the admin", Response: ref("RestoreLimits")},
+	"get_user":               {Summary: "Current user and roles", Response: ref("Identity")},
+	"get_job":                {Summary: "Progress of a restore job", Values: []string{"job_id"}, Response: ref("Job")},
+	"list_jobs":              {Summary: "Restore jobs of visible repositories and indices", Response: arrayOf(ref("Job"))},
+	"cancel_job":             {Summary: "Remove a queued job from the queue or delete partially restored indices of a running one", Values: []string{"job_id"}, Response: ref("Job")},
+	"list_schedules":         {Summary: "Scheduled restores of visible repositories sorted by name", Response: arrayOf(ref("Schedule"))},
+	"get_schedule":           {Summary: "A scheduled restore with its last runs", Values: []string{"schedule_id"}, Response: ref("ScheduleInfo")},
+	"create_schedule":        {Summary: "Restore indices by a cron expression from a snapshot or the latest snapshot matching snapshot_pattern", Values: []string{"name", "cron", "repo", "snapshot", "snapshot_pattern", "indices", "options", "priority", "enabled"}, Response: ref("Schedule")},
+	"update_schedule":        {Summary: "Change given fields of a scheduled restore, enabled pauses and resumes it, the editor becomes the owner", Values: []string{"schedule_id", "name", "cron", "repo", "snapshot", "snapshot_pattern", "indices", "options", "priority", "enabled"}, Response: ref("Schedule")},
+	"delete_schedule":        {Summary: "Delete a scheduled restore", Values: []string{"schedule_id"}, Response: ref("Schedule")},
+	"run_schedule":           {Summary: "Run a scheduled restore now", Values: []string{"schedule_id"}, Response: ref("RestoreResult"), Status: http.StatusAccepted, Partial: true},
+	"get_history":            {Summary: "Audit history of visible repositories and indices, newest first", Values: []string{"limit"}, Response: arrayOf(ref("Event"))},
+}
+
+// schemaOf describes a Go type by its json tags
+func schemaOf(t reflect.Type) schema {
+	if t == reflect.TypeOf(time.Time{}) {
+		return schema{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaOf(t.Elem())
+	case reflect.String:
+		return schema{"type": "string"}
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return schema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return arrayOf(schemaOf(t.Elem()))
+	case reflect.Map:
+		return mapOf(schemaOf(t.Elem()))
+	case reflect.Struct:
+		props := schema{}
+		var required []string
+		fieldsOf(t, props, &required)
+		s := schema{"type": "object", "properties": props}
+		if len(required) > 0 {
+			sort.Strings(required)
+			s["required"] = required
+		}
+		return s
+	}
+	return schema{}
+}
+
+func fieldsOf(t reflect.Type, props schema, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			fieldsOf(f.Type, props, required)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		tag := strings.Split(f.Tag.Get("json"), ",")
+		name := tag[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		props[name] = schemaOf(f.Type)
+		omit := false
+		for _, o := range tag[1:] {
+			omit = omit || o == "omitempty"
+		}
+		if !omit && f.Type.Kind() != reflect.Ptr {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// valueFields - json names of apiRequest.Values
+func valueFields() map[string]bool {
+	res := make(map[string]bool)
+	props := schemaOf(reflect.TypeOf(apiRequest{}.Values))["properties"].(schema)
+	for n := range props {
+		res[n] = true
+	}
+	return res
+}
+
+// openAPI builds the OpenAPI 3 document of the legacy and /api/v1 endpoints
+func openAPI() schema {
+	schemas := schema{}
+	for n, s := range esSchemas {
+		schemas[n] = s
+	}
+	for n, v := range typeSchemas {
+		schemas[n] = schemaOf(reflect.TypeOf(v))
+	}
+
+	errorResponse := schema{
+		"description": "Error",
+		"content":     schema{"application/json": schema{"schema": ref("Error")}},
+	}
+	jsonResponse := func(description string, s schema) schema {
+		return schema{"description": description, "content": schema{"application/json": schema{"schema": s}}}
+	}
+
+	var actions []string
+	for a := range actionDocs {
+		actions = append(actions, a)
+	}
+	sort.Strings(actions)
+
+	var legacy []string
+	for _, a := range actions {
+		d := actionDocs[a]
+		line := a + " - " + d.Summary
+		if len(d.Values) > 0 {
+			line += " (values: " + strings.Join(d.Values, ", ") + ")"
+		}
+		legacy = append(legacy, line)
+	}
+
+	paths := schema{
+		"/api/": schema{
+			"post": schema{
+				"summary":     "Action endpoint used by the UI",
+				"description": "Actions:\n\n- " + strings.Join(legacy, "\n- ") + "\n\nThe response of an action is the same as of its /api/v1 route, restore answers with RestoreResult.",
+				"operationId": "action",
+				"requestBody": schema{
+					"required": true,
+					"content": schema{"application/json": schema{"schema": schema{
+						"type":     "object",
+						"required": []string{"action"},
+						"properties": schema{
+							"action": schema{"type": "string", "enum": actions},
+							"values": ref("Values"),
+						},
+					}}},
+				},
+				"responses": schema{
+					"200":     jsonResponse("Result of the action", schema{}),
+					"default": errorResponse,
+				},
+			},
+		},
+	}
+
+	for _, r := range routes {
+		d := actionDocs[r.action]
+		p := "/api/v1/" + r.path
+		op := schema{
+			"summary":     d.Summary,
+			"operationId": r.action,
+			"responses":   schema{"default": errorResponse},
+		}
+		status := d.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		op["responses"].(schema)[strconv.Itoa(status)] = jsonResponse(http.StatusText(status), d.Response)
+		if d.Partial {
+			for _, s := range []int{http.StatusMultiStatus, http.StatusConflict} {
+				op["responses"].(schema)[strconv.Itoa(s)] = jsonResponse(http.StatusText(s), d.Response)
+			}
+		}
+
+		var params []schema
+		for _, seg := range strings.Split(r.path, "/") {
+			if strings.HasPrefix(seg, "{") {
+				params = append(params, schema{"name": strings.Trim(seg, "{}"), "in": "path", "required": true, "schema": schema{"type": "string"}})
+			}
+		}
+		for _, v := range d.Values {
+			switch v {
+			case "ipattern":
+				params = append(params, schema{"name": "pattern", "in": "query", "schema": schema{"type": "string"}})
+			case "limit":
+				params = append(params, schema{"name": "limit", "in": "query", "schema": schema{"type": "integer"}})
+			case "repos":
+				if r.method == http.MethodGet {
+					params = append(params, schema{"name": "repos", "in": "query", "description": "comma separated", "schema": schema{"type": "string"}})
+				}
+			case "snapshot_pattern", "state", "from", "to", "sort", "order", "after":
+				if r.method != http.MethodGet {
+					continue
+				}
+				name := v
+				if v == "snapshot_pattern" {
+					name = "name"
+				}
+				params = append(params, schema{"name": name, "in": "query", "schema": schema{"type": "string"}})
+			}
+		}
+		if len(params) > 0 {
+			op["parameters"] = params
+		}
+		if r.method == http.MethodPost {
+			op["requestBody"] = schema{"content": schema{"application/json": schema{"schema": ref("Values")}}}
+		}
+
+		item, ok := paths[p].(schema)
+		if !ok {
+			item = schema{}
+			paths[p] = item
+		}
+		item[strings.ToLower(r.method)] = op
+	}
+
+	return schema{
+		"openapi": "3.0.3",
+		"info": schema{
+			"title":   "extractor",
+			"version": version.Version,
+		},
+		"paths":      paths,
+		"components": schema{"schemas": schemas},
+	}
+}
+
+// OpenAPIHandler serves /api/openapi.json
+func (rt *Router) OpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	rt.cors(w, "GET")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Server", version.Version)
+	j, _ := json.MarshalIndent(openAPI(), "", "  ")
+	w.Write(j)
+}
@@ -0,0 +1,110 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// dispatchActions - actions of the switch in dispatch, read from r.go
+func dispatchActions(t *testing.T) map[string]bool {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "r.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := make(map[string]bool)
+	for _, d := range f.Decls {
+		fn, ok := d.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "dispatch" {
+			continue
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			sw, ok := n.(*ast.SwitchStmt)
+			if !ok {
+				return true
+			}
+			// только switch request.Action
+			sel, ok := sw.Tag.(*ast.SelectorExpr)
+			if !ok || sel.Sel.Name != "Action" {
+				return true
+			}
+			for _, s := range sw.Body.List {
+				for _, e := range s.(*ast.CaseClause).List {
+					if lit, ok := e.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						name, _ := strconv.Unquote(lit.Value)
+						res[name] = true
+					}
+				}
+			}
+			return true
+		})
+	}
+	if len(res) == 0 {
+		t.Fatal("no actions found in dispatch")
+	}
+	return res
+}
+
+func TestActionDocs(t *testing.T) {
+	actions := dispatchActions(t)
+	for a := range actions {
+		if _, ok := actionDocs[a]; !ok {
+			t.Errorf("action %s is not described in actionDocs", a)
+		}
+	}
+	for a := range actionDocs {
+		if !actions[a] {
+			t.Errorf("actionDocs describes %s, dispatch has no such action", a)
+		}
+	}
+}
+
+func TestRoutesDocumented(t *testing.T) {
+	for _, r := range routes {
+		if _, ok := actionDocs[r.action]; !ok {
+			t.Errorf("route %s %s has no description of %s", r.method, r.path, r.action)
+		}
+	}
+}
+
+func TestActionDocValues(t *testing.T) {
+	fields := valueFields()
+	for name, d := range actionDocs {
+		for _, v := range d.Values {
+			if !fields[v] {
+				t.Errorf("action %s documents unknown value %s", name, v)
+			}
+		}
+	}
+}
+
+func TestOpenAPIPaths(t *testing.T) {
+	paths := openAPI()["paths"].(schema)
+	for _, r := range routes {
+		p, ok := paths["/api/v1/"+r.path].(schema)
+		if !ok {
+			t.Errorf("no path /api/v1/%s in the document", r.path)
+			continue
+		}
+		if _, ok := p[strings.ToLower(r.method)]; !ok {
+			t.Errorf("no %s of /api/v1/%s in the document", r.method, r.path)
+		}
+	}
+}
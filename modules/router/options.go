@@ -0,0 +1,163 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"strings"
+)
+
+// restoreOptions - what a user may change in the _restore request;
+// flags are pointers so that an edit of a schedule can set them back to false
+type restoreOptions struct {
+	Replicas            *int                   `json:"replicas,omitempty"`
+	IncludeAliases      *bool                  `json:"include_aliases,omitempty"`
+	IndexSettings       map[string]interface{} `json:"index_settings,omitempty"`
+	IgnoreIndexSettings []string               `json:"ignore_index_settings,omitempty"`
+	Partial             *bool                  `json:"partial,omitempty"`
+	FeatureStates       []string               `json:"feature_states,omitempty"`
+	Conflict            string                 `json:"conflict,omitempty"`
+	ConfirmReplace      *bool                  `json:"confirm_replace,omitempty"`
+}
+
+// restoreLimits - options allowed by the admin, answered by get_restore_options
+type restoreLimits struct {
+	Replicas       int      `json:"replicas"`
+	MaxReplicas    int      `json:"max_replicas"`
+	AllowAliases   bool     `json:"allow_aliases"`
+	AllowPartial   bool     `json:"allow_partial"`
+	Settings       []string `json:"settings"`
+	IgnoreSettings []string `json:"ignore_settings"`
+	FeatureStates  []string `json:"feature_states"`
+	Conflict       string   `json:"conflict"`
+}
+
+func (rt *Router) restoreLimits() restoreLimits {
+	c := rt.conf.Restore
+	// default не может быть больше max
+	if c.Replicas.Max < c.Replicas.Default {
+		c.Replicas.Max = c.Replicas.Default
+	}
+	return restoreLimits{
+		Replicas:       c.Replicas.Default,
+		MaxReplicas:    c.Replicas.Max,
+		AllowAliases:   c.AllowAliases,
+		AllowPartial:   c.AllowPartial,
+		Settings:       append([]string{}, c.Settings...),
+		IgnoreSettings: append([]string{}, c.IgnoreSettings...),
+		FeatureStates:  append([]string{}, c.FeatureStates...),
+		Conflict:       c.Conflict,
+	}
+}
+
+// isTrue reports whether an optional flag is given and set
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
+
+func settingName(s string) string {
+	if strings.HasPrefix(s, "index.") {
+		return s
+	}
+	return "index." + s
+}
+
+// checkOptions validates options against the limits and returns the number of replicas
+func (rt *Router) checkOptions(o *restoreOptions) (int, error) {
+	l := rt.restoreLimits()
+	replicas := l.Replicas
+	if o.Replicas != nil {
+		replicas = *o.Replicas
+	}
+	if replicas < 0 || replicas > l.MaxReplicas {
+		return 0, badRequest("replicas must be between 0 and %d", l.MaxReplicas)
+	}
+	if isTrue(o.IncludeAliases) && !l.AllowAliases {
+		return 0, badRequest("include_aliases is not allowed")
+	}
+	if isTrue(o.Partial) && !l.AllowPartial {
+		return 0, badRequest("partial restore is not allowed")
+	}
+
+	allocation := rt.allocationSettings()
+	settings := make(map[string]interface{})
+	for k, v := range o.IndexSettings {
+		name := settingName(k)
+		if name == "index.number_of_replicas" {
+			return 0, badRequest("use the replicas option instead of %s", k)
+		}
+		if _, ok := allocation[name]; ok {
+			return 0, badRequest("%s is set by the allocation config", name)
+		}
+		if len(l.Settings) == 0 || !matchAny(l.Settings, name) {
+			return 0, badRequest("index setting %s may not be changed", name)
+		}
+		settings[name] = v
+	}
+	o.IndexSettings = settings
+
+	for i, k := range o.IgnoreIndexSettings {
+		name := settingName(k)
+		if len(l.IgnoreSettings) == 0 || !matchAny(l.IgnoreSettings, name) {
+			return 0, badRequest("index setting %s may not be ignored", name)
+		}
+		o.IgnoreIndexSettings[i] = name
+	}
+
+	for _, f := range o.FeatureStates {
+		allowed := false
+		for _, a := range l.FeatureStates {
+			allowed = allowed || a == f
+		}
+		if !allowed {
+			return 0, badRequest("feature state %s is not allowed", f)
+		}
+	}
+	if o.Conflict == "" {
+		o.Conflict = l.Conflict
+	}
+	if !conflictStrategies[o.Conflict] {
+		return 0, badRequest("conflict must be one of fail, suffix, skip, replace")
+	}
+	return replicas, nil
+}
+
+// restoreBody builds the _restore request for checked options
+func (rt *Router) restoreBody(o restoreOptions, replicas int, indices []string, rv renameVars) map[string]interface{} {
+	settings := make(map[string]interface{})
+	for k, v := range o.IndexSettings {
+		settings[k] = v
+	}
+	for k, v := range rt.allocationSettings() {
+		settings[k] = v
+	}
+	settings["index.number_of_replicas"] = replicas
+
+	req := map[string]interface{}{
+		"ignore_unavailable":   false,
+		"include_global_state": false,
+		"include_aliases":      isTrue(o.IncludeAliases),
+		"partial":              isTrue(o.Partial),
+		"rename_pattern":       "(.+)",
+		"rename_replacement":   rt.renameReplacement(rv),
+		"indices":              indices,
+		"index_settings":       settings,
+	}
+	if len(o.IgnoreIndexSettings) > 0 {
+		req["ignore_index_settings"] = o.IgnoreIndexSettings
+	}
+	if len(o.FeatureStates) > 0 {
+		req["feature_states"] = o.FeatureStates
+	}
+	return req
+}
@@ -0,0 +1,156 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const optionsConfig = `
+allocation:
+  tiers: [data_warm, data_hot]
+  attributes:
+    box_type: warm
+restore:
+  replicas:
+    default: 1
+    max: 2
+  allow_aliases: true
+  settings: ["index.refresh_interval", "index.blocks.*"]
+  ignore_settings: ["index.lifecycle.*"]
+  feature_states: [security]
+  conflict: suffix
+`
+
+func intp(i int) *int {
+	return &i
+}
+
+func boolp(b bool) *bool {
+	return &b
+}
+
+func TestCheckOptions(t *testing.T) {
+	rt := testRouter(t, optionsConfig)
+	tests := []struct {
+		name     string
+		options  restoreOptions
+		replicas int
+		ok       bool
+	}{
+		{name: "defaults", replicas: 1, ok: true},
+		{name: "no replicas", options: restoreOptions{Replicas: intp(0)}, replicas: 0, ok: true},
+		{name: "max replicas", options: restoreOptions{Replicas: intp(2)}, replicas: 2, ok: true},
+		{name: "above max", options: restoreOptions{Replicas: intp(3)}},
+		{name: "negative replicas", options: restoreOptions{Replicas: intp(-1)}},
+		{name: "aliases", options: restoreOptions{IncludeAliases: boolp(true)}, replicas: 1, ok: true},
+		{name: "partial", options: restoreOptions{Partial: boolp(true)}},
+		{name: "no partial", options: restoreOptions{Partial: boolp(false)}, replicas: 1, ok: true},
+		{name: "allowed setting", options: restoreOptions{IndexSettings: map[string]interface{}{"refresh_interval": "30s"}}, replicas: 1, ok: true},
+		{name: "allowed by a glob", options: restoreOptions{IndexSettings: map[string]interface{}{"index.blocks.write": true}}, replicas: 1, ok: true},
+		{name: "other setting", options: restoreOptions{IndexSettings: map[string]interface{}{"index.codec": "best_compression"}}},
+		{name: "replicas as a setting", options: restoreOptions{IndexSettings: map[string]interface{}{"number_of_replicas": 0}}},
+		{name: "allocation setting", options: restoreOptions{IndexSettings: map[string]interface{}{"index.routing.allocation.require.box_type": "hot"}}},
+		{name: "ignored setting", options: restoreOptions{IgnoreIndexSettings: []string{"lifecycle.name"}}, replicas: 1, ok: true},
+		{name: "other ignored setting", options: restoreOptions{IgnoreIndexSettings: []string{"index.refresh_interval"}}},
+		{name: "feature state", options: restoreOptions{FeatureStates: []string{"security"}}, replicas: 1, ok: true},
+		{name: "other feature state", options: restoreOptions{FeatureStates: []string{"kibana"}}},
+		{name: "conflict", options: restoreOptions{Conflict: "skip"}, replicas: 1, ok: true},
+		{name: "unknown conflict", options: restoreOptions{Conflict: "merge"}},
+	}
+	for _, tt := range tests {
+		o := tt.options
+		replicas, err := rt.checkOptions(&o)
+		if !tt.ok {
+			if httpStatus(err) != http.StatusBadRequest {
+				t.Errorf("%s: %v, want 400", tt.name, err)
+			}
+			continue
+		}
+		if err != nil || replicas != tt.replicas {
+			t.Errorf("%s: %d, %v, want %d replicas", tt.name, replicas, err, tt.replicas)
+		}
+	}
+
+	// по умолчанию меняется только refresh_interval, default больше max поднимает max
+	rt = testRouter(t, "restore:\n  replicas:\n    default: 2\n")
+	for _, o := range []restoreOptions{
+		{IncludeAliases: boolp(true)},
+		{IndexSettings: map[string]interface{}{"index.blocks.write": true}},
+		{IgnoreIndexSettings: []string{"index.refresh_interval"}},
+		{Replicas: intp(3)},
+	} {
+		if _, err := rt.checkOptions(&o); httpStatus(err) != http.StatusBadRequest {
+			t.Errorf("%+v without limits: %v, want 400", o, err)
+		}
+	}
+	o := restoreOptions{}
+	if replicas, err := rt.checkOptions(&o); err != nil || replicas != 2 || o.Conflict != "fail" {
+		t.Errorf("defaults: %d, %v, conflict %q, want 2 replicas and fail", replicas, err, o.Conflict)
+	}
+}
+
+func TestRestoreBody(t *testing.T) {
+	rt := testRouter(t, optionsConfig)
+	o := restoreOptions{
+		IncludeAliases:      boolp(true),
+		IndexSettings:       map[string]interface{}{"refresh_interval": "30s"},
+		IgnoreIndexSettings: []string{"lifecycle.name"},
+		FeatureStates:       []string{"security"},
+	}
+	replicas, err := rt.checkOptions(&o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rv := renameVars{Repo: "archive", Snapshot: "s1", Time: time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)}
+	body := rt.restoreBody(o, replicas, []string{"logs-a", "logs-b"}, rv)
+
+	got, _ := json.Marshal(body)
+	var gotMap, want map[string]interface{}
+	json.Unmarshal(got, &gotMap)
+	json.Unmarshal([]byte(`{
+		"ignore_unavailable": false,
+		"include_global_state": false,
+		"include_aliases": true,
+		"partial": false,
+		"rename_pattern": "(.+)",
+		"rename_replacement": "extracted_$1-01-11-2020",
+		"indices": ["logs-a", "logs-b"],
+		"index_settings": {
+			"index.refresh_interval": "30s",
+			"index.number_of_replicas": 1,
+			"index.routing.allocation.require.box_type": "warm",
+			"index.routing.allocation.include._tier_preference": "data_warm,data_hot"
+		},
+		"ignore_index_settings": ["index.lifecycle.name"],
+		"feature_states": ["security"]
+	}`), &want)
+	if !reflect.DeepEqual(gotMap, want) {
+		t.Errorf("restore body\n%s\nwant\n%v", got, want)
+	}
+
+	// без опций в теле нет ignore_index_settings и feature_states
+	o = restoreOptions{}
+	replicas, _ = rt.checkOptions(&o)
+	body = rt.restoreBody(o, replicas, []string{"logs-a"}, rv)
+	for _, k := range []string{"ignore_index_settings", "feature_states"} {
+		if _, ok := body[k]; ok {
+			t.Errorf("%s in the body without options", k)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/uzhinskiy/lib.go/helpers"
+)
+
+// simNode - data node as seen by the placement simulator
+type simNode struct {
+	Name       string            `json:"name"`
+	Ip         string            `json:"ip,omitempty"`
+	Roles      string            `json:"roles,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Total      int64             `json:"total"`
+	Used       int64             `json:"used"`
+}
+
+// watermark - one of cluster.routing.allocation.disk.watermark.* settings,
+// either a used disk ratio or an absolute amount of free space
+type watermark struct {
+	Ratio    float64
+	FreeSize int64
+}
+
+// limit returns the maximum number of used bytes allowed on a disk of size total
+func (wm watermark) limit(total int64) int64 {
+	if wm.FreeSize > 0 {
+		return total - wm.FreeSize
+	}
+	return int64(float64(total) * wm.Ratio)
+}
+
+type watermarks struct {
+	Low  watermark
+	High watermark
+}
+
+// nodeUsage - projected disk usage of a node after the restore
+type nodeUsage struct {
+	Name        string  `json:"name"`
+	Total       int64   `json:"total"`
+	UsedBefore  int64   `json:"used_before"`
+	UsedAfter   int64   `json:"used_after"`
+	PercentUsed float64 `json:"percent_after"`
+	Shards      int     `json:"shards"`
+}
+
+// indexVerdict - may the index be restored and why
+type indexVerdict struct {
+	Index  string              `json:"index"`
+	Fits   bool                `json:"fits"`
+	Reason string              `json:"reason,omitempty"`
+	Size   int                 `json:"size"`
+	Shards int                 `json:"shards"`
+	Nodes  map[string][]string `json:"nodes,omitempty"` // shard -> nodes
+}
+
+// placement - result of the shard-allocation simulation
+type placement struct {
+	Verdicts []indexVerdict `json:"indices"`
+	Nodes    []nodeUsage    `json:"nodes"`
+}
+
+func (p placement) Accepted() []string {
+	var a []string
+	for _, v := range p.Verdicts {
+		if v.Fits {
+			a = append(a, v.Index)
+		}
+	}
+	return a
+}
+
+func (p placement) Rejected() map[string]string {
+	b := make(map[string]string)
+	for _, v := range p.Verdicts {
+		if !v.Fits {
+			b[v.Index] = v.Reason
+		}
+	}
+	return b
+}
+
+// Barrel checks whether indices fit into the cluster: shards are bin-packed
+// onto eligible data nodes honoring the disk watermarks. What running jobs
+// are still to restore counts as used.
+func (rt *Router) Barrel(array IndicesInSnap, replicas int) (placement, error) {
+	nodes, err := rt.simNodes()
+	if err != nil {
+		return placement{}, err
+	}
+	reserved, err := rt.jobs.reserved()
+	if err != nil {
+		return placement{}, err
+	}
+	for i := range nodes {
+		nodes[i].Used += reserved[nodes[i].Name]
+	}
+	wm, err := rt.watermarks()
+	if err != nil {
+		return placement{}, err
+	}
+	return simulate(rt.eligibleNodes(nodes), wm, array, replicas+1), nil
+}
+
+// simulate places copies of every shard of every index on distinct nodes.
+// A node takes a shard only while it is under the low watermark and stays
+// under the high one afterwards. An index either fits completely or not at all.
+func simulate(nodes []simNode, wm watermarks, array IndicesInSnap, copies int) placement {
+	var p placement
+	used := make(map[string]int64)
+	count := make(map[string]int)
+	for _, n := range nodes {
+		used[n.Name] = n.Used
+	}
+
+	names := make([]string, 0, len(array))
+	for name := range array {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ind := array[name]
+		v := indexVerdict{Index: name, Size: ind.Size, Shards: len(ind.Shards), Nodes: make(map[string][]string)}
+
+		shards := make([]int, len(ind.Shards))
+		copy(shards, ind.Shards)
+		sort.Sort(sort.Reverse(sort.IntSlice(shards)))
+
+		if len(nodes) == 0 {
+			v.Reason = "no eligible data nodes"
+		} else if copies > len(nodes) {
+			v.Reason = fmt.Sprintf("%d copies of each shard requested, but only %d eligible nodes", copies, len(nodes))
+		} else if len(shards) == 0 {
+			v.Reason = "index not found in snapshot or has no shards"
+		}
+
+		// пробное размещение, применяется только если поместился весь индекс
+		tried := make(map[string]int64)
+		tcount := make(map[string]int)
+		for s := 0; v.Reason == "" && s < len(shards); s++ {
+			size := int64(shards[s])
+			taken := make(map[string]bool)
+			for c := 0; c < copies; c++ {
+				best := -1
+				var bestFree int64
+				for i, n := range nodes {
+					if taken[n.Name] {
+						continue
+					}
+					u := used[n.Name] + tried[n.Name]
+					if u > wm.Low.limit(n.Total) || u+size > wm.High.limit(n.Total) {
+						continue
+					}
+					if free := n.Total - u; best < 0 || free > bestFree {
+						best, bestFree = i, free
+					}
+				}
+				if best < 0 {
+					v.Reason = fmt.Sprintf("shard %d (%d bytes), copy %d: no node stays below the disk watermarks", s, size, c+1)
+					break
+				}
+				n := nodes[best].Name
+				taken[n] = true
+				tried[n] += size
+				tcount[n]++
+				key := strconv.Itoa(s)
+				v.Nodes[key] = append(v.Nodes[key], n)
+			}
+		}
+
+		if v.Reason == "" {
+			v.Fits = true
+			for n, size := range tried {
+				used[n] += size
+				count[n] += tcount[n]
+			}
+		} else {
+			v.Nodes = nil
+		}
+		p.Verdicts = append(p.Verdicts, v)
+	}
+
+	for _, n := range nodes {
+		nu := nodeUsage{Name: n.Name, Total: n.Total, UsedBefore: n.Used, UsedAfter: used[n.Name], Shards: count[n.Name]}
+		if n.Total > 0 {
+			nu.PercentUsed = float64(nu.UsedAfter) * 100 / float64(n.Total)
+		}
+		p.Nodes = append(p.Nodes, nu)
+	}
+	return p
+}
+
+// tierRoles maps data tiers to the letters used in _cat/nodes node.role
+var tierRoles = map[string]string{
+	"data":         "d",
+	"data_content": "s",
+	"data_hot":     "h",
+	"data_warm":    "w",
+	"data_cold":    "c",
+	"data_frozen":  "f",
+}
+
+// eligibleNodes keeps data nodes matching allocation.tiers and allocation.attributes
+func (rt *Router) eligibleNodes(nodes []simNode) []simNode {
+	roles := "dshwc"
+	if len(rt.conf.Allocation.Tiers) > 0 {
+		roles = "d"
+		for _, t := range rt.conf.Allocation.Tiers {
+			roles += tierRoles[t]
+		}
+	}
+
+	var res []simNode
+	for _, n := range nodes {
+		if !strings.ContainsAny(n.Roles, roles) {
+			continue
+		}
+		ok := true
+		for attr, val := range rt.conf.Allocation.Attributes {
+			if n.Attributes[attr] != val {
+				ok = false
+			}
+		}
+		if ok {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+// allocationSettings returns index settings that pin restored indices
+// to the same nodes the simulator considered
+func (rt *Router) allocationSettings() map[string]interface{} {
+	s := make(map[string]interface{})
+	for attr, val := range rt.conf.Allocation.Attributes {
+		s["index.routing.allocation.require."+attr] = val
+	}
+	if len(rt.conf.Allocation.Tiers) > 0 {
+		s["index.routing.allocation.include._tier_preference"] = strings.Join(rt.conf.Allocation.Tiers, ",")
+	}
+	return s
+}
+
+func (rt *Router) simNodes() ([]simNode, error) {
+	var (
+		cat   []map[string]string
+		nodes []simNode
+	)
+
+	response, err := rt.doGet(rt.conf.Elastic.Host + "_cat/nodes?format=json&bytes=b&h=name,ip,node.role,dt,du&s=name")
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(response, &cat)
+	if err != nil {
+		return nil, err
+	}
+	idx := make(map[string]int)
+	for _, c := range cat {
+		idx[c["name"]] = len(nodes)
+		nodes = append(nodes, simNode{
+			Name:       c["name"],
+			Ip:         c["ip"],
+			Roles:      c["node.role"],
+			Attributes: make(map[string]string),
+			Total:      int64(helpers.Atoi(c["dt"])),
+			Used:       int64(helpers.Atoi(c["du"])),
+		})
+	}
+
+	if len(rt.conf.Allocation.Attributes) > 0 {
+		cat = nil
+		response, err = rt.doGet(rt.conf.Elastic.Host + "_cat/nodeattrs?format=json&h=node,attr,value")
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(response, &cat)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range cat {
+			if i, ok := idx[c["node"]]; ok {
+				nodes[i].Attributes[c["attr"]] = c["value"]
+			}
+		}
+	}
+	return nodes, nil
+}
+
+func (rt *Router) watermarks() (watermarks, error) {
+	var (
+		cs struct {
+			Persistent map[string]interface{} `json:"persistent"`
+			Transient  map[string]interface{} `json:"transient"`
+			Defaults   map[string]interface{} `json:"defaults"`
+		}
+		wm watermarks
+	)
+
+	response, err := rt.doGet(rt.conf.Elastic.Host + "_cluster/settings?include_defaults=true&flat_settings=true")
+	if err != nil {
+		return wm, err
+	}
+	err = json.Unmarshal(response, &cs)
+	if err != nil {
+		return wm, err
+	}
+
+	get := func(key, def string) string {
+		for _, m := range []map[string]interface{}{cs.Transient, cs.Persistent, cs.Defaults} {
+			if v, ok := m[key].(string); ok && v != "" {
+				return v
+			}
+		}
+		return def
+	}
+
+	wm.Low, err = parseWatermark(get("cluster.routing.allocation.disk.watermark.low", "85%"))
+	if err != nil {
+		return wm, err
+	}
+	wm.High, err = parseWatermark(get("cluster.routing.allocation.disk.watermark.high", "90%"))
+	if err != nil {
+		return wm, err
+	}
+	// отключённый порог диска - размещаем до конца диска
+	if get("cluster.routing.allocation.disk.threshold_enabled", "true") == "false" {
+		wm.Low, wm.High = watermark{Ratio: 1}, watermark{Ratio: 1}
+	}
+	return wm, nil
+}
+
+// parseWatermark understands "85%", "0.85" and absolute values like "50gb"
+func parseWatermark(s string) (watermark, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if strings.HasSuffix(s, "%") {
+		f, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		if err != nil {
+			return watermark{}, err
+		}
+		return watermark{Ratio: f / 100}, nil
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil && f <= 1 {
+		return watermark{Ratio: f}, nil
+	}
+	size, err := parseByteSize(s)
+	if err != nil {
+		return watermark{}, err
+	}
+	return watermark{FreeSize: size}, nil
+}
+
+func parseByteSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		mult   int64
+	}{
+		{"pb", 1 << 50}, {"tb", 1 << 40}, {"gb", 1 << 30}, {"mb", 1 << 20}, {"kb", 1 << 10}, {"b", 1},
+	}
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			f, err := strconv.ParseFloat(strings.TrimSuffix(s, u.suffix), 64)
+			if err != nil {
+				return 0, err
+			}
+			return int64(f * float64(u.mult)), nil
+		}
+	}
+	return 0, errors.New("wrong byte size value: " + s)
+}
@@ -0,0 +1,238 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var testWatermarks = watermarks{Low: watermark{Ratio: 0.85}, High: watermark{Ratio: 0.90}}
+
+func testNode(name string, total, used int64) simNode {
+	return simNode{Name: name, Roles: "dim", Total: total, Used: used}
+}
+
+func testIndex(name string, shards ...int) *IndexInSnap {
+	ind := &IndexInSnap{Name: name, Shards: shards}
+	for _, s := range shards {
+		ind.Size += s
+	}
+	return ind
+}
+
+func TestSimulate(t *testing.T) {
+	tests := []struct {
+		name     string
+		nodes    []simNode
+		wm       watermarks
+		indices  []*IndexInSnap
+		copies   int
+		accepted []string
+		nodesOf  map[string]map[string][]string // index -> shard -> nodes
+	}{
+		{
+			name:     "fits on the node with most free space",
+			nodes:    []simNode{testNode("n1", 1000, 500), testNode("n2", 1000, 100)},
+			wm:       testWatermarks,
+			indices:  []*IndexInSnap{testIndex("a", 100)},
+			copies:   1,
+			accepted: []string{"a"},
+			nodesOf:  map[string]map[string][]string{"a": {"0": {"n2"}}},
+		},
+		{
+			name:    "node above the low watermark takes nothing",
+			nodes:   []simNode{testNode("n1", 1000, 860)},
+			wm:      testWatermarks,
+			indices: []*IndexInSnap{testIndex("a", 1)},
+			copies:  1,
+		},
+		{
+			name:     "shard may not push the node above the high watermark",
+			nodes:    []simNode{testNode("n1", 1000, 800)},
+			wm:       testWatermarks,
+			indices:  []*IndexInSnap{testIndex("a", 101), testIndex("b", 100)},
+			copies:   1,
+			accepted: []string{"b"},
+		},
+		{
+			name:     "absolute watermarks leave free space",
+			nodes:    []simNode{testNode("n1", 1000, 0)},
+			wm:       watermarks{Low: watermark{FreeSize: 300}, High: watermark{FreeSize: 200}},
+			indices:  []*IndexInSnap{testIndex("a", 801), testIndex("b", 800)},
+			copies:   1,
+			accepted: []string{"b"},
+		},
+		{
+			name:    "more copies than eligible nodes",
+			nodes:   []simNode{testNode("n1", 1000, 0), testNode("n2", 1000, 0)},
+			wm:      testWatermarks,
+			indices: []*IndexInSnap{testIndex("a", 10)},
+			copies:  3,
+		},
+		{
+			name:     "copies of a shard go to distinct nodes",
+			nodes:    []simNode{testNode("n1", 1000, 0), testNode("n2", 1000, 0)},
+			wm:       testWatermarks,
+			indices:  []*IndexInSnap{testIndex("a", 10)},
+			copies:   2,
+			accepted: []string{"a"},
+			nodesOf:  map[string]map[string][]string{"a": {"0": {"n1", "n2"}}},
+		},
+		{
+			name:    "index without shards",
+			nodes:   []simNode{testNode("n1", 1000, 0)},
+			wm:      testWatermarks,
+			indices: []*IndexInSnap{testIndex("a")},
+			copies:  1,
+		},
+		{
+			name:     "earlier shards consume space of later ones",
+			nodes:    []simNode{testNode("n1", 1000, 0), testNode("n2", 1000, 100)},
+			wm:       testWatermarks,
+			indices:  []*IndexInSnap{testIndex("a", 500, 300, 200)},
+			copies:   1,
+			accepted: []string{"a"},
+			// без учёта занятого всё ушло бы на n1
+			nodesOf: map[string]map[string][]string{"a": {"0": {"n1"}, "1": {"n2"}, "2": {"n2"}}},
+		},
+		{
+			name:     "earlier indices consume space of later ones",
+			nodes:    []simNode{testNode("n1", 1000, 0)},
+			wm:       testWatermarks,
+			indices:  []*IndexInSnap{testIndex("a", 500), testIndex("b", 500)},
+			copies:   1,
+			accepted: []string{"a"},
+		},
+		{
+			name:     "rejected index does not consume space",
+			nodes:    []simNode{testNode("n1", 1000, 0)},
+			wm:       testWatermarks,
+			indices:  []*IndexInSnap{testIndex("a", 500, 500), testIndex("b", 800)},
+			copies:   1,
+			accepted: []string{"b"},
+		},
+		{
+			name:    "no eligible nodes",
+			wm:      testWatermarks,
+			indices: []*IndexInSnap{testIndex("a", 1)},
+			copies:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			array := make(IndicesInSnap)
+			for _, i := range tt.indices {
+				array[i.Name] = i
+			}
+			p := simulate(tt.nodes, tt.wm, array, tt.copies)
+
+			accepted := p.Accepted()
+			sort.Strings(accepted)
+			if len(accepted) != 0 || len(tt.accepted) != 0 {
+				if !reflect.DeepEqual(accepted, tt.accepted) {
+					t.Fatalf("accepted %v, want %v", accepted, tt.accepted)
+				}
+			}
+			for _, v := range p.Verdicts {
+				if !v.Fits && v.Reason == "" {
+					t.Errorf("%s rejected without a reason", v.Index)
+				}
+				if want, ok := tt.nodesOf[v.Index]; ok {
+					for _, n := range v.Nodes {
+						sort.Strings(n)
+					}
+					if !reflect.DeepEqual(v.Nodes, want) {
+						t.Errorf("%s placed on %v, want %v", v.Index, v.Nodes, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSimulateNodeUsage(t *testing.T) {
+	nodes := []simNode{testNode("n1", 1000, 100), testNode("n2", 1000, 200)}
+	array := IndicesInSnap{"a": testIndex("a", 300, 300)}
+	p := simulate(nodes, testWatermarks, array, 2)
+
+	want := []nodeUsage{
+		{Name: "n1", Total: 1000, UsedBefore: 100, UsedAfter: 700, PercentUsed: 70, Shards: 2},
+		{Name: "n2", Total: 1000, UsedBefore: 200, UsedAfter: 800, PercentUsed: 80, Shards: 2},
+	}
+	if !reflect.DeepEqual(p.Nodes, want) {
+		t.Fatalf("nodes %+v, want %+v", p.Nodes, want)
+	}
+}
+
+func TestEligibleNodes(t *testing.T) {
+	nodes := []simNode{
+		{Name: "hot", Roles: "him", Attributes: map[string]string{"box_type": "hot"}},
+		{Name: "warm", Roles: "wi", Attributes: map[string]string{"box_type": "warm"}},
+		{Name: "cold", Roles: "c", Attributes: map[string]string{"box_type": "warm"}},
+		{Name: "data", Roles: "dim", Attributes: map[string]string{}},
+		{Name: "master", Roles: "m", Attributes: map[string]string{}},
+		{Name: "frozen", Roles: "f", Attributes: map[string]string{}},
+	}
+	tests := []struct {
+		name  string
+		tiers []string
+		attrs map[string]string
+		want  []string
+	}{
+		{name: "every data node but frozen", want: []string{"hot", "warm", "cold", "data"}},
+		{name: "tier", tiers: []string{"data_warm"}, want: []string{"warm", "data"}},
+		{name: "tiers", tiers: []string{"data_warm", "data_cold"}, want: []string{"warm", "cold", "data"}},
+		{name: "attribute", attrs: map[string]string{"box_type": "warm"}, want: []string{"warm", "cold"}},
+		{name: "tier and attribute", tiers: []string{"data_cold"}, attrs: map[string]string{"box_type": "warm"}, want: []string{"cold"}},
+		{name: "nothing matches", attrs: map[string]string{"box_type": "none"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := &Router{}
+			rt.conf.Allocation.Tiers = tt.tiers
+			rt.conf.Allocation.Attributes = tt.attrs
+			var got []string
+			for _, n := range rt.eligibleNodes(nodes) {
+				got = append(got, n.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("eligible %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWatermark(t *testing.T) {
+	tests := []struct {
+		in   string
+		want watermark
+	}{
+		{"85%", watermark{Ratio: 0.85}},
+		{"0.9", watermark{Ratio: 0.9}},
+		{"50gb", watermark{FreeSize: 50 << 30}},
+		{"500MB", watermark{FreeSize: 500 << 20}},
+	}
+	for _, tt := range tests {
+		got, err := parseWatermark(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("parseWatermark(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := parseWatermark("lots"); err == nil {
+		t.Error("parseWatermark(\"lots\") must fail")
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// restoreQueue holds restores waiting for a free slot and for disk space.
+// At most concurrency restore jobs run at once, a queued job starts when
+// Barrel accepts all of its indices.
+type restoreQueue struct {
+	sync.Mutex
+	rt          *Router
+	concurrency int
+	interval    time.Duration
+	maxWait     time.Duration
+	started     bool
+	items       []*queuedRestore
+	wake        chan struct{}
+}
+
+// queuedRestore - restore request kept until it is started
+type queuedRestore struct {
+	job     *restoreJob
+	request apiRequest
+	id      *identity
+}
+
+func newRestoreQueue(rt *Router) *restoreQueue {
+	c := rt.conf.Restore.Queue
+	q := &restoreQueue{
+		rt:          rt,
+		concurrency: c.Concurrency,
+		wake:        make(chan struct{}, 1),
+	}
+	var err error
+	q.interval, err = time.ParseDuration(c.Interval)
+	if err != nil || q.interval <= 0 {
+		q.interval = 30 * time.Second
+	}
+	q.maxWait, err = time.ParseDuration(c.MaxWait)
+	if err != nil || q.maxWait <= 0 {
+		q.maxWait = 24 * time.Hour
+	}
+	return q
+}
+
+// start runs the queue when restore.queue.concurrency is set
+func (q *restoreQueue) start() {
+	if q.concurrency <= 0 {
+		return
+	}
+	q.Lock()
+	q.started = true
+	q.Unlock()
+	log.Println("Queue: concurrency", q.concurrency, "check every", q.interval, "max wait", q.maxWait)
+	go q.run()
+}
+
+// enabled reports whether restores go through the queue. The command-line client
+// works without the queue, nobody would start its jobs.
+func (q *restoreQueue) enabled() bool {
+	q.Lock()
+	defer q.Unlock()
+	return q.started
+}
+
+func (q *restoreQueue) run() {
+	for {
+		q.dispatch()
+		select {
+		case <-q.wake:
+		case <-time.After(q.interval):
+		}
+	}
+}
+
+func (q *restoreQueue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// push adds a planned restore to the queue, only the accepted indices are restored later
+func (q *restoreQueue) push(r *http.Request, request *apiRequest, plan *restorePlan) *restoreJob {
+	now := time.Now()
+	item := &queuedRestore{request: *request, id: requestIdentity(r)}
+	item.request.Values.Indices = plan.Accepted
+	item.request.Values.From, item.request.Values.To = "", ""
+	item.job = &restoreJob{
+		User:     plan.vars.User,
+		Repo:     plan.Repo,
+		Snapshot: plan.Snapshot,
+		Indices:  plan.Accepted,
+		Targets:  plan.Targets,
+		Priority: request.Values.Priority,
+		Queued:   &now,
+		Started:  now,
+		State:    jobQueued,
+	}
+	q.rt.jobs.add(item.job)
+
+	q.Lock()
+	q.items = append(q.items, item)
+	// больший приоритет раньше, при равном - в порядке поступления
+	sort.SliceStable(q.items, func(a, b int) bool { return q.items[a].job.Priority > q.items[b].job.Priority })
+	q.Unlock()
+	q.notify()
+	return item.job
+}
+
+// position returns the place of the job in the queue starting from 1, 0 if it is not queued
+func (q *restoreQueue) position(id string) int {
+	q.Lock()
+	defer q.Unlock()
+	for i, item := range q.items {
+		if item.job.ID == id {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// remove takes the job out of the queue, false if it is not there anymore
+func (q *restoreQueue) remove(id string) bool {
+	q.Lock()
+	defer q.Unlock()
+	for i, item := range q.items {
+		if item.job.ID == id {
+			q.items = append(q.items[:i], q.items[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// dispatch starts queued restores while there are free slots
+func (q *restoreQueue) dispatch() {
+	q.Lock()
+	waiting := append([]*queuedRestore{}, q.items...)
+	q.Unlock()
+	if len(waiting) == 0 {
+		return
+	}
+
+	running := q.rt.jobs.running()
+	for _, item := range waiting {
+		if running >= q.concurrency {
+			return
+		}
+		if time.Since(*item.job.Queued) > q.maxWait {
+			if q.remove(item.job.ID) {
+				q.rt.jobs.finish(item.job.ID, jobFailed, "indices did not fit into the cluster for "+q.maxWait.String())
+			}
+			continue
+		}
+
+		r := identityRequest(item.id, "/queue")
+		request := item.request
+		plan, err := q.rt.planRestore(r, &request)
+		// места пока нет или ES недоступен - ждём, задания с меньшим приоритетом могут пройти вперёд
+		if err == nil && len(plan.Rejected) > 0 || err != nil && httpStatus(err) == http.StatusBadGateway {
+			continue
+		}
+		// задание могли отменить, пока строился план
+		if !q.remove(item.job.ID) {
+			continue
+		}
+		if err == nil && len(plan.Accepted) == 0 {
+			err = &apiError{Status: http.StatusConflict, Message: "nothing to restore: " + plan.describeRejected(plan.Rejected)}
+		}
+		if err == nil {
+			_, err = q.rt.startRestore(r, &request, plan, item.job)
+		}
+		if err != nil {
+			log.Println("Queue: job", item.job.ID, "failed:", err)
+			q.rt.jobs.finish(item.job.ID, jobFailed, err.Error())
+			continue
+		}
+		log.Println("Queue: job", item.job.ID, "started")
+		running++
+	}
+}
+
+// cancelJob removes a queued job from the queue or deletes partially restored
+// indices of a running one
+func (rt *Router) cancelJob(r *http.Request, id string) (*jobProgress, error) {
+	job, err := rt.jobs.get(id)
+	if err != nil {
+		return nil, notFound("%s", err)
+	}
+	// rbac по репозиторию и индексам задания
+	check := apiRequest{Action: "cancel_job"}
+	check.Values.Repo = job.Repo
+	check.Values.Indices = job.Indices
+	err = rt.authorizeRequest(r, &check)
+	if err != nil {
+		return nil, err
+	}
+	err = rt.ownerOrAdmin(r, "cancel_job", job.User)
+	if err != nil {
+		return nil, err
+	}
+
+	user := requestUser(r)
+	switch job.State {
+	case jobQueued:
+		if !rt.queue.remove(id) {
+			return nil, &apiError{Status: http.StatusConflict, Message: "job " + id + " is being started, try again"}
+		}
+	case jobRunning:
+		for _, index := range job.Targets {
+			_, err := rt.doDel(rt.conf.Elastic.Host + index)
+			if err != nil && httpStatus(err) != http.StatusNotFound {
+				return nil, err
+			}
+			rt.janitor.forget(index)
+		}
+	default:
+		return nil, &apiError{Status: http.StatusConflict, Message: "job " + id + " is already " + job.State}
+	}
+
+	rt.jobs.finish(id, jobCancelled, "cancelled by "+user)
+	rt.record(event{User: user, Action: "cancel_job", Repo: job.Repo, Snapshot: job.Snapshot, Job: id, Details: "was " + job.State})
+	rt.queue.notify()
+	return rt.jobs.get(id)
+}
@@ -31,22 +31,70 @@ import (
 )
 
 type Router struct {
-	conf  config.Config
-	nc    *http.Client
-	nodes nodesArray
+	conf      config.Config
+	nc        *http.Client
+	janitor   *janitor
+	jobs      *jobRegistry
+	queue     *restoreQueue
+	schedules *scheduler
+	checks    *checkRegistry
+	catalog   *catalog
+	store     store
+	auth      *auth
 }
 
 type apiRequest struct {
 	Action string `json:"action,omitempty"` // Имя вызываемого метода*
 	Values struct {
-		Indices  []string `json:"indices,omitempty"`
-		Repo     string   `json:"repo,omitempty"`
-		Snapshot string   `json:"snapshot,omitempty"`
-		Index    string   `json:"index,omitempty"`
-		Ipattern string   `json:"ipattern,omitempty"`
+		Indices  []string       `json:"indices,omitempty"`
+		Repo     string         `json:"repo,omitempty"`
+		Snapshot string         `json:"snapshot,omitempty"`
+		Index    string         `json:"index,omitempty"`
+		Ipattern string         `json:"ipattern,omitempty"`
+		Hours    int            `json:"hours,omitempty"`
+		JobId    string         `json:"job_id,omitempty"`
+		Limit    int            `json:"limit,omitempty"`
+		From     string         `json:"from,omitempty"` // даты индексов для restore и снапшотов для get_snapshots, 2006-01-02
+		To       string         `json:"to,omitempty"`
+		Options  restoreOptions `json:"options,omitempty"`
+		Priority int            `json:"priority,omitempty"` // очередь restore, больше - раньше
+		// расписания restore
+		ScheduleId      string `json:"schedule_id,omitempty"`
+		Name            string `json:"name,omitempty"`
+		Cron            string `json:"cron,omitempty"`
+		SnapshotPattern string `json:"snapshot_pattern,omitempty"`
+		Enabled         *bool  `json:"enabled,omitempty"`
+		// create_snapshot
+		Reason             string `json:"reason,omitempty"`
+		IncludeGlobalState bool   `json:"include_global_state,omitempty"`
+		// create_repository
+		Type     string                 `json:"type,omitempty"`
+		Settings map[string]interface{} `json:"settings,omitempty"`
+		CheckId  string                 `json:"check_id,omitempty"`
+		// get_snapshots
+		State string `json:"state,omitempty"`
+		Sort  string `json:"sort,omitempty"`
+		Order string `json:"order,omitempty"`
+		After string `json:"after,omitempty"`
+		// find_index, пустой список - все репозитории
+		Repos []string `json:"repos,omitempty"`
 	} `json:"values,omitempty"`
 }
 
+// restoreResult - answer of restore on both endpoints: 202 when every index is restored,
+// 207 when some are rejected and 409 with error and status when none is
+type restoreResult struct {
+	JobID     string              `json:"job_id,omitempty"`
+	Accepted  []string            `json:"accepted"`
+	Rejected  map[string]string   `json:"rejected,omitempty"`
+	Targets   map[string]string   `json:"targets"`
+	Conflicts map[string]conflict `json:"conflicts,omitempty"`
+	Position  int                 `json:"position,omitempty"` // место в очереди
+	Message   string              `json:"message"`
+	Error     string              `json:"error,omitempty"`
+	Status    int                 `json:"status,omitempty"`
+}
+
 type snapStatus struct {
 	Snapshots []struct {
 		Snapshot string `json:"snapshot,omitempty"`
@@ -86,13 +134,6 @@ type nodesStatus struct {
 	nlist []singleNode
 }
 
-type nodesArray struct {
-	//sync.RWMutex
-	list []int
-	max  int
-	sum  int
-}
-
 type IndexInSnap struct {
 	Name   string
 	Size   int
@@ -101,17 +142,55 @@ type IndexInSnap struct {
 
 type IndicesInSnap map[string]*IndexInSnap
 
-func Run(cnf config.Config) {
-	rt := Router{}
+// prepare creates the router with everything but the web server
+func prepare(cnf config.Config) (*Router, error) {
+	rt := &Router{}
 	rt.conf = cnf
 	rt.netClientPrepare()
-	_, err := rt.getNodes()
+	var err error
+	rt.store, err = newStore(rt)
+	if err != nil {
+		return nil, fmt.Errorf("store: %v", err)
+	}
+	rt.janitor = newJanitor(rt)
+	rt.queue = newRestoreQueue(rt)
+	rt.jobs = newJobRegistry(rt)
+	rt.schedules = newScheduler(rt)
+	rt.checks = newCheckRegistry(rt)
+	rt.catalog = newCatalog(rt)
+	return rt, nil
+}
+
+func Run(cnf config.Config) {
+	rt, err := prepare(cnf)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	_, err = rt.getNodes()
 	if err != nil {
 		log.Println(err)
 	}
+	rt.jobs.recover()
+	rt.checks.recover()
+	go rt.janitor.run()
+	rt.queue.start()
+	go rt.schedules.run()
+	go rt.catalog.run()
+	rt.auth, err = newAuth(rt)
+	if err != nil {
+		log.Fatalln("Auth:", err)
+	}
 
-	http.HandleFunc("/", rt.FrontHandler)
-	http.HandleFunc("/api/", rt.ApiHandler)
+	http.HandleFunc("/", rt.requireAuth(rt.FrontHandler, false))
+	http.HandleFunc("/api/", rt.requireAuth(rt.ApiHandler, true))
+	http.HandleFunc("/api/v1/", rt.requireAuth(rt.V1Handler, true))
+	http.HandleFunc("/api/openapi.json", rt.OpenAPIHandler)
+	http.HandleFunc("/login", rt.LoginHandler)
+	http.HandleFunc("/logout", rt.LogoutHandler)
+	if rt.auth.oidc != nil {
+		http.HandleFunc("/oidc/login", rt.auth.oidc.LoginHandler)
+		http.HandleFunc("/oidc/callback", rt.auth.oidc.CallbackHandler)
+	}
 	http.ListenAndServe(":"+cnf.App.Port, nil)
 }
 
@@ -132,7 +211,7 @@ func (rt *Router) FrontHandler(w http.ResponseWriter, r *http.Request) {
 	/* отправить его клиенту */
 	contentType := mime.TypeByExtension(path.Ext(cFile))
 	w.Header().Set("Content-Type", contentType)
-	w.Header().Set("Access-Control-Allow-Origin", "*")
+	rt.cors(w, "GET")
 	w.Header().Set("X-Server", version.Version)
 	w.Write(data)
 }
@@ -143,10 +222,7 @@ func (rt *Router) ApiHandler(w http.ResponseWriter, r *http.Request) {
 	defer r.Body.Close()
 	remoteIP := helpers.GetIP(r.RemoteAddr, r.Header.Get("X-Real-IP"), r.Header.Get("X-Forwarded-For"))
 
-	w.Header().Add("Access-Control-Allow-Origin", "*")
-	w.Header().Add("Access-Control-Allow-Methods", "POST,OPTIONS")
-	w.Header().Add("Access-Control-Allow-Credentials", "true")
-	w.Header().Add("Access-Control-Allow-Headers", "Accept, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+	rt.cors(w, "POST,OPTIONS")
 	w.Header().Add("Content-Type", "application/json; charset=utf-8")
 	w.Header().Set("X-Server", version.Version)
 
@@ -155,38 +231,163 @@ func (rt *Router) ApiHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if r.Method != http.MethodPost {
-		http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
-		log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", http.StatusServiceUnavailable, "\t", "Invalid request method ", "\t", r.UserAgent())
+		w.Header().Set("Allow", "POST, OPTIONS")
+		rt.fail(w, r, "", &apiError{Status: http.StatusMethodNotAllowed, Message: "Invalid request method"})
+		return
+	}
+	if !rt.jsonBody(w, r) {
 		return
 	}
 	err := json.NewDecoder(r.Body).Decode(&request)
 	if err != nil {
-		http.Error(w, err.Error(), 500)
-		log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+		rt.fail(w, r, "", badRequest("%s", err))
+		return
+	}
+
+	if !rt.allowed(w, r, &request) {
 		return
 	}
 
 	log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 200, "\t", r.UserAgent())
+	rt.dispatch(w, r, &request)
+}
+
+// allowed checks the request against rbac, denied requests are answered with 403
+func (rt *Router) allowed(w http.ResponseWriter, r *http.Request, request *apiRequest) bool {
+	err := rt.authorizeRequest(r, request)
+	if err != nil {
+		rt.fail(w, r, request.Action, err)
+		return false
+	}
+	return true
+}
+
+// authorizeRequest checks the request against rbac and records denials
+func (rt *Router) authorizeRequest(r *http.Request, request *apiRequest) error {
+	err := rt.authorize(requestIdentity(r), request)
+	if err != nil {
+		rt.record(event{User: requestUser(r), Action: "denied", Repo: request.Values.Repo, Index: request.Values.Index, Details: request.Action + ": " + err.Error()})
+		return &apiError{Status: http.StatusForbidden, Message: err.Error()}
+	}
+	return nil
+}
+
+// ownerOrAdmin lets only the owner of a job or a schedule and admins change it
+func (rt *Router) ownerOrAdmin(r *http.Request, action, owner string) error {
+	id := requestIdentity(r)
+	if id != nil && id.Name == owner || rt.isAdmin(id) {
+		return nil
+	}
+	rt.record(event{User: requestUser(r), Action: "denied", Details: action + ": owned by " + owner})
+	return &apiError{Status: http.StatusForbidden, Message: "only the owner or an admin may " + action}
+}
+
+// dispatch runs the action of the request for both the legacy and the /api/v1 endpoints
+func (rt *Router) dispatch(w http.ResponseWriter, r *http.Request, request *apiRequest) {
+	remoteIP := helpers.GetIP(r.RemoteAddr, r.Header.Get("X-Real-IP"), r.Header.Get("X-Forwarded-For"))
 
 	switch request.Action {
 	case "get_repositories":
 		{
-			response, err := rt.doGet(rt.conf.Elastic.Host + "_cat/repositories?format=json")
+			list, err := rt.catalog.repositories()
+			var response []byte
+			if err == nil {
+				response, _ = json.Marshal(list)
+				response, err = rt.filterRepositories(requestIdentity(r), response)
+			}
 			if err != nil {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+				rt.fail(w, r, request.Action, err)
 				return
 			}
 			w.Write(response)
 		}
+
+	case "get_repository", "create_repository", "delete_repository":
+		{
+			if request.Values.Repo == "" {
+				rt.fail(w, r, request.Action, badRequest("repo is required"))
+				return
+			}
+			var (
+				res interface{}
+				err error
+			)
+			switch request.Action {
+			case "get_repository":
+				res, err = rt.getRepository(request.Values.Repo)
+			case "create_repository":
+				res, err = rt.createRepository(request)
+			case "delete_repository":
+				var response []byte
+				response, err = rt.deleteRepository(request.Values.Repo)
+				res = json.RawMessage(response)
+			}
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			if request.Action != "get_repository" {
+				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", request.Values.Repo, "\t", requestUser(r))
+				rt.record(event{User: requestUser(r), Action: request.Action, Repo: request.Values.Repo, Details: request.Values.Type})
+			}
+			j, _ := json.Marshal(res)
+			w.Write(j)
+		}
+
+	case "verify_repository", "analyze_repository":
+		{
+			if request.Values.Repo == "" {
+				rt.fail(w, r, request.Action, badRequest("repo is required"))
+				return
+			}
+			kind := strings.TrimSuffix(request.Action, "_repository")
+			c, err := rt.checks.start(request.Values.Repo, kind, requestUser(r))
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", request.Values.Repo, "\t", c.ID, "\t", requestUser(r))
+			j, _ := json.Marshal(c)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write(j)
+		}
+
+	case "list_repository_checks":
+		{
+			if request.Values.Repo == "" {
+				rt.fail(w, r, request.Action, badRequest("repo is required"))
+				return
+			}
+			j, _ := json.Marshal(rt.checks.forRepo(request.Values.Repo))
+			w.Write(j)
+		}
+
+	case "get_repository_check":
+		{
+			// rbac проверяет репозиторий из запроса, он должен совпасть с репозиторием проверки
+			if request.Values.Repo == "" || request.Values.CheckId == "" {
+				rt.fail(w, r, request.Action, badRequest("repo and check_id are required"))
+				return
+			}
+			c, err := rt.checks.get(request.Values.CheckId)
+			if err == nil && c.Repo != request.Values.Repo {
+				err = notFound("check %s not found", request.Values.CheckId)
+			}
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			j, _ := json.Marshal(c)
+			w.Write(j)
+		}
+
 	case "get_nodes":
 		{
 
 			nresp, err := rt.getNodes()
 
 			if err != nil {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+				rt.fail(w, r, request.Action, err)
 				return
 			}
 
@@ -198,12 +399,16 @@ func (rt *Router) ApiHandler(w http.ResponseWriter, r *http.Request) {
 		{
 			//response, err := rt.doGet(rt.conf.Elastic.Host + "_cat/indices/restored*?s=i&format=json")
 			if request.Values.Ipattern == "" {
-				request.Values.Ipattern = "*"
+				request.Values.Ipattern = rt.restoredPattern()
+			}
+			// шаблон попадает в путь запроса к elasticsearch
+			if strings.ContainsAny(request.Values.Ipattern, "/?#\\ ") || strings.HasPrefix(request.Values.Ipattern, "-") {
+				rt.fail(w, r, request.Action, badRequest("bad index pattern %q", request.Values.Ipattern))
+				return
 			}
 			response, err := rt.doGet(rt.conf.Elastic.Host + request.Values.Ipattern + "/_recovery/")
 			if err != nil {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+				rt.fail(w, r, request.Action, err)
 				return
 			}
 
@@ -213,171 +418,444 @@ func (rt *Router) ApiHandler(w http.ResponseWriter, r *http.Request) {
 	case "del_index":
 		{
 			if request.Values.Index == "" {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+				rt.fail(w, r, request.Action, badRequest("index is required"))
+				return
+			}
+			// удаляется ровно один индекс: _all, шаблоны и списки запрещены
+			if !plainIndexName(request.Values.Index) {
+				rt.fail(w, r, request.Action, badRequest("%q is not a name of a single index", request.Values.Index))
 				return
 			}
 			response, err := rt.doDel(rt.conf.Elastic.Host + request.Values.Index)
 			if err != nil {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+				rt.fail(w, r, request.Action, err)
 				return
 			}
+			rt.janitor.forget(request.Values.Index)
+			rt.record(event{User: requestUser(r), Action: request.Action, Index: request.Values.Index})
 
 			w.Write(response)
 		}
 
+	case "get_retention":
+		{
+			list, err := rt.janitor.list()
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			j, _ := json.Marshal(list)
+			w.Write(j)
+		}
+
+	case "pin_index", "unpin_index", "extend_index":
+		{
+			if request.Values.Index == "" {
+				rt.fail(w, r, request.Action, badRequest("index is required"))
+				return
+			}
+			list, err := rt.janitor.list()
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			var ri *retentionInfo
+			for i := range list {
+				if list[i].Index == request.Values.Index {
+					ri = &list[i]
+				}
+			}
+			if ri == nil {
+				rt.fail(w, r, request.Action, notFound("%s is not a restored index", request.Values.Index))
+				return
+			}
+
+			switch request.Action {
+			case "pin_index":
+				err = rt.janitor.pin(ri.Index)
+			case "unpin_index":
+				err = rt.janitor.unpin(ri.Index)
+			case "extend_index":
+				if request.Values.Hours <= 0 {
+					request.Values.Hours = 24
+				}
+				if ri.Restored == nil {
+					err = badRequest("restore time of %s is unknown, the janitor keeps it", ri.Index)
+					break
+				}
+				err = rt.janitor.extend(ri.Index, *ri.Restored, time.Duration(request.Values.Hours)*time.Hour)
+			}
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", ri.Index, "\t", requestUser(r))
+			rt.record(event{User: requestUser(r), Action: request.Action, Index: ri.Index, Details: fmt.Sprintf("hours: %d", request.Values.Hours)})
+
+			restored := time.Time{}
+			if ri.Restored != nil {
+				restored = *ri.Restored
+			}
+			info := rt.janitor.info(ri.Index, restored)
+			j, _ := json.Marshal(info)
+			w.Write(j)
+		}
+
 	case "get_snapshots":
 		{
 			if request.Values.Repo == "" {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+				rt.fail(w, r, request.Action, badRequest("repo is required"))
 				return
 			}
-			response, err := rt.doGet(rt.conf.Elastic.Host + "_cat/snapshots/" + request.Values.Repo + "?format=json")
+			q, err := snapshotQueryOf(request)
 			if err != nil {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+				rt.fail(w, r, request.Action, err)
 				return
 			}
-			w.Write(response)
+			page, err := rt.listSnapshots(requestIdentity(r), request.Values.Repo, q)
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			j, _ := json.Marshal(page)
+			w.Write(j)
 		}
 
 	case "get_snapshot":
 		{
 
-			if request.Values.Repo == "" {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+			if request.Values.Repo == "" || request.Values.Snapshot == "" {
+				rt.fail(w, r, request.Action, badRequest("repo and snapshot are required"))
 				return
 			}
 
-			if request.Values.Snapshot == "" {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+			status_response, _, err := rt.catalog.status(request.Values.Repo, request.Values.Snapshot)
+			if err == nil {
+				status_response, err = rt.filterSnapshotIndices(requestIdentity(r), request.Values.Repo, status_response)
+			}
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
 				return
 			}
+			w.Write(status_response)
+		}
 
-			status_response, err := rt.doGet(rt.conf.Elastic.Host + "_snapshot/" + request.Values.Repo + "/" + request.Values.Snapshot + "/_status")
+	case "create_snapshot":
+		{
+			p, err := rt.createSnapshot(r, request)
 			if err != nil {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+				rt.fail(w, r, request.Action, err)
 				return
 			}
-			w.Write(status_response)
+			j, _ := json.Marshal(p)
+			w.Write(j)
 		}
 
-	case "restore":
+	case "delete_snapshot":
 		{
+			if request.Values.Repo == "" || request.Values.Snapshot == "" {
+				rt.fail(w, r, request.Action, badRequest("repo and snapshot are required"))
+				return
+			}
+			// как и в del_index: *, _all и списки удалили бы много снапшотов сразу
+			if !plainIndexName(request.Values.Repo) || !plainIndexName(request.Values.Snapshot) {
+				rt.fail(w, r, request.Action, badRequest("repo and snapshot must be single names, not patterns or lists"))
+				return
+			}
+			response, err := rt.doDel(rt.conf.Elastic.Host + "_snapshot/" + request.Values.Repo + "/" + request.Values.Snapshot)
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			rt.catalog.forget(request.Values.Repo, request.Values.Snapshot)
+			log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", request.Values.Repo, "\t", request.Values.Snapshot, "\t", requestUser(r))
+			rt.record(event{User: requestUser(r), Action: request.Action, Repo: request.Values.Repo, Snapshot: request.Values.Snapshot})
+			w.Write(response)
+		}
 
-			if request.Values.Repo == "" {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+	case "get_catalog":
+		{
+			j, _ := json.Marshal(rt.catalogInfo(requestIdentity(r)))
+			w.Write(j)
+		}
+
+	case "refresh_catalog":
+		{
+			var err error
+			if request.Values.Repo != "" {
+				err = rt.catalog.refreshOne(request.Values.Repo)
+			} else {
+				err = rt.catalog.refreshAll()
+			}
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
 				return
 			}
+			log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", request.Values.Repo, "\t", requestUser(r))
+			j, _ := json.Marshal(rt.catalogInfo(requestIdentity(r)))
+			w.Write(j)
+		}
 
-			if request.Values.Snapshot == "" {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+	case "find_index":
+		{
+			res, err := rt.findIndex(requestIdentity(r), request)
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
 				return
 			}
+			j, _ := json.Marshal(res)
+			w.Write(j)
+		}
 
-			status_response, err := rt.doGet(rt.conf.Elastic.Host + "_snapshot/" + request.Values.Repo + "/" + request.Values.Snapshot + "/_status")
+	case "restore":
+		{
+			plan, err := rt.planRestore(r, request)
 			if err != nil {
-				http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", r.UserAgent())
+				rt.fail(w, r, request.Action, err)
 				return
 			}
-			var snap_status snapStatus
-			_ = json.Unmarshal(status_response, &snap_status)
+			res, status, err := rt.submitRestore(r, request, plan)
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			rt.writeRestoreResult(w, r, status, res)
+		}
 
-			indices := make(IndicesInSnap)
+	case "cancel_job":
+		{
+			if request.Values.JobId == "" {
+				rt.fail(w, r, request.Action, badRequest("job_id is required"))
+				return
+			}
+			job, err := rt.cancelJob(r, request.Values.JobId)
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			j, _ := json.Marshal(job)
+			w.Write(j)
+		}
 
-			for _, iname := range request.Values.Indices {
-				ind := snap_status.Snapshots[0].Indices[iname]
-				indices[iname] = &IndexInSnap{}
-				indices[iname].Size = ind.Stats.Total.Size
-				if ind.ShardsStats.Total > 0 {
-					for s := range snap_status.Snapshots[0].Indices[iname].Shards {
-						indices[iname].Shards = append(indices[iname].Shards, snap_status.Snapshots[0].Indices[iname].Shards[s].Stats.Total.Size)
-					}
+	case "list_schedules":
+		{
+			id := requestIdentity(r)
+			list := []schedule{}
+			for _, s := range rt.schedules.all() {
+				if rt.isAdmin(id) || rt.visibleRepository(id, s.Repo) {
+					list = append(list, s)
 				}
 			}
+			j, _ := json.Marshal(list)
+			w.Write(j)
+		}
 
-			index_list_for_restore, index_list_not_restore := rt.Barrel(indices)
-			t := time.Now()
-			req := map[string]interface{}{
-				"ignore_unavailable":   false,
-				"include_global_state": false,
-				"include_aliases":      false,
-				"rename_pattern":       "(.+)",
-				"rename_replacement":   fmt.Sprintf("extracted_$1-%s", t.Format("02-01-2006")),
-				"indices":              index_list_for_restore,
-				"index_settings":       map[string]interface{}{"index.number_of_replicas": 0},
+	case "get_schedule":
+		{
+			s, err := rt.schedules.get(request.Values.ScheduleId)
+			if err == nil && !rt.isAdmin(requestIdentity(r)) && !rt.visibleRepository(requestIdentity(r), s.Repo) {
+				err = notFound("schedule %s not found", request.Values.ScheduleId)
+			}
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			info := scheduleInfo{schedule: s}
+			info.History, err = rt.scheduleHistoryOf(s.ID)
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
 			}
+			j, _ := json.Marshal(info)
+			w.Write(j)
+		}
 
-			response, err := rt.doPost(rt.conf.Elastic.Host+"_snapshot/"+request.Values.Repo+"/"+request.Values.Snapshot+"/_restore?wait_for_completion=false", req)
+	case "create_schedule", "update_schedule":
+		{
+			s := schedule{Enabled: true}
+			if request.Action == "update_schedule" {
+				var err error
+				s, err = rt.schedules.get(request.Values.ScheduleId)
+				if err == nil {
+					err = rt.ownerOrAdmin(r, request.Action, s.Owner.Name)
+				}
+				if err != nil {
+					rt.fail(w, r, request.Action, err)
+					return
+				}
+			}
+			scheduleFromValues(&s, request)
+			err := rt.checkSchedule(r, &s)
 			if err != nil {
-				msg := fmt.Sprintf("{\"error\":\"%s\"}", err)
-				http.Error(w, msg, 500)
-				//http.Error(w, err.Error(), 500)
-				log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", request.Action, "\t", 500, "\t", err.Error(), "\t", response)
+				rt.fail(w, r, request.Action, err)
 				return
 			}
+			if s.ID == "" {
+				s.ID = newJobID()
+				s.Created = time.Now()
+			}
+			// расписание проверено с правами редактора, с ними оно и выполняется
+			s.Owner = identity{Name: requestUser(r)}
+			if ri := requestIdentity(r); ri != nil {
+				s.Owner = *ri
+			}
+			err = rt.schedules.save(s)
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			rt.record(event{User: requestUser(r), Action: request.Action, Repo: s.Repo, Snapshot: s.Snapshot, Schedule: s.ID, Details: s.Name + " " + s.Cron})
+			j, _ := json.Marshal(s)
+			w.Write(j)
+		}
 
-			if len(index_list_not_restore) > 0 {
-				msg := fmt.Sprintf("{\"message\":\"Indices '%v' will not be restored: Not enough space\", \"error\":1}", index_list_not_restore)
-				w.Write([]byte(msg))
+	case "delete_schedule":
+		{
+			s, err := rt.schedules.get(request.Values.ScheduleId)
+			if err == nil {
+				err = rt.ownerOrAdmin(r, request.Action, s.Owner.Name)
 			}
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			s.Deleted, s.Next = true, nil
+			err = rt.schedules.save(s)
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			rt.record(event{User: requestUser(r), Action: request.Action, Repo: s.Repo, Schedule: s.ID, Details: s.Name})
+			j, _ := json.Marshal(s)
+			w.Write(j)
+		}
 
-			msg := fmt.Sprintf("{\"message\":\"Indices '%v' will be restored\", \"error\":0}", index_list_for_restore)
-			w.Write([]byte(msg))
+	case "run_schedule":
+		{
+			s, err := rt.schedules.get(request.Values.ScheduleId)
+			if err == nil {
+				err = rt.ownerOrAdmin(r, request.Action, s.Owner.Name)
+			}
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			// отказ по месту или конфликтам отдаём как restore, с rejected
+			res, status, err := rt.runSchedule(s, requestUser(r))
+			if err != nil && status == 0 {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			rt.writeRestoreResult(w, r, status, res)
+		}
 
+	case "preview_restore":
+		{
+			plan, err := rt.planRestore(r, request)
+			if err == nil {
+				err = rt.estimate(plan)
+			}
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			j, _ := json.Marshal(plan)
+			w.Write(j)
 		}
 
-	default:
+	case "get_restore_options":
 		{
-			http.Error(w, "Service Unavailable", http.StatusServiceUnavailable)
-			log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", http.StatusServiceUnavailable, "\t", "Invalid request method ", "\t", r.UserAgent())
-			return
+			j, _ := json.Marshal(rt.restoreLimits())
+			w.Write(j)
+		}
 
+	case "get_user":
+		{
+			id := identity{Name: requestUser(r)}
+			if ri := requestIdentity(r); ri != nil {
+				id = *ri
+			}
+			id.Roles = rt.roles(&id)
+			j, _ := json.Marshal(id)
+			w.Write(j)
 		}
 
-	}
-}
+	case "get_job":
+		{
+			if request.Values.JobId == "" {
+				rt.fail(w, r, request.Action, badRequest("job_id is required"))
+				return
+			}
+			job, err := rt.jobs.get(request.Values.JobId)
+			// чужие задания по недоступным индексам не отличить от несуществующих
+			if err == nil && !rt.visibleJob(requestIdentity(r), job.restoreJob) {
+				err = fmt.Errorf("job %s not found", request.Values.JobId)
+			}
+			if err != nil {
+				rt.fail(w, r, request.Action, notFound("%s", err))
+				return
+			}
+			j, _ := json.Marshal(job)
+			w.Write(j)
+		}
 
-func (rt *Router) Barrel(array IndicesInSnap) ([]string, []string) {
-	var (
-		k  int
-		Sk int
-		a  []string
-		b  []string
-	)
+	case "get_history":
+		{
+			id := requestIdentity(r)
+			limit := request.Values.Limit
+			if !rt.isAdmin(id) {
+				// фильтр после limit отдал бы меньше событий, чем просили
+				limit = 0
+			}
+			list, err := rt.store.Events(limit)
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			if !rt.isAdmin(id) {
+				visible := []event{}
+				for _, e := range list {
+					if rt.visibleEvent(id, e) {
+						visible = append(visible, e)
+					}
+				}
+				list = lastEvents(visible, request.Values.Limit)
+			}
+			j, _ := json.Marshal(list)
+			w.Write(j)
+		}
 
-	for name, ind := range array {
-		for n := range rt.nodes.list {
-			for m := range ind.Shards {
-				k = rt.nodes.list[n] / ind.Shards[m]
-				Sk = Sk + k
+	case "list_jobs":
+		{
+			list, err := rt.jobs.all()
+			if err != nil {
+				rt.fail(w, r, request.Action, err)
+				return
+			}
+			id := requestIdentity(r)
+			visible := []*jobProgress{}
+			for _, job := range list {
+				if rt.visibleJob(id, job.restoreJob) {
+					visible = append(visible, job)
+				}
 			}
+			j, _ := json.Marshal(visible)
+			w.Write(j)
 		}
 
-		if Sk > len(ind.Shards) {
-			a = append(a, name)
-		} else {
-			b = append(b, name)
+	default:
+		{
+			rt.fail(w, r, request.Action, badRequest("unknown action %q", request.Action))
+			return
+
 		}
+
 	}
-	return a, b
 }
 
 func (rt *Router) getNodes() ([]singleNode, error) {
 
 	var nresp []singleNode
-	var na nodesArray
-
-	//	rt.nodes.RLock()
-	//	defer rt.nodes.RUnlock()
 
 	response, err := rt.doGet(rt.conf.Elastic.Host + "_cat/nodes?format=json&bytes=b&h=ip,name,dt,du,dup,d&s=name")
 	if err != nil {
@@ -388,15 +866,9 @@ func (rt *Router) getNodes() ([]singleNode, error) {
 	if err != nil {
 		return nil, err
 	}
-	s := 0
 	for i, n := range nresp {
 		nresp[i].Dt = fmt.Sprintf("%dGb", helpers.Atoi(n.Dt)/(1024*1024*1024))
-		na.list = append(na.list, helpers.Atoi(n.D))
-		s += helpers.Atoi(n.D)
 	}
-	na.sum = s
-	na.max = helpers.GetMaxValueInArray(na.list)
-	rt.nodes = na
 	return nresp, nil
 
 }
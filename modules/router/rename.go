@@ -0,0 +1,94 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// renameVars - values substituted into the indices.rename template
+type renameVars struct {
+	Repo     string
+	Snapshot string
+	User     string
+	Time     time.Time
+	Suffix   string // -1, -2... when the name is already taken
+}
+
+// символы, запрещённые в имени индекса elasticsearch
+var indexNameReplacer = strings.NewReplacer(
+	"\\", "-", "/", "-", "*", "-", "?", "-", "\"", "-", "<", "-", ">", "-",
+	"|", "-", " ", "-", ",", "-", "#", "-", ":", "-", "$", "-",
+)
+
+// plainIndexName reports whether s names a single index, not a pattern, a list or an API path
+func plainIndexName(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.HasPrefix(s, "_") && !strings.HasPrefix(s, "-") &&
+		!strings.ContainsAny(s, "\\/*?\"<>| ,#:")
+}
+
+func sanitizeIndexPart(s string) string {
+	return indexNameReplacer.Replace(strings.ToLower(s))
+}
+
+// renameReplacement builds the rename_replacement for _restore. {index}
+// becomes $1, which refers to the original name captured by rename_pattern "(.+)".
+func (rt *Router) renameReplacement(v renameVars) string {
+	user := v.User
+	if user == "" {
+		user = "anonymous"
+	}
+	r := strings.NewReplacer(
+		"{prefix}", sanitizeIndexPart(rt.conf.Indices.Prefix),
+		"{index}", "$1",
+		"{date}", sanitizeIndexPart(v.Time.Format(rt.conf.Indices.DateFormat)),
+		"{snapshot}", sanitizeIndexPart(v.Snapshot),
+		"{repo}", sanitizeIndexPart(v.Repo),
+		"{user}", sanitizeIndexPart(user),
+	)
+	return r.Replace(rt.conf.Indices.Rename) + v.Suffix
+}
+
+// restoredName returns the name index will get after restore
+func (rt *Router) restoredName(index string, v renameVars) string {
+	return strings.Replace(rt.renameReplacement(v), "$1", index, -1)
+}
+
+// restoredPattern - pattern matching every index restored by the extractor,
+// derived from indices.rename: every placeholder but {prefix} becomes *
+func (rt *Router) restoredPattern() string {
+	r := strings.NewReplacer(
+		"{prefix}", sanitizeIndexPart(rt.conf.Indices.Prefix),
+		"{index}", "*", "{date}", "*", "{snapshot}", "*", "{repo}", "*", "{user}", "*",
+	)
+	pattern := r.Replace(rt.conf.Indices.Rename)
+	for strings.Contains(pattern, "**") {
+		pattern = strings.Replace(pattern, "**", "*", -1)
+	}
+	// суффикс -1, -2... добавляется после шаблона
+	if !strings.HasSuffix(pattern, "*") {
+		pattern += "*"
+	}
+	return pattern
+}
+
+// requestUser returns the name of the user who made the request
+func requestUser(r *http.Request) string {
+	if id := requestIdentity(r); id != nil {
+		return id.Name
+	}
+	return "anonymous"
+}
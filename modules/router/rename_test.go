@@ -0,0 +1,69 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"path"
+	"testing"
+	"time"
+)
+
+func TestRestoredName(t *testing.T) {
+	vars := renameVars{Repo: "Archive", Snapshot: "snap 2020/11", User: "Bob", Time: time.Date(2020, 11, 1, 10, 0, 0, 0, time.UTC)}
+	tests := []struct {
+		conf        string
+		vars        renameVars
+		replacement string
+		name        string
+		pattern     string
+	}{
+		{conf: "", vars: vars, replacement: "extracted_$1-01-11-2020", name: "extracted_logs-a-01-11-2020", pattern: "extracted_*-*"},
+		{conf: "indices:\n  prefix: Restored\n  rename: \"{prefix}-{repo}-{snapshot}-{index}\"\n", vars: vars,
+			replacement: "restored-archive-snap-2020-11-$1", name: "restored-archive-snap-2020-11-logs-a", pattern: "restored-*-*-*"},
+		{conf: "indices:\n  rename: \"{prefix}_{user}_{index}_{date}\"\n  date_format: \"2006.01.02\"\n", vars: vars,
+			replacement: "extracted_bob_$1_2020.11.01", name: "extracted_bob_logs-a_2020.11.01", pattern: "extracted_*_*_*"},
+		// без пользователя и с суффиксом занятого имени
+		{conf: "indices:\n  rename: \"{prefix}_{user}_{index}\"\n", vars: renameVars{Suffix: "-2"},
+			replacement: "extracted_anonymous_$1-2", name: "extracted_anonymous_logs-a-2", pattern: "extracted_*_*"},
+		{conf: "indices:\n  rename: \"{index}-{prefix}\"\n", vars: vars,
+			replacement: "$1-extracted", name: "logs-a-extracted", pattern: "*-extracted*"},
+	}
+	for _, tt := range tests {
+		rt := &Router{conf: testConfig(t, tt.conf)}
+		if got := rt.renameReplacement(tt.vars); got != tt.replacement {
+			t.Errorf("%q: renameReplacement %q, want %q", tt.conf, got, tt.replacement)
+		}
+		name := rt.restoredName("logs-a", tt.vars)
+		if name != tt.name {
+			t.Errorf("%q: restoredName %q, want %q", tt.conf, name, tt.name)
+		}
+		pattern := rt.restoredPattern()
+		if pattern != tt.pattern {
+			t.Errorf("%q: restoredPattern %q, want %q", tt.conf, pattern, tt.pattern)
+		}
+		// janitor находит восстановленный индекс по шаблону
+		if ok, _ := path.Match(pattern, name); !ok {
+			t.Errorf("%q: pattern %q does not match %q", tt.conf, pattern, name)
+		}
+	}
+}
+
+func TestRenameRequiresPrefix(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("rename without {prefix} accepted")
+		}
+	}()
+	testConfig(t, "indices:\n  rename: \"{index}-restored\"\n")
+}
@@ -0,0 +1,164 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// repositoryInfo - registered snapshot repository
+type repositoryInfo struct {
+	Name     string                 `json:"name"`
+	Type     string                 `json:"type"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+// repositoryType - settings accepted for a repository type
+type repositoryType struct {
+	required []string
+	optional []string
+}
+
+// общие для всех типов настройки
+var repositoryCommon = []string{"readonly", "compress", "chunk_size", "max_snapshot_bytes_per_sec", "max_restore_bytes_per_sec"}
+
+var repositoryTypes = map[string]repositoryType{
+	"fs":    {required: []string{"location"}, optional: []string{"max_number_of_snapshots"}},
+	"url":   {required: []string{"url"}, optional: []string{"http_max_retries", "http_socket_timeout", "max_number_of_snapshots"}},
+	"s3":    {required: []string{"bucket"}, optional: []string{"client", "base_path", "buffer_size", "server_side_encryption", "storage_class", "canned_acl"}},
+	"gcs":   {required: []string{"bucket"}, optional: []string{"client", "base_path", "application_name"}},
+	"azure": {optional: []string{"client", "container", "base_path", "location_mode"}},
+}
+
+// схемы, которые ES разрешает для url-репозиториев
+var repositoryURLSchemes = []string{"http", "https", "ftp", "file", "jar"}
+
+// checkRepository validates the name, the type and the settings of a repository to register
+func checkRepository(name, typ string, settings map[string]interface{}) error {
+	if name == "" {
+		return badRequest("repo is required")
+	}
+	if sanitizeIndexPart(name) != name || !plainIndexName(name) {
+		return badRequest("bad repository name %q", name)
+	}
+	t, ok := repositoryTypes[typ]
+	if !ok {
+		var types []string
+		for k := range repositoryTypes {
+			types = append(types, k)
+		}
+		sort.Strings(types)
+		return badRequest("type must be one of %s", strings.Join(types, ", "))
+	}
+
+	known := make(map[string]bool)
+	for _, list := range [][]string{repositoryCommon, t.required, t.optional} {
+		for _, k := range list {
+			known[k] = true
+		}
+	}
+	for k, v := range settings {
+		if !known[k] {
+			// ключи доступа в настройках видны всем, их место - keystore
+			if strings.Contains(k, "key") || strings.Contains(k, "secret") {
+				return badRequest("%s of %s repositories belongs to the Elasticsearch keystore", k, typ)
+			}
+			return badRequest("unknown setting %s of %s repository", k, typ)
+		}
+		switch k {
+		case "readonly", "compress":
+			if _, ok := v.(bool); !ok {
+				return badRequest("%s must be true or false", k)
+			}
+		}
+	}
+	for _, k := range t.required {
+		if s, _ := settings[k].(string); s == "" {
+			return badRequest("%s repository needs settings.%s", typ, k)
+		}
+	}
+
+	if typ == "url" {
+		u, err := url.Parse(settings["url"].(string))
+		if err != nil || !matchAny(repositoryURLSchemes, u.Scheme) {
+			return badRequest("url must use one of %s", strings.Join(repositoryURLSchemes, ", "))
+		}
+	}
+	return nil
+}
+
+// createRepository registers the repository or changes its settings, Elasticsearch verifies it on every node
+func (rt *Router) createRepository(request *apiRequest) (*repositoryInfo, error) {
+	err := checkRepository(request.Values.Repo, request.Values.Type, request.Values.Settings)
+	if err != nil {
+		return nil, err
+	}
+	settings := request.Values.Settings
+	if settings == nil {
+		settings = map[string]interface{}{}
+	}
+	_, err = rt.doPut(rt.conf.Elastic.Host+"_snapshot/"+request.Values.Repo, map[string]interface{}{
+		"type":     request.Values.Type,
+		"settings": settings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rt.catalog.forget(request.Values.Repo, "")
+	return rt.getRepository(request.Values.Repo)
+}
+
+func (rt *Router) getRepository(name string) (*repositoryInfo, error) {
+	response, err := rt.doGet(rt.conf.Elastic.Host + "_snapshot/" + name)
+	if err != nil {
+		return nil, err
+	}
+	var list map[string]repositoryInfo
+	err = json.Unmarshal(response, &list)
+	if err != nil {
+		return nil, upstreamError(http.StatusBadGateway, "bad repository: "+err.Error())
+	}
+	info, ok := list[name]
+	if !ok {
+		return nil, notFound("repository %s not found", name)
+	}
+	info.Name = name
+	return &info, nil
+}
+
+// deleteRepository unregisters the repository, snapshots stay in the storage.
+// Repositories used by schedules are kept.
+func (rt *Router) deleteRepository(name string) ([]byte, error) {
+	if name == "" {
+		return nil, badRequest("repo is required")
+	}
+	// * или a,b удалили бы все репозитории мимо проверки расписаний
+	if !plainIndexName(name) {
+		return nil, badRequest("repo must be a single name, not a pattern or a list")
+	}
+	for _, s := range rt.schedules.all() {
+		if s.Repo == name {
+			return nil, &apiError{Status: http.StatusConflict, Message: "repository " + name + " is used by schedule " + s.Name}
+		}
+	}
+	response, err := rt.doDel(rt.conf.Elastic.Host + "_snapshot/" + name)
+	if err == nil {
+		rt.catalog.forget(name, "")
+	}
+	return response, err
+}
@@ -0,0 +1,99 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCheckRepository(t *testing.T) {
+	tests := []struct {
+		name     string
+		repo     string
+		typ      string
+		settings map[string]interface{}
+		ok       bool
+	}{
+		{name: "fs", repo: "archive", typ: "fs", settings: map[string]interface{}{"location": "/mnt/backup", "compress": true}, ok: true},
+		{name: "fs without location", repo: "archive", typ: "fs", settings: map[string]interface{}{"compress": true}},
+		{name: "fs with empty location", repo: "archive", typ: "fs", settings: map[string]interface{}{"location": ""}},
+		{name: "url", repo: "archive", typ: "url", settings: map[string]interface{}{"url": "https://backup.example.com/es/"}, ok: true},
+		{name: "url file", repo: "archive", typ: "url", settings: map[string]interface{}{"url": "file:///mnt/backup"}, ok: true},
+		{name: "url without url", repo: "archive", typ: "url"},
+		{name: "url scheme", repo: "archive", typ: "url", settings: map[string]interface{}{"url": "gopher://backup.example.com/"}},
+		{name: "url as a number", repo: "archive", typ: "url", settings: map[string]interface{}{"url": 1}},
+		{name: "s3", repo: "archive", typ: "s3", settings: map[string]interface{}{"bucket": "backups", "base_path": "es", "client": "default"}, ok: true},
+		{name: "s3 without bucket", repo: "archive", typ: "s3", settings: map[string]interface{}{"base_path": "es"}},
+		{name: "s3 secret", repo: "archive", typ: "s3", settings: map[string]interface{}{"bucket": "backups", "secret_key": "x"}},
+		{name: "s3 access key", repo: "archive", typ: "s3", settings: map[string]interface{}{"bucket": "backups", "access_key": "x"}},
+		{name: "gcs", repo: "archive", typ: "gcs", settings: map[string]interface{}{"bucket": "backups"}, ok: true},
+		{name: "gcs without bucket", repo: "archive", typ: "gcs"},
+		{name: "s3 setting of gcs", repo: "archive", typ: "gcs", settings: map[string]interface{}{"bucket": "backups", "storage_class": "standard"}},
+		{name: "azure", repo: "archive", typ: "azure", ok: true},
+		{name: "azure container", repo: "archive", typ: "azure", settings: map[string]interface{}{"container": "backups", "readonly": true}, ok: true},
+		{name: "azure location", repo: "archive", typ: "azure", settings: map[string]interface{}{"location": "/mnt/backup"}},
+		{name: "readonly as a string", repo: "archive", typ: "fs", settings: map[string]interface{}{"location": "/mnt/backup", "readonly": "true"}},
+		{name: "compress as a number", repo: "archive", typ: "fs", settings: map[string]interface{}{"location": "/mnt/backup", "compress": 1}},
+		{name: "unknown type", repo: "archive", typ: "hdfs", settings: map[string]interface{}{"uri": "hdfs://namenode/"}},
+		{name: "no type", repo: "archive"},
+
+		{name: "no name", typ: "azure"},
+		{name: "upper case", repo: "Archive", typ: "azure"},
+		{name: "underscore", repo: "_all", typ: "azure"},
+		{name: "dash", repo: "-archive", typ: "azure"},
+		{name: "pattern", repo: "arch*", typ: "azure"},
+		{name: "list", repo: "a,b", typ: "azure"},
+		{name: "path", repo: "archive/x", typ: "azure"},
+	}
+	for _, tt := range tests {
+		err := checkRepository(tt.repo, tt.typ, tt.settings)
+		if tt.ok && err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		if !tt.ok && httpStatus(err) != http.StatusBadRequest {
+			t.Errorf("%s: %v, want 400", tt.name, err)
+		}
+	}
+}
+
+func TestDeleteRepository(t *testing.T) {
+	es := newFakeES(t, func(method, path string) (int, string) {
+		return http.StatusOK, `{"acknowledged":true}`
+	})
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n")
+	rt.catalog = newCatalog(rt)
+	rt.schedules = newScheduler(rt)
+	rt.schedules.save(schedule{ID: "s1", Name: "nightly", Repo: "archive"})
+
+	// шаблоны и списки удалили бы и archive, которым пользуется расписание
+	for _, name := range []string{"", "*", "arch*", "_all", "a,b", "archive,other", "-archive", "archive/x"} {
+		if _, err := rt.deleteRepository(name); httpStatus(err) != http.StatusBadRequest {
+			t.Errorf("deleteRepository(%q): %v, want 400", name, err)
+		}
+	}
+	if _, err := rt.deleteRepository("archive"); httpStatus(err) != http.StatusConflict {
+		t.Errorf("deleteRepository of a scheduled repository: %v, want 409", err)
+	}
+	if len(es.requests()) != 0 {
+		t.Fatalf("requests sent: %+v", es.requests())
+	}
+
+	if _, err := rt.deleteRepository("other"); err != nil {
+		t.Fatal(err)
+	}
+	if calls := es.requests(); len(calls) != 1 || calls[0].Method != http.MethodDelete || calls[0].Path != "/_snapshot/other" {
+		t.Fatalf("requests %+v, want DELETE /_snapshot/other", calls)
+	}
+}
@@ -0,0 +1,493 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uzhinskiy/lib.go/helpers"
+)
+
+// restorePlan - what a restore of the snapshot would do
+type restorePlan struct {
+	Repo       string              `json:"repo"`
+	Snapshot   string              `json:"snapshot"`
+	Indices    []string            `json:"indices"`
+	Replicas   int                 `json:"replicas"`
+	Accepted   []string            `json:"accepted"`
+	Rejected   map[string]string   `json:"rejected,omitempty"`
+	Targets    map[string]string   `json:"targets"`             // original name -> restored name
+	Conflicts  map[string]conflict `json:"conflicts,omitempty"` // original name -> existing index
+	Size       int64               `json:"size_bytes"`
+	Throughput float64             `json:"shard_bytes_per_second,omitempty"`
+	ETA        int64               `json:"eta_seconds,omitempty"`
+	Placement  placement           `json:"placement"`
+	vars       renameVars
+	suffixes   map[string]string
+}
+
+// conflict - an index whose restored name is already taken and what is done about it
+type conflict struct {
+	Existing string `json:"existing"`
+	Strategy string `json:"strategy"`
+	Target   string `json:"target,omitempty"` // empty for fail and skip
+}
+
+var conflictStrategies = map[string]bool{"fail": true, "suffix": true, "skip": true, "replace": true}
+
+// скорость восстановления считаем по последним 7 дням
+const throughputWindow = 7 * 24 * time.Hour
+
+// планировщик ES по умолчанию восстанавливает 2 шарда на узел
+const recoveriesPerNode = 2
+
+// planRestore selects indices of the snapshot, checks access and capacity
+// and resolves restored names. Nothing is changed in the cluster.
+func (rt *Router) planRestore(r *http.Request, request *apiRequest) (*restorePlan, error) {
+	if request.Values.Repo == "" || request.Values.Snapshot == "" {
+		return nil, badRequest("repo and snapshot are required")
+	}
+	replicas, err := rt.checkOptions(&request.Values.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	status_response, _, err := rt.catalog.status(request.Values.Repo, request.Values.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	var snap_status snapStatus
+	err = json.Unmarshal(status_response, &snap_status)
+	if err != nil {
+		return nil, upstreamError(http.StatusBadGateway, "bad snapshot status: "+err.Error())
+	}
+	if len(snap_status.Snapshots) == 0 {
+		return nil, notFound("snapshot %s not found", request.Values.Snapshot)
+	}
+
+	var names []string
+	for iname := range snap_status.Snapshots[0].Indices {
+		names = append(names, iname)
+	}
+	selected, err := selectIndices(names, request.Values.Indices, request.Values.From, request.Values.To)
+	if err != nil {
+		return nil, err
+	}
+	// шаблоны проверены rbac только по виду, проверяем найденные индексы
+	check := *request
+	check.Values.Indices = selected
+	err = rt.authorizeRequest(r, &check)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := make(IndicesInSnap)
+
+	for _, iname := range selected {
+		ind := snap_status.Snapshots[0].Indices[iname]
+		indices[iname] = &IndexInSnap{}
+		indices[iname].Size = ind.Stats.Total.Size
+		if ind.ShardsStats.Total > 0 {
+			for s := range snap_status.Snapshots[0].Indices[iname].Shards {
+				indices[iname].Shards = append(indices[iname].Shards, snap_status.Snapshots[0].Indices[iname].Shards[s].Stats.Total.Size)
+			}
+		}
+	}
+
+	placement, err := rt.Barrel(indices, replicas)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &restorePlan{
+		Repo:      request.Values.Repo,
+		Snapshot:  request.Values.Snapshot,
+		Indices:   selected,
+		Replicas:  replicas,
+		Accepted:  placement.Accepted(),
+		Rejected:  placement.Rejected(),
+		Targets:   make(map[string]string),
+		Conflicts: make(map[string]conflict),
+		Placement: placement,
+		vars: renameVars{
+			Repo:     request.Values.Repo,
+			Snapshot: request.Values.Snapshot,
+			User:     requestUser(r),
+			Time:     time.Now(),
+		},
+		suffixes: make(map[string]string),
+	}
+	for _, iname := range p.Accepted {
+		p.Targets[iname] = rt.restoredName(iname, p.vars)
+	}
+
+	existing, err := rt.existingIndices(p.Targets)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]bool)
+	for _, t := range p.Targets {
+		taken[t] = true
+	}
+	strategy := request.Values.Options.Conflict
+	var accepted []string
+	for _, iname := range p.Accepted {
+		t := p.Targets[iname]
+		if existing[t] {
+			c := conflict{Existing: t, Strategy: strategy}
+			switch strategy {
+			case "skip":
+				delete(p.Targets, iname)
+				p.Conflicts[iname] = c
+				continue
+			case "suffix":
+				v := p.vars
+				for n := 1; ; n++ {
+					v.Suffix = "-" + strconv.Itoa(n)
+					c.Target = rt.restoredName(iname, v)
+					if !existing[c.Target] && !taken[c.Target] {
+						break
+					}
+				}
+				taken[c.Target] = true
+				p.Targets[iname] = c.Target
+				p.suffixes[iname] = v.Suffix
+			case "replace":
+				c.Target = t
+			}
+			p.Conflicts[iname] = c
+		}
+		accepted = append(accepted, iname)
+		p.Size += int64(indices[iname].Size) * int64(replicas+1)
+	}
+	p.Accepted = accepted
+	return p, nil
+}
+
+// describeRejected lists rejected and skipped indices with the reasons
+func (p *restorePlan) describeRejected(rejected map[string]string) string {
+	var list []string
+	for i, reason := range rejected {
+		list = append(list, i+" ("+reason+")")
+	}
+	for i, c := range p.Conflicts {
+		if c.Strategy == "skip" {
+			list = append(list, i+" ("+c.Existing+" exists)")
+		}
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
+
+// writeRestoreResult answers restore, the result without accepted indices carries the error envelope too
+func (rt *Router) writeRestoreResult(w http.ResponseWriter, r *http.Request, status int, res restoreResult) {
+	if len(res.Accepted) == 0 {
+		res.Error = res.Message
+		res.Status = status
+	}
+	remoteIP := helpers.GetIP(r.RemoteAddr, r.Header.Get("X-Real-IP"), r.Header.Get("X-Forwarded-For"))
+	j, _ := json.Marshal(res)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(j)
+	log.Println(remoteIP, "\t", r.Method, "\t", r.URL.Path, "\t", "restore", "\t", status, "\t", res.Message, "\t", r.UserAgent())
+}
+
+// existingIndices returns indices of the cluster whose names start with one of the targets
+func (rt *Router) existingIndices(targets map[string]string) (map[string]bool, error) {
+	existing := make(map[string]bool)
+	if len(targets) == 0 {
+		return existing, nil
+	}
+	var patterns []string
+	for _, t := range targets {
+		// шаблон вместо имени, чтобы отсутствующие индексы не давали 404
+		patterns = append(patterns, t+"*")
+	}
+	sort.Strings(patterns)
+	response, err := rt.doGet(rt.conf.Elastic.Host + "_cat/indices/" + strings.Join(patterns, ",") + "?format=json&h=index&expand_wildcards=all")
+	if err != nil {
+		return nil, err
+	}
+	var cat []map[string]string
+	err = json.Unmarshal(response, &cat)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cat {
+		existing[c["index"]] = true
+	}
+	return existing, nil
+}
+
+// checkConflicts refuses the restore for the fail strategy and checks
+// the confirmation and permissions of the replace strategy. Returns indices to replace.
+func (rt *Router) checkConflicts(r *http.Request, request *apiRequest, p *restorePlan) ([]string, error) {
+	var failed, replaced []string
+	for _, c := range p.Conflicts {
+		switch c.Strategy {
+		case "fail":
+			failed = append(failed, c.Existing)
+		case "replace":
+			replaced = append(replaced, c.Existing)
+		}
+	}
+	sort.Strings(failed)
+	sort.Strings(replaced)
+	if len(failed) > 0 {
+		return nil, &apiError{Status: http.StatusConflict, Message: "indices already exist: " + strings.Join(failed, ", ")}
+	}
+	if len(replaced) == 0 {
+		return nil, nil
+	}
+	if !isTrue(request.Values.Options.ConfirmReplace) {
+		return nil, &apiError{Status: http.StatusConflict, Message: "confirm_replace is required to overwrite existing indices: " + strings.Join(replaced, ", ")}
+	}
+	// замена - это удаление, нужны права del_index на старые индексы
+	for _, i := range replaced {
+		check := apiRequest{Action: "del_index"}
+		check.Values.Index = i
+		check.Values.Indices = []string{i}
+		err := rt.authorizeRequest(r, &check)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return replaced, nil
+}
+
+// resolveConflicts checks conflicts and closes the existing indices for the replace
+// strategy: _restore overwrites a closed index with the same number of shards, so the
+// old data stays until the restore is accepted. Returns the closed indices.
+func (rt *Router) resolveConflicts(r *http.Request, request *apiRequest, p *restorePlan) ([]string, error) {
+	replaced, err := rt.checkConflicts(r, request, p)
+	if err != nil {
+		return nil, err
+	}
+	var closed []string
+	for _, i := range replaced {
+		_, err := rt.doPost(rt.conf.Elastic.Host+i+"/_close", nil)
+		if err != nil {
+			rt.reopen(closed)
+			return nil, err
+		}
+		closed = append(closed, i)
+	}
+	return closed, nil
+}
+
+// reopen opens indices closed for a replace that did not happen, errors are only logged
+func (rt *Router) reopen(indices []string) {
+	for _, i := range indices {
+		_, err := rt.doPost(rt.conf.Elastic.Host+i+"/_open", nil)
+		if err != nil {
+			log.Println("Restore: can't reopen", i, "after a failed replace:", err)
+		}
+	}
+}
+
+// submitRestore queues the planned restore or starts it at once,
+// returns the answer with its HTTP status
+func (rt *Router) submitRestore(r *http.Request, request *apiRequest, plan *restorePlan) (restoreResult, int, error) {
+	res := restoreResult{
+		Accepted:  []string{},
+		Rejected:  plan.Rejected,
+		Targets:   plan.Targets,
+		Conflicts: plan.Conflicts,
+	}
+	if len(plan.Accepted) == 0 {
+		res.Message = "Indices will not be restored: " + plan.describeRejected(res.Rejected)
+		return res, http.StatusConflict, nil
+	}
+
+	// при очереди индексы проверяются ещё раз перед запуском
+	if rt.queue.enabled() {
+		_, err := rt.checkConflicts(r, request, plan)
+		if err != nil {
+			return res, 0, err
+		}
+		job := rt.queue.push(r, request, plan)
+		res.JobID = job.ID
+		res.Accepted = plan.Accepted
+		res.Position = rt.queue.position(job.ID)
+		res.Message = fmt.Sprintf("Indices '%v' are queued, position %d", res.Accepted, res.Position)
+	} else {
+		var err error
+		res, err = rt.startRestore(r, request, plan, nil)
+		if err != nil {
+			return res, 0, err
+		}
+		res.Message = fmt.Sprintf("Indices '%v' will be restored", res.Accepted)
+	}
+	if len(res.Rejected) > 0 {
+		res.Message += ", will not be restored: " + plan.describeRejected(res.Rejected)
+		return res, http.StatusMultiStatus, nil
+	}
+	return res, http.StatusAccepted, nil
+}
+
+// startRestore resolves conflicts and issues _restore for the accepted indices of the plan.
+// job is the queued job being started, nil creates a new one.
+func (rt *Router) startRestore(r *http.Request, request *apiRequest, plan *restorePlan, job *restoreJob) (restoreResult, error) {
+	res := restoreResult{
+		Accepted:  []string{},
+		Rejected:  plan.Rejected,
+		Targets:   plan.Targets,
+		Conflicts: plan.Conflicts,
+	}
+	closed, err := rt.resolveConflicts(r, request, plan)
+	if err != nil {
+		return res, err
+	}
+
+	// индексы с суффиксом восстанавливаются отдельными запросами
+	rv := plan.vars
+	suffixes, groups := plan.restoreGroups()
+	for _, suffix := range suffixes {
+		rv.Suffix = suffix
+		req := rt.restoreBody(request.Values.Options, plan.Replicas, groups[suffix], rv)
+		_, err = rt.doPost(rt.conf.Elastic.Host+"_snapshot/"+plan.Repo+"/"+plan.Snapshot+"/_restore?wait_for_completion=false", req)
+		if err != nil && len(res.Accepted) == 0 {
+			rt.reopen(closed)
+			return res, err
+		}
+		for _, i := range groups[suffix] {
+			if err != nil {
+				res.Rejected[i] = err.Error()
+				delete(res.Targets, i)
+				continue
+			}
+			res.Accepted = append(res.Accepted, i)
+		}
+	}
+	rv.Suffix = ""
+
+	// закрытые индексы, которые восстановление не перезаписало, открываем обратно
+	overwritten := make(map[string]bool)
+	for _, i := range res.Accepted {
+		overwritten[res.Targets[i]] = true
+	}
+	var kept []string
+	for _, i := range closed {
+		if !overwritten[i] {
+			kept = append(kept, i)
+			continue
+		}
+		rt.janitor.forget(i)
+		rt.record(event{User: rv.User, Action: "del_index", Index: i, Repo: rv.Repo, Snapshot: rv.Snapshot, Details: "replaced by restore"})
+	}
+	rt.reopen(kept)
+
+	var restored []string
+	for _, t := range res.Targets {
+		restored = append(restored, t)
+	}
+	rt.janitor.touch(restored, rv.Time)
+	if job == nil {
+		job = &restoreJob{
+			User:     rv.User,
+			Repo:     rv.Repo,
+			Snapshot: rv.Snapshot,
+			Indices:  res.Accepted,
+			Targets:  res.Targets,
+			Reserved: plan.reserved(),
+			Started:  rv.Time,
+		}
+		rt.jobs.add(job)
+	} else {
+		rt.jobs.start(job.ID, res.Accepted, res.Targets, plan.reserved(), rv.Time)
+	}
+	rt.record(event{User: rv.User, Action: "restore", Repo: rv.Repo, Snapshot: rv.Snapshot, Job: job.ID, Details: fmt.Sprintf("restored: %v, rejected: %v, conflicts: %v", res.Targets, res.Rejected, res.Conflicts)})
+
+	res.JobID = job.ID
+	return res, nil
+}
+
+// reserved returns the bytes the plan puts on each node
+func (p *restorePlan) reserved() map[string]int64 {
+	res := make(map[string]int64)
+	for _, n := range p.Placement.Nodes {
+		if size := n.UsedAfter - n.UsedBefore; size > 0 {
+			res[n.Name] = size
+		}
+	}
+	return res
+}
+
+// restoreGroups splits accepted indices by name suffix, rename_replacement of one _restore is common for all indices
+func (p *restorePlan) restoreGroups() ([]string, map[string][]string) {
+	groups := make(map[string][]string)
+	for _, i := range p.Accepted {
+		groups[p.suffixes[i]] = append(groups[p.suffixes[i]], i)
+	}
+	var keys []string
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, groups
+}
+
+// estimate fills the expected transfer time from recent snapshot recoveries
+func (rt *Router) estimate(p *restorePlan) error {
+	response, err := rt.doGet(rt.conf.Elastic.Host + rt.restoredPattern() + "/_recovery/")
+	if err != nil {
+		return err
+	}
+	var rec map[string]struct {
+		Shards []shardRecovery `json:"shards"`
+	}
+	err = json.Unmarshal(response, &rec)
+	if err != nil {
+		return err
+	}
+
+	var bytes, millis int64
+	since := time.Now().Add(-throughputWindow).UnixNano() / int64(time.Millisecond)
+	for _, i := range rec {
+		for _, s := range i.Shards {
+			if s.Type != "SNAPSHOT" || s.Stage != "DONE" || s.StopTime < since || s.StopTime <= s.StartTime {
+				continue
+			}
+			bytes += s.Index.Size.Total
+			millis += s.StopTime - s.StartTime
+		}
+	}
+	if bytes == 0 || millis == 0 {
+		return nil
+	}
+	// скорость одного шарда, шарды восстанавливаются параллельно
+	p.Throughput = float64(bytes) * 1000 / float64(millis)
+	shards := 0
+	for _, v := range p.Placement.Verdicts {
+		if v.Fits {
+			shards += v.Shards * (p.Replicas + 1)
+		}
+	}
+	parallel := len(p.Placement.Nodes) * recoveriesPerNode
+	if shards < parallel {
+		parallel = shards
+	}
+	if parallel > 0 {
+		p.ETA = int64(float64(p.Size) / (p.Throughput * float64(parallel)))
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// esCall - a request received by the fake Elasticsearch
+type esCall struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// fakeES answers every request with the body returned by answer
+// and keeps the requests
+type fakeES struct {
+	sync.Mutex
+	srv   *httptest.Server
+	calls []esCall
+}
+
+func newFakeES(t *testing.T, answer func(method, path string) (int, string)) *fakeES {
+	es := &fakeES{}
+	es.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		es.Lock()
+		es.calls = append(es.calls, esCall{Method: r.Method, Path: r.URL.Path, Body: string(body)})
+		es.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		status, res := answer(r.Method, r.URL.Path)
+		w.WriteHeader(status)
+		w.Write([]byte(res))
+	}))
+	t.Cleanup(es.srv.Close)
+	return es
+}
+
+func (es *fakeES) requests() []esCall {
+	es.Lock()
+	defer es.Unlock()
+	return append([]esCall(nil), es.calls...)
+}
+
+func TestResolveConflictsReplace(t *testing.T) {
+	es := newFakeES(t, func(method, path string) (int, string) {
+		return http.StatusOK, `{"acknowledged":true}`
+	})
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n")
+	p := &restorePlan{Repo: "archive", Snapshot: "s1", Conflicts: map[string]conflict{
+		"logs-a": {Existing: "extracted_logs-a", Strategy: "replace"},
+	}}
+	request := &apiRequest{Action: "restore"}
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/archive/snapshots/s1/restore", nil)
+
+	if _, err := rt.resolveConflicts(r, request, p); httpStatus(err) != http.StatusConflict {
+		t.Fatalf("replace without confirm_replace: %v, want 409", err)
+	}
+	if len(es.requests()) != 0 {
+		t.Fatalf("unconfirmed replace sent %v", es.requests())
+	}
+
+	request.Values.Options.ConfirmReplace = boolp(true)
+	closed, err := rt.resolveConflicts(r, request, p)
+	if err != nil || !reflect.DeepEqual(closed, []string{"extracted_logs-a"}) {
+		t.Fatalf("closed %v, %v", closed, err)
+	}
+	// ES не принимает тело у _close, запрос уходит пустым; удалять нечего,
+	// _restore перезаписывает закрытый индекс
+	want := []esCall{
+		{Method: http.MethodPost, Path: "/extracted_logs-a/_close"},
+	}
+	if got := es.requests(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("requests %+v, want %+v", got, want)
+	}
+}
+
+func TestReplaceKeepsIndexOnFailedRestore(t *testing.T) {
+	for _, restoreFails := range []bool{true, false} {
+		es := newFakeES(t, func(method, path string) (int, string) {
+			if strings.HasSuffix(path, "/_restore") && restoreFails {
+				return http.StatusInternalServerError, `{"error":{"type":"snapshot_restore_exception","reason":"cannot restore index [extracted_logs-a] with [2] shards from a snapshot of index [logs-a] with [1] shards"},"status":500}`
+			}
+			if strings.HasSuffix(path, "/_recovery/") {
+				return http.StatusOK, `{}`
+			}
+			return http.StatusOK, `{"acknowledged":true,"accepted":true}`
+		})
+		rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n")
+		rt.queue = newRestoreQueue(rt)
+		rt.jobs = newJobRegistry(rt)
+		plan := &restorePlan{
+			Repo:      "archive",
+			Snapshot:  "s1",
+			Accepted:  []string{"logs-a"},
+			Rejected:  map[string]string{},
+			Targets:   map[string]string{"logs-a": "extracted_logs-a"},
+			Conflicts: map[string]conflict{"logs-a": {Existing: "extracted_logs-a", Target: "extracted_logs-a", Strategy: "replace"}},
+			vars:      renameVars{Repo: "archive", Snapshot: "s1", Time: time.Now()},
+		}
+		request := &apiRequest{Action: "restore"}
+		request.Values.Options.ConfirmReplace = boolp(true)
+		request.Values.Options.Conflict = "replace"
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/archive/snapshots/s1/restore", nil)
+
+		_, err := rt.startRestore(r, request, plan, nil)
+		if restoreFails != (err != nil) {
+			t.Fatalf("restore fails %v: %v", restoreFails, err)
+		}
+		var calls []string
+		for _, c := range es.requests() {
+			if c.Method == http.MethodDelete {
+				t.Errorf("restore fails %v: the replaced index is deleted: %+v", restoreFails, c)
+			}
+			if !strings.HasSuffix(c.Path, "/_recovery/") {
+				calls = append(calls, c.Method+" "+c.Path)
+			}
+		}
+		want := []string{"POST /extracted_logs-a/_close", "POST /_snapshot/archive/s1/_restore"}
+		if restoreFails {
+			want = append(want, "POST /extracted_logs-a/_open")
+		}
+		if !reflect.DeepEqual(calls, want) {
+			t.Errorf("restore fails %v: requests %v, want %v", restoreFails, calls, want)
+		}
+	}
+}
+
+func TestPlanRestoreErrors(t *testing.T) {
+	const oneIndex = `{"snapshots":[{"snapshot":"s1","state":"SUCCESS","indices":{"logs-a":{"stats":{"total":{"size_in_bytes":100}}}}}]}`
+	tests := []struct {
+		name    string
+		answer  string
+		repo    string
+		indices []string
+		status  int
+	}{
+		{name: "no repo", answer: oneIndex, indices: []string{"logs-a"}, status: http.StatusBadRequest},
+		{name: "empty snapshot list", answer: `{"snapshots":[]}`, repo: "archive", indices: []string{"logs-a"}, status: http.StatusNotFound},
+		{name: "missing index", answer: oneIndex, repo: "archive", indices: []string{"logs-b"}, status: http.StatusNotFound},
+		{name: "unmatched pattern", answer: oneIndex, repo: "archive", indices: []string{"other-*"}, status: http.StatusBadRequest},
+		{name: "bad status", answer: `{"snapshots":`, repo: "archive", indices: []string{"logs-a"}, status: http.StatusBadGateway},
+		{name: "status of another type", answer: `{"snapshots":{"s1":1}}`, repo: "archive", indices: []string{"logs-a"}, status: http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		es := newFakeES(t, func(method, path string) (int, string) {
+			return http.StatusOK, tt.answer
+		})
+		rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n")
+		rt.catalog = newCatalog(rt)
+		request := &apiRequest{Action: "restore"}
+		request.Values.Repo = tt.repo
+		request.Values.Snapshot = "s1"
+		request.Values.Indices = tt.indices
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/archive/snapshots/s1/restore", nil)
+
+		plan, err := rt.planRestore(r, request)
+		if plan != nil || httpStatus(err) != tt.status {
+			t.Errorf("%s: plan %+v, error %v (%d), want %d", tt.name, plan, err, httpStatus(err), tt.status)
+		}
+	}
+}
+
+func TestWriteRestoreResult(t *testing.T) {
+	rt := testRouter(t, "")
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/archive/snapshots/s1/restore", nil)
+
+	tests := []struct {
+		name   string
+		status int
+		res    restoreResult
+		error  string
+	}{
+		{name: "nothing accepted", status: http.StatusConflict, res: restoreResult{Message: "Indices will not be restored: no space"}, error: "Indices will not be restored: no space"},
+		{name: "zero result", status: http.StatusConflict},
+		{name: "partial", status: http.StatusMultiStatus, res: restoreResult{Accepted: []string{"logs-a"}, Rejected: map[string]string{"logs-b": "no space"}, Message: "Restore started"}},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		rt.writeRestoreResult(w, r, tt.status, tt.res)
+		if w.Code != tt.status || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+			t.Errorf("%s: status %d %q, want %d", tt.name, w.Code, w.Header().Get("Content-Type"), tt.status)
+			continue
+		}
+		var got map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("%s: %v in %s", tt.name, err, w.Body)
+			continue
+		}
+		// без принятых индексов ответ несёт и конверт ошибки
+		_, hasStatus := got["status"]
+		if len(tt.res.Accepted) == 0 && (got["error"] != nilIfEmpty(tt.error) || !hasStatus || got["status"] != float64(tt.status)) {
+			t.Errorf("%s: %s, want error %q and status %d", tt.name, w.Body, tt.error, tt.status)
+		}
+		if len(tt.res.Accepted) > 0 && (got["error"] != nil || hasStatus) {
+			t.Errorf("%s: %s has an error envelope", tt.name, w.Body)
+		}
+	}
+}
+
+// nilIfEmpty - how an omitempty string reads back from json
+func nilIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
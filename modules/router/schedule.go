@@ -0,0 +1,378 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// schedule - restore repeated by a cron expression on behalf of its owner
+type schedule struct {
+	ID              string         `json:"id"`
+	Name            string         `json:"name"`
+	Cron            string         `json:"cron"`
+	Repo            string         `json:"repo"`
+	Snapshot        string         `json:"snapshot,omitempty"`
+	SnapshotPattern string         `json:"snapshot_pattern,omitempty"` // последний успешный снапшот по шаблону
+	Indices         []string       `json:"indices"`
+	Options         restoreOptions `json:"options"`
+	Priority        int            `json:"priority,omitempty"`
+	Enabled         bool           `json:"enabled"`
+	Owner           identity       `json:"owner"`
+	Created         time.Time      `json:"created"`
+	Next            *time.Time     `json:"next,omitempty"`
+	LastRun         *time.Time     `json:"last_run,omitempty"`
+	LastJob         string         `json:"last_job,omitempty"`
+	LastError       string         `json:"last_error,omitempty"`
+	Deleted         bool           `json:"deleted,omitempty"`
+}
+
+// scheduleInfo - answer of get_schedule: the schedule and its history
+type scheduleInfo struct {
+	schedule
+	History []event `json:"history"`
+}
+
+// сколько последних событий расписания отдаёт get_schedule
+const scheduleHistory = 50
+
+// scheduler starts restores of enabled schedules when their time comes
+type scheduler struct {
+	sync.RWMutex
+	rt   *Router
+	list map[string]*schedule
+}
+
+func newScheduler(rt *Router) *scheduler {
+	sc := &scheduler{rt: rt, list: make(map[string]*schedule)}
+	saved, err := rt.store.Schedules()
+	if err != nil {
+		log.Println("Store: can't load schedules:", err)
+	}
+	now := time.Now()
+	for i := range saved {
+		s := saved[i]
+		// пропущенные за время простоя запуски не догоняем
+		s.Next = nil
+		if c, err := parseCron(s.Cron); err == nil && s.Enabled {
+			next := c.next(now)
+			s.Next = &next
+		}
+		sc.list[s.ID] = &s
+	}
+	return sc
+}
+
+func (sc *scheduler) run() {
+	sc.RLock()
+	log.Println("Scheduler:", len(sc.list), "schedules")
+	sc.RUnlock()
+	for {
+		now := time.Now()
+		time.Sleep(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+		sc.tick(time.Now())
+	}
+}
+
+// tick runs schedules that are due at now
+func (sc *scheduler) tick(now time.Time) {
+	var due []schedule
+	sc.Lock()
+	for _, s := range sc.list {
+		if !s.Enabled || s.Next == nil || s.Next.After(now) {
+			continue
+		}
+		due = append(due, *s)
+		if c, err := parseCron(s.Cron); err == nil {
+			next := c.next(now)
+			s.Next = &next
+		}
+	}
+	sc.Unlock()
+	for _, s := range due {
+		sc.rt.runSchedule(s, "scheduler")
+	}
+}
+
+func (sc *scheduler) get(id string) (schedule, error) {
+	if id == "" {
+		return schedule{}, badRequest("schedule_id is required")
+	}
+	sc.RLock()
+	defer sc.RUnlock()
+	s, ok := sc.list[id]
+	if !ok {
+		return schedule{}, notFound("schedule %s not found", id)
+	}
+	return *s, nil
+}
+
+// all returns schedules sorted by name
+func (sc *scheduler) all() []schedule {
+	sc.RLock()
+	res := []schedule{}
+	for _, s := range sc.list {
+		res = append(res, *s)
+	}
+	sc.RUnlock()
+	sort.Slice(res, func(a, b int) bool { return res[a].Name < res[b].Name })
+	return res
+}
+
+// save stores the schedule, deleted schedules are removed from the list.
+// The store is written under the lock so that its last record matches the list.
+func (sc *scheduler) save(s schedule) error {
+	sc.Lock()
+	defer sc.Unlock()
+	if s.Deleted {
+		delete(sc.list, s.ID)
+	} else {
+		sc.list[s.ID] = &s
+	}
+	return sc.rt.store.SaveSchedule(s)
+}
+
+// scheduleFromValues fills the definition of s from the request, only given fields replace the old ones
+func scheduleFromValues(s *schedule, v *apiRequest) {
+	if v.Values.Name != "" {
+		s.Name = v.Values.Name
+	}
+	if v.Values.Cron != "" {
+		s.Cron = v.Values.Cron
+	}
+	if v.Values.Repo != "" {
+		s.Repo = v.Values.Repo
+	}
+	if v.Values.Snapshot != "" || v.Values.SnapshotPattern != "" {
+		s.Snapshot, s.SnapshotPattern = v.Values.Snapshot, v.Values.SnapshotPattern
+	}
+	if v.Values.Indices != nil {
+		s.Indices = v.Values.Indices
+	}
+	o := v.Values.Options
+	if o.Replicas != nil {
+		s.Options.Replicas = o.Replicas
+	}
+	if o.IncludeAliases != nil {
+		s.Options.IncludeAliases = o.IncludeAliases
+	}
+	if o.IndexSettings != nil {
+		s.Options.IndexSettings = o.IndexSettings
+	}
+	if o.IgnoreIndexSettings != nil {
+		s.Options.IgnoreIndexSettings = o.IgnoreIndexSettings
+	}
+	if o.Partial != nil {
+		s.Options.Partial = o.Partial
+	}
+	if o.FeatureStates != nil {
+		s.Options.FeatureStates = o.FeatureStates
+	}
+	if o.Conflict != "" {
+		s.Options.Conflict = o.Conflict
+	}
+	if o.ConfirmReplace != nil {
+		s.Options.ConfirmReplace = o.ConfirmReplace
+	}
+	if v.Values.Priority != 0 {
+		s.Priority = v.Values.Priority
+	}
+	if v.Values.Enabled != nil {
+		s.Enabled = *v.Values.Enabled
+	}
+}
+
+// checkSchedule validates the schedule and checks that the user may restore what it names
+func (rt *Router) checkSchedule(r *http.Request, s *schedule) error {
+	if s.Name == "" {
+		return badRequest("name is required")
+	}
+	c, err := parseCron(s.Cron)
+	if err != nil {
+		return badRequest("cron: %s", err)
+	}
+	if s.Repo == "" {
+		return badRequest("repo is required")
+	}
+	if (s.Snapshot == "") == (s.SnapshotPattern == "") {
+		return badRequest("either snapshot or snapshot_pattern is required")
+	}
+	if _, err := path.Match(s.SnapshotPattern, ""); err != nil {
+		return badRequest("bad snapshot_pattern %q", s.SnapshotPattern)
+	}
+	if len(s.Indices) == 0 {
+		return badRequest("indices are required")
+	}
+	for _, p := range s.Indices {
+		if _, err := path.Match(strings.TrimPrefix(p, "-"), ""); err != nil {
+			return badRequest("bad pattern %q", p)
+		}
+	}
+	if _, err := rt.checkOptions(&s.Options); err != nil {
+		return err
+	}
+
+	check := apiRequest{Action: "restore"}
+	check.Values.Repo = s.Repo
+	check.Values.Indices = s.Indices
+	err = rt.authorizeRequest(r, &check)
+	if err != nil {
+		return err
+	}
+
+	s.Next = nil
+	if s.Enabled {
+		next := c.next(time.Now())
+		if next.IsZero() {
+			return badRequest("cron %q never fires", s.Cron)
+		}
+		s.Next = &next
+	}
+	return nil
+}
+
+// latestSnapshot returns the newest successful snapshot of repo matching pattern
+func (rt *Router) latestSnapshot(repo, pattern string) (string, error) {
+	response, err := rt.doGet(rt.conf.Elastic.Host + "_cat/snapshots/" + repo + "?format=json&h=id,status,end_epoch")
+	if err != nil {
+		return "", err
+	}
+	var list []map[string]string
+	err = json.Unmarshal(response, &list)
+	if err != nil {
+		return "", err
+	}
+	var (
+		latest string
+		end    int64 = -1
+	)
+	// _cat/snapshots отдаёт снапшоты по времени начала, при равенстве берём более поздний
+	for _, s := range list {
+		if s["status"] != "SUCCESS" {
+			continue
+		}
+		if ok, _ := path.Match(pattern, s["id"]); !ok {
+			continue
+		}
+		e, _ := strconv.ParseInt(s["end_epoch"], 10, 64)
+		if e >= end {
+			latest, end = s["id"], e
+		}
+	}
+	if latest == "" {
+		return "", notFound("no successful snapshot of %s matches %s", repo, pattern)
+	}
+	return latest, nil
+}
+
+// scheduleOwner returns the owner of the schedule as the user is now: static users
+// and LDAP are asked again, so removed groups and users take effect. Groups and
+// roles given by OpenID Connect or a proxy header are only known at login, the
+// ones saved with the schedule are used.
+func (rt *Router) scheduleOwner(s schedule) (*identity, error) {
+	if rt.auth != nil {
+		if l, ok := rt.auth.backend.(lookuper); ok {
+			return l.Lookup(s.Owner.Name)
+		}
+	}
+	owner := s.Owner
+	return &owner, nil
+}
+
+// runSchedule restores what the schedule names on behalf of its owner and records the run.
+// by is the user who started the run by hand or "scheduler".
+func (rt *Router) runSchedule(s schedule, by string) (restoreResult, int, error) {
+	owner, ownerErr := rt.scheduleOwner(s)
+	if ownerErr != nil {
+		owner = &identity{Name: s.Owner.Name}
+	}
+	r := identityRequest(owner, "/schedule/"+s.ID)
+	request := apiRequest{Action: "restore"}
+	request.Values.Repo = s.Repo
+	request.Values.Snapshot = s.Snapshot
+	request.Values.Indices = append([]string{}, s.Indices...)
+	request.Values.Options = s.Options
+	request.Values.Options.IgnoreIndexSettings = append([]string{}, s.Options.IgnoreIndexSettings...)
+	request.Values.Priority = s.Priority
+
+	var (
+		res    restoreResult
+		status int
+		plan   *restorePlan
+		err    = ownerErr
+	)
+	if err == nil && s.SnapshotPattern != "" {
+		request.Values.Snapshot, err = rt.latestSnapshot(s.Repo, s.SnapshotPattern)
+	}
+	if err == nil {
+		plan, err = rt.planRestore(r, &request)
+	}
+	if err == nil {
+		res, status, err = rt.submitRestore(r, &request, plan)
+	}
+	if err == nil && len(res.Accepted) == 0 {
+		err = &apiError{Status: status, Message: res.Message}
+	}
+
+	e := event{User: owner.Name, Action: "scheduled_restore", Repo: s.Repo, Snapshot: request.Values.Snapshot, Job: res.JobID, Schedule: s.ID}
+	now := time.Now()
+	s.LastRun, s.LastJob, s.LastError = &now, res.JobID, ""
+	if err != nil {
+		s.LastError = err.Error()
+		e.Details = by + ": " + err.Error()
+		log.Println("Scheduler:", s.Name, "failed:", err)
+	} else {
+		e.Details = by + ": " + res.Message
+		log.Println("Scheduler:", s.Name, "started job", res.JobID)
+	}
+	rt.record(e)
+
+	// расписание могли изменить или удалить, пока шло восстановление;
+	// удалённое не сохраняем, иначе оно вернётся после перезапуска
+	rt.schedules.Lock()
+	if cur, ok := rt.schedules.list[s.ID]; ok {
+		cur.LastRun, cur.LastJob, cur.LastError = s.LastRun, s.LastJob, s.LastError
+		if err := rt.store.SaveSchedule(*cur); err != nil {
+			log.Println("Store: can't save schedule", s.ID, ":", err)
+		}
+	}
+	rt.schedules.Unlock()
+	return res, status, err
+}
+
+// scheduleHistoryOf returns the last events of the schedule, newest first
+func (rt *Router) scheduleHistoryOf(id string) ([]event, error) {
+	list, err := rt.store.Events(0)
+	if err != nil {
+		return nil, err
+	}
+	res := []event{}
+	for _, e := range list {
+		if e.Schedule == id {
+			res = append(res, e)
+			if len(res) == scheduleHistory {
+				break
+			}
+		}
+	}
+	return res, nil
+}
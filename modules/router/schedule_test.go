@@ -0,0 +1,197 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// scheduleCall runs a schedule action through dispatch on behalf of user
+func scheduleCall(rt *Router, user string, request apiRequest) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	rt.dispatch(w, identityRequest(&identity{Name: user}, "/api/v1/schedules"), &request)
+	return w
+}
+
+func TestScheduleOwner(t *testing.T) {
+	rt := testRouter(t, aclConfig)
+	rt.schedules = newScheduler(rt)
+
+	create := apiRequest{Action: "create_schedule"}
+	create.Values.Name = "daily"
+	create.Values.Cron = "@daily"
+	create.Values.Repo = "archive"
+	create.Values.SnapshotPattern = "snap-*"
+	create.Values.Indices = []string{"logs-*"}
+	w := scheduleCall(rt, "operator", create)
+	var s schedule
+	if err := json.Unmarshal(w.Body.Bytes(), &s); w.Code != http.StatusOK || err != nil || s.Owner.Name != "operator" {
+		t.Fatalf("create: %d %s", w.Code, w.Body)
+	}
+
+	// чужое расписание другой оператор не меняет, не запускает и не удаляет
+	for _, action := range []string{"update_schedule", "run_schedule", "delete_schedule"} {
+		request := apiRequest{Action: action}
+		request.Values.ScheduleId = s.ID
+		request.Values.Cron = "@hourly"
+		if w := scheduleCall(rt, "operator2", request); w.Code != http.StatusForbidden {
+			t.Errorf("%s by operator2: %d %s, want 403", action, w.Code, w.Body)
+		}
+	}
+	if got, _ := rt.schedules.get(s.ID); got.Cron != "@daily" || got.Deleted {
+		t.Fatalf("schedule changed by operator2: %+v", got)
+	}
+
+	// admin может, и расписание дальше выполняется с его правами
+	update := apiRequest{Action: "update_schedule"}
+	update.Values.ScheduleId = s.ID
+	update.Values.Cron = "@hourly"
+	if w := scheduleCall(rt, "admin", update); w.Code != http.StatusOK {
+		t.Fatalf("update by admin: %d %s", w.Code, w.Body)
+	}
+	if got, _ := rt.schedules.get(s.ID); got.Cron != "@hourly" || got.Owner.Name != "admin" {
+		t.Fatalf("after update by admin: %+v", got)
+	}
+
+	// прежний владелец больше не владеет
+	del := apiRequest{Action: "delete_schedule"}
+	del.Values.ScheduleId = s.ID
+	if w := scheduleCall(rt, "operator", del); w.Code != http.StatusForbidden {
+		t.Fatalf("delete by the former owner: %d %s, want 403", w.Code, w.Body)
+	}
+	if w := scheduleCall(rt, "admin", del); w.Code != http.StatusOK {
+		t.Fatalf("delete by admin: %d %s", w.Code, w.Body)
+	}
+}
+
+func TestScheduleDeletedDuringRun(t *testing.T) {
+	var rt *Router
+	s := schedule{ID: "s1", Name: "daily", Cron: "@daily", Repo: "archive", SnapshotPattern: "snap-*", Indices: []string{"logs-*"}, Enabled: true}
+	es := newFakeES(t, func(method, path string) (int, string) {
+		// расписание удаляют, пока ищется снапшот
+		if strings.HasPrefix(path, "/_cat/snapshots/") {
+			d := s
+			d.Deleted = true
+			rt.schedules.save(d)
+		}
+		return http.StatusOK, `[]`
+	})
+	rt = testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n")
+	rt.schedules = newScheduler(rt)
+	if err := rt.schedules.save(s); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := rt.runSchedule(s, "scheduler"); httpStatus(err) != http.StatusNotFound {
+		t.Fatalf("run: %v, want no snapshot found", err)
+	}
+	if _, err := rt.schedules.get(s.ID); err == nil {
+		t.Error("deleted schedule is back in the list")
+	}
+	saved, err := rt.store.Schedules()
+	if err != nil || len(saved) != 0 {
+		t.Errorf("saved schedules %+v, %v, want none", saved, err)
+	}
+}
+
+func TestScheduleUpdateFlags(t *testing.T) {
+	rt := testRouter(t, "restore:\n  allow_aliases: true\n  allow_partial: true\n")
+	rt.schedules = newScheduler(rt)
+
+	create := apiRequest{Action: "create_schedule"}
+	create.Values.Name = "daily"
+	create.Values.Cron = "@daily"
+	create.Values.Repo = "archive"
+	create.Values.Snapshot = "s1"
+	create.Values.Indices = []string{"logs-*"}
+	create.Values.Options.IncludeAliases = boolp(true)
+	create.Values.Options.Partial = boolp(true)
+	create.Values.Options.Conflict = "skip"
+	var s schedule
+	w := scheduleCall(rt, "operator", create)
+	if err := json.Unmarshal(w.Body.Bytes(), &s); w.Code != http.StatusOK || err != nil {
+		t.Fatalf("create: %d %s", w.Code, w.Body)
+	}
+
+	// флаг можно вернуть в false, остальные опции остаются
+	update := apiRequest{Action: "update_schedule"}
+	update.Values.ScheduleId = s.ID
+	update.Values.Options.IncludeAliases = boolp(false)
+	if w := scheduleCall(rt, "operator", update); w.Code != http.StatusOK {
+		t.Fatalf("update: %d %s", w.Code, w.Body)
+	}
+	got, _ := rt.schedules.get(s.ID)
+	if isTrue(got.Options.IncludeAliases) || !isTrue(got.Options.Partial) || got.Options.Conflict != "skip" {
+		t.Fatalf("options after the update %+v", got.Options)
+	}
+}
+
+func TestScheduleOwnerResolved(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	es := newFakeES(t, func(method, path string) (int, string) {
+		return http.StatusOK, `{"snapshots":[{"snapshot":"s1","state":"SUCCESS","indices":{"logs-a":{"stats":{"total":{"size_in_bytes":100}}}}}]}`
+	})
+	rt := testRouter(t, `
+elastic:
+  host: `+es.srv.URL+`/
+auth:
+  type: static
+  users:
+    - name: alice
+      password: "`+string(hash)+`"
+      groups: [ops]
+    - name: bob
+      password: "`+string(hash)+`"
+rbac:
+  groups:
+    ops: [operator]
+  roles:
+    operator:
+      actions: [restore]
+      repositories: [archive]
+`)
+	rt.auth, err = newAuth(rt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt.catalog = newCatalog(rt)
+	rt.schedules = newScheduler(rt)
+
+	// группы, сохранённые с расписанием, не действуют: у bob их больше нет
+	for _, tt := range []struct {
+		owner  identity
+		denied bool
+	}{
+		{owner: identity{Name: "bob", Groups: []string{"ops"}}, denied: true},
+		{owner: identity{Name: "carol", Groups: []string{"ops"}}, denied: true},
+		{owner: identity{Name: "alice"}},
+	} {
+		s := schedule{ID: tt.owner.Name, Name: tt.owner.Name, Cron: "@daily", Repo: "archive", Snapshot: "s1", Indices: []string{"logs-a"}, Owner: tt.owner}
+		rt.schedules.save(s)
+		_, _, err := rt.runSchedule(s, "scheduler")
+		denied := err != nil && (httpStatus(err) == http.StatusForbidden || strings.Contains(err.Error(), "not in auth.users"))
+		if denied != tt.denied {
+			t.Errorf("run as %s: %v, want denied %v", tt.owner.Name, err, tt.denied)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// дата в имени индекса: 2020.11.01, 2020-11-01, 20201101 или месяц 2020.11
+var indexDate = regexp.MustCompile(`(?:^|[^0-9])([0-9]{4})[._-]?([0-9]{2})(?:[._-]?([0-9]{2}))?(?:[^0-9]|$)`)
+
+// isPattern reports whether the entry of Values.Indices is a glob or an exclusion
+func isPattern(s string) bool {
+	return strings.HasPrefix(s, "-") || strings.ContainsAny(s, "*?[")
+}
+
+// indexPeriod returns the time span covered by a date-suffixed index
+func indexPeriod(name string) (time.Time, time.Time, bool) {
+	m := indexDate.FindAllStringSubmatch(name, -1)
+	for i := len(m) - 1; i >= 0; i-- {
+		y, mon, d := m[i][1], m[i][2], m[i][3]
+		if d == "" {
+			start, err := time.Parse("2006-01", y+"-"+mon)
+			if err == nil && start.Year() >= 1970 {
+				return start, start.AddDate(0, 1, 0), true
+			}
+			continue
+		}
+		start, err := time.Parse(dateLayout, y+"-"+mon+"-"+d)
+		if err == nil && start.Year() >= 1970 {
+			return start, start.AddDate(0, 0, 1), true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// selectIndices expands names, glob patterns and -exclusions against the index list
+// of a snapshot and keeps indices whose date suffix falls into [from, to].
+// Missing names (404) and patterns or exclusions that matched nothing (400) are reported as an error.
+func selectIndices(names []string, patterns []string, from, to string) ([]string, error) {
+	var (
+		include, exclude []string
+		start, end       time.Time
+		err              error
+	)
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if _, err := path.Match(strings.TrimPrefix(p, "-"), ""); err != nil {
+			return nil, badRequest("bad pattern %q", p)
+		}
+		if strings.HasPrefix(p, "-") {
+			exclude = append(exclude, p[1:])
+		} else {
+			include = append(include, p)
+		}
+	}
+	if from != "" {
+		start, err = time.Parse(dateLayout, from)
+		if err != nil {
+			return nil, badRequest("from must be a date like %s", dateLayout)
+		}
+	}
+	if to != "" {
+		end, err = time.Parse(dateLayout, to)
+		if err != nil {
+			return nil, badRequest("to must be a date like %s", dateLayout)
+		}
+		end = end.AddDate(0, 0, 1)
+	}
+	ranged := from != "" || to != ""
+	if len(include) == 0 && len(exclude) == 0 && !ranged {
+		return nil, badRequest("indices or a time range are required")
+	}
+	// только исключения или диапазон дат - выбираем из всех индексов
+	if len(include) == 0 {
+		include = []string{"*"}
+	}
+
+	var (
+		res       []string
+		unmatched []string
+		missing   []string
+		seen      = make(map[string]bool)
+	)
+	for _, p := range include {
+		matched := false
+		for _, n := range names {
+			if ok, _ := path.Match(p, n); !ok {
+				continue
+			}
+			matched = true
+			if seen[n] || len(exclude) > 0 && matchAny(exclude, n) {
+				continue
+			}
+			if ranged {
+				s, e, ok := indexPeriod(n)
+				if !ok || !end.IsZero() && !s.Before(end) || !start.IsZero() && !e.After(start) {
+					continue
+				}
+			}
+			seen[n] = true
+			res = append(res, n)
+		}
+		if !matched && isPattern(p) {
+			unmatched = append(unmatched, p)
+		} else if !matched {
+			missing = append(missing, p)
+		}
+	}
+	// исключение, которое ничего не задело, скорее всего написано с ошибкой
+	for _, p := range exclude {
+		matched := false
+		for _, n := range names {
+			if ok, _ := path.Match(p, n); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, "-"+p)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, notFound("indices are not in the snapshot: %s", strings.Join(missing, ", "))
+	}
+	if len(unmatched) > 0 {
+		return nil, badRequest("patterns matched no index of the snapshot: %s", strings.Join(unmatched, ", "))
+	}
+	if len(res) == 0 {
+		return nil, badRequest("no index of the snapshot is selected")
+	}
+	sort.Strings(res)
+	return res, nil
+}
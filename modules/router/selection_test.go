@@ -0,0 +1,94 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSelectIndices(t *testing.T) {
+	names := []string{"logs-a-2020.11.01", "logs-a-2020.11.02", "logs-a-2020.11.03", "logs-b-2020.11.02", "metrics-2020.11", ".kibana", "other"}
+	tests := []struct {
+		name     string
+		patterns []string
+		from, to string
+		want     []string
+		status   int
+	}{
+		{name: "plain name", patterns: []string{"other"}, want: []string{"other"}},
+		{name: "glob", patterns: []string{"logs-a-*"}, want: []string{"logs-a-2020.11.01", "logs-a-2020.11.02", "logs-a-2020.11.03"}},
+		{name: "overlapping globs", patterns: []string{"logs-a-*", "logs-*-2020.11.01"}, want: []string{"logs-a-2020.11.01", "logs-a-2020.11.02", "logs-a-2020.11.03"}},
+		{name: "exclusion", patterns: []string{"logs-*", "-logs-b-*"}, want: []string{"logs-a-2020.11.01", "logs-a-2020.11.02", "logs-a-2020.11.03"}},
+		{name: "only exclusions", patterns: []string{"-logs-*", "-.kibana"}, want: []string{"metrics-2020.11", "other"}},
+		{name: "exclusion matching nothing", patterns: []string{"logs-*", "-log-b-*"}, status: http.StatusBadRequest},
+		{name: "missing name", patterns: []string{"logs-c-2020.11.01"}, status: http.StatusNotFound},
+		{name: "glob matching nothing", patterns: []string{"logs-c-*"}, status: http.StatusBadRequest},
+		{name: "bad glob", patterns: []string{"logs-["}, status: http.StatusBadRequest},
+		{name: "everything excluded", patterns: []string{"logs-a-*", "-logs-a-*"}, status: http.StatusBadRequest},
+		{name: "nothing asked", patterns: []string{" "}, status: http.StatusBadRequest},
+
+		// месячный индекс попадает в любой день своего месяца
+		{name: "one day", from: "2020-11-02", to: "2020-11-02", want: []string{"logs-a-2020.11.02", "logs-b-2020.11.02", "metrics-2020.11"}},
+		{name: "from", patterns: []string{"logs-a-*"}, from: "2020-11-02", want: []string{"logs-a-2020.11.02", "logs-a-2020.11.03"}},
+		{name: "to", patterns: []string{"logs-a-*", "metrics-*"}, to: "2020-11-01", want: []string{"logs-a-2020.11.01", "metrics-2020.11"}},
+		{name: "range and exclusion", patterns: []string{"-metrics-*"}, from: "2020-11-02", to: "2020-11-02", want: []string{"logs-a-2020.11.02", "logs-b-2020.11.02"}},
+		{name: "range selecting nothing", from: "2021-01-01", status: http.StatusBadRequest},
+		{name: "bad from", from: "2020/11/01", status: http.StatusBadRequest},
+		{name: "bad to", to: "01-11-2020", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		got, err := selectIndices(names, tt.patterns, tt.from, tt.to)
+		if tt.status != 0 {
+			if httpStatus(err) != tt.status {
+				t.Errorf("%s: %v, %v, want status %d", tt.name, got, err, tt.status)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestIndexPeriod(t *testing.T) {
+	day := func(s string) time.Time {
+		d, _ := time.Parse(dateLayout, s)
+		return d
+	}
+	tests := []struct {
+		name       string
+		start, end time.Time
+		ok         bool
+	}{
+		{"logs-2020.11.01", day("2020-11-01"), day("2020-11-02"), true},
+		{"logs-2020-11-01", day("2020-11-01"), day("2020-11-02"), true},
+		{"logs_20201101", day("2020-11-01"), day("2020-11-02"), true},
+		{"metrics-2020.11", day("2020-11-01"), day("2020-12-01"), true},
+		// дата берётся с конца имени
+		{"v2020.01-logs-2020.11.30", day("2020-11-30"), day("2020-12-01"), true},
+		{"logs-2020.13.01", time.Time{}, time.Time{}, false},
+		{"logs-1969.12.31", time.Time{}, time.Time{}, false},
+		{"logs-123456789", time.Time{}, time.Time{}, false},
+		{"logs", time.Time{}, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		start, end, ok := indexPeriod(tt.name)
+		if ok != tt.ok || !start.Equal(tt.start) || !end.Equal(tt.end) {
+			t.Errorf("indexPeriod(%s) = %v, %v, %v, want %v, %v, %v", tt.name, start, end, ok, tt.start, tt.end, tt.ok)
+		}
+	}
+}
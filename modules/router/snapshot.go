@@ -0,0 +1,160 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// snapshotProgress - state of a snapshot read from the same _status as get_snapshot
+type snapshotProgress struct {
+	Repo        string            `json:"repo"`
+	Snapshot    string            `json:"snapshot"`
+	State       string            `json:"state"`
+	Indices     []string          `json:"indices"`
+	ShardsDone  int               `json:"shards_done"`
+	ShardsTotal int               `json:"shards_total"`
+	Percent     float64           `json:"percent"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// snapshotName expands the snapshots.name template or the name given by the user
+func (rt *Router) snapshotName(name, repo, user string, t time.Time) string {
+	if name == "" {
+		name = rt.conf.Snapshots.Name
+	}
+	r := strings.NewReplacer(
+		"{repo}", repo,
+		"{user}", user,
+		"{date}", t.Format(rt.conf.Snapshots.DateFormat),
+	)
+	// те же ограничения, что и у имён индексов
+	return sanitizeIndexPart(r.Replace(name))
+}
+
+// snapshotProgress reads the progress of a snapshot from _status
+func (rt *Router) snapshotProgress(repo, snapshot string) (*snapshotProgress, error) {
+	response, err := rt.doGet(rt.conf.Elastic.Host + "_snapshot/" + repo + "/" + snapshot + "/_status")
+	if err != nil {
+		return nil, err
+	}
+	var status struct {
+		Snapshots []struct {
+			State       string `json:"state"`
+			ShardsStats struct {
+				Done  int `json:"done"`
+				Total int `json:"total"`
+			} `json:"shards_stats"`
+			Indices map[string]json.RawMessage `json:"indices"`
+		} `json:"snapshots"`
+	}
+	err = json.Unmarshal(response, &status)
+	if err != nil {
+		return nil, upstreamError(http.StatusBadGateway, "bad snapshot status: "+err.Error())
+	}
+	if len(status.Snapshots) == 0 {
+		return nil, notFound("snapshot %s not found in %s", snapshot, repo)
+	}
+	s := status.Snapshots[0]
+	p := &snapshotProgress{Repo: repo, Snapshot: snapshot, State: s.State, Indices: []string{}, ShardsDone: s.ShardsStats.Done, ShardsTotal: s.ShardsStats.Total}
+	for i := range s.Indices {
+		p.Indices = append(p.Indices, i)
+	}
+	sort.Strings(p.Indices)
+	if p.ShardsTotal > 0 {
+		p.Percent = float64(p.ShardsDone) * 100 / float64(p.ShardsTotal)
+	}
+	return p, nil
+}
+
+// createSnapshot starts a snapshot of the open indices matching the patterns of the request.
+// Patterns are resolved first, so rbac sees the real names.
+func (rt *Router) createSnapshot(r *http.Request, request *apiRequest) (*snapshotProgress, error) {
+	if request.Values.Repo == "" {
+		return nil, badRequest("repo is required")
+	}
+	if !plainIndexName(request.Values.Repo) {
+		return nil, badRequest("bad repository name %q", request.Values.Repo)
+	}
+	if len(request.Values.Indices) == 0 {
+		return nil, badRequest("indices are required")
+	}
+	// шаблоны попадают в путь запроса к elasticsearch
+	for _, p := range request.Values.Indices {
+		if p == "" || strings.ContainsAny(p, "/?#\\ ") {
+			return nil, badRequest("bad index pattern %q", p)
+		}
+	}
+	user := requestUser(r)
+	name := rt.snapshotName(request.Values.Snapshot, request.Values.Repo, user, time.Now())
+	// _all, -name и _-пути elasticsearch понимает по-своему
+	if !plainIndexName(name) {
+		return nil, badRequest("bad snapshot name %q", name)
+	}
+
+	response, err := rt.doGet(rt.conf.Elastic.Host + "_cat/indices/" + strings.Join(request.Values.Indices, ",") + "?format=json&h=index&expand_wildcards=open")
+	if err != nil {
+		return nil, err
+	}
+	var list []struct {
+		Index string `json:"index"`
+	}
+	err = json.Unmarshal(response, &list)
+	if err != nil {
+		return nil, err
+	}
+	var indices []string
+	for _, i := range list {
+		indices = append(indices, i.Index)
+	}
+	if len(indices) == 0 {
+		return nil, badRequest("indices %s match nothing", strings.Join(request.Values.Indices, ","))
+	}
+	sort.Strings(indices)
+
+	check := apiRequest{Action: request.Action}
+	check.Values.Repo = request.Values.Repo
+	check.Values.Indices = indices
+	err = rt.authorizeRequest(r, &check)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := map[string]string{"requester": user, "created_by": "extractor"}
+	if request.Values.Reason != "" {
+		metadata["reason"] = request.Values.Reason
+	}
+	_, err = rt.doPut(rt.conf.Elastic.Host+"_snapshot/"+request.Values.Repo+"/"+name, map[string]interface{}{
+		"indices":              strings.Join(indices, ","),
+		"include_global_state": request.Values.IncludeGlobalState,
+		"metadata":             metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rt.catalog.forget(request.Values.Repo, name)
+	rt.record(event{User: user, Action: "create_snapshot", Repo: request.Values.Repo, Snapshot: name, Details: request.Values.Reason})
+
+	p, err := rt.snapshotProgress(request.Values.Repo, name)
+	if err != nil {
+		// снапшот создаётся, статус можно спросить позже через get_snapshot
+		p = &snapshotProgress{Repo: request.Values.Repo, Snapshot: name, State: "IN_PROGRESS", Indices: indices}
+	}
+	p.Metadata = metadata
+	return p, nil
+}
@@ -0,0 +1,176 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const snapshotConfig = `
+rbac:
+  users:
+    alice: [snapshots]
+    _admin: [snapshots]
+  roles:
+    snapshots:
+      actions: [create_snapshot, delete_snapshot]
+      repositories: [archive]
+      indices: ["logs-*"]
+`
+
+func TestSnapshotName(t *testing.T) {
+	rt := testRouter(t, "")
+	now := time.Date(2020, 11, 1, 10, 20, 30, 0, time.UTC)
+	for _, tt := range []struct {
+		name, user, want string
+	}{
+		{"", "alice", "alice-2020.11.01-10.20.30"},
+		{"", "Bob Smith", "bob-smith-2020.11.01-10.20.30"},
+		{"{repo}-nightly-{date}", "alice", "archive-nightly-2020.11.01-10.20.30"},
+		{"Before/Upgrade", "alice", "before-upgrade"},
+		{"a,b", "alice", "a-b"},
+	} {
+		if got := rt.snapshotName(tt.name, "archive", tt.user, now); got != tt.want {
+			t.Errorf("snapshotName(%q, %q) = %q, want %q", tt.name, tt.user, got, tt.want)
+		}
+	}
+}
+
+// snapshotES answers _cat/indices of the patterns like elasticsearch would
+func snapshotES(t *testing.T) *fakeES {
+	return newFakeES(t, func(method, path string) (int, string) {
+		switch {
+		case strings.HasPrefix(path, "/_cat/indices/"):
+			var list []string
+			for _, p := range strings.Split(strings.TrimPrefix(path, "/_cat/indices/"), ",") {
+				for _, i := range []string{"logs-a", "logs-b", "secret-a"} {
+					if matchAny([]string{p}, i) {
+						list = append(list, `{"index":"`+i+`"}`)
+					}
+				}
+			}
+			return http.StatusOK, "[" + strings.Join(list, ",") + "]"
+		case strings.HasSuffix(path, "/_status"):
+			return http.StatusOK, `{"snapshots":[{"state":"IN_PROGRESS","shards_stats":{"done":0,"total":2},"indices":{"logs-a":{},"logs-b":{}}}]}`
+		}
+		return http.StatusOK, `{"accepted":true}`
+	})
+}
+
+func TestCreateSnapshot(t *testing.T) {
+	es := snapshotES(t)
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n"+snapshotConfig)
+	rt.catalog = newCatalog(rt)
+
+	request := &apiRequest{Action: "create_snapshot"}
+	request.Values.Repo = "archive"
+	request.Values.Snapshot = "nightly-{user}"
+	request.Values.Indices = []string{"logs-*"}
+	p, err := rt.createSnapshot(identityRequest(&identity{Name: "alice"}, "/api/v1/repositories/archive/snapshots"), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Snapshot != "nightly-alice" || strings.Join(p.Indices, ",") != "logs-a,logs-b" {
+		t.Fatalf("progress %+v", p)
+	}
+	var put map[string]interface{}
+	for _, c := range es.requests() {
+		if c.Method == http.MethodPut {
+			if c.Path != "/_snapshot/archive/nightly-alice" {
+				t.Errorf("snapshot created at %s", c.Path)
+			}
+			json.Unmarshal([]byte(c.Body), &put)
+		}
+	}
+	// шаблоны раскрыты до имён
+	if put["indices"] != "logs-a,logs-b" {
+		t.Errorf("snapshot of %v, want logs-a,logs-b", put["indices"])
+	}
+}
+
+func TestCreateSnapshotRefuses(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     string
+		repo     string
+		snapshot string
+		indices  []string
+		status   int
+	}{
+		// шаблон прошёл бы проверку как строка, но раскрывается и в чужие индексы
+		{name: "expanded to a hidden index", user: "alice", repo: "archive", indices: []string{"*-a"}, status: http.StatusForbidden},
+		{name: "matches nothing", user: "alice", repo: "archive", indices: []string{"logs-z*"}, status: http.StatusBadRequest},
+		{name: "underscore name", user: "alice", repo: "archive", snapshot: "_all", indices: []string{"logs-*"}, status: http.StatusBadRequest},
+		{name: "dash name", user: "alice", repo: "archive", snapshot: "-{date}", indices: []string{"logs-*"}, status: http.StatusBadRequest},
+		{name: "underscore from the user", user: "_admin", repo: "archive", indices: []string{"logs-*"}, status: http.StatusBadRequest},
+		{name: "repo path", user: "alice", repo: "archive/_all", indices: []string{"logs-*"}, status: http.StatusBadRequest},
+		{name: "pattern path", user: "alice", repo: "archive", indices: []string{"logs-*/_settings"}, status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		es := snapshotES(t)
+		rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n"+snapshotConfig)
+		rt.catalog = newCatalog(rt)
+		request := &apiRequest{Action: "create_snapshot"}
+		request.Values.Repo = tt.repo
+		request.Values.Snapshot = tt.snapshot
+		request.Values.Indices = tt.indices
+		_, err := rt.createSnapshot(identityRequest(&identity{Name: tt.user}, "/api/v1/repositories/archive/snapshots"), request)
+		if httpStatus(err) != tt.status {
+			t.Errorf("%s: %v, want %d", tt.name, err, tt.status)
+		}
+		for _, c := range es.requests() {
+			if c.Method == http.MethodPut {
+				t.Errorf("%s: snapshot created: %+v", tt.name, c)
+			}
+		}
+	}
+}
+
+func TestDeleteSnapshotRefusesPatterns(t *testing.T) {
+	es := newFakeES(t, func(method, path string) (int, string) {
+		return http.StatusOK, `{"acknowledged":true}`
+	})
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\n")
+	rt.catalog = newCatalog(rt)
+	for _, v := range [][2]string{
+		{"archive", "*"}, {"archive", "_all"}, {"archive", "a,b"}, {"archive", "-a"}, {"archive", "snap-*"},
+		{"*", "s1"}, {"_all", "s1"}, {"archive/s1", "x"}, {"a,b", "s1"},
+	} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodDelete, "/api/v1/repositories/x/snapshots/y", nil)
+		request := apiRequest{Action: "delete_snapshot"}
+		request.Values.Repo, request.Values.Snapshot = v[0], v[1]
+		rt.dispatch(w, r, &request)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("delete_snapshot %s/%s: status %d, want 400", v[0], v[1], w.Code)
+		}
+	}
+	if len(es.requests()) != 0 {
+		t.Fatalf("requests sent: %+v", es.requests())
+	}
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodDelete, "/api/v1/repositories/archive/snapshots/s1", nil)
+	request := apiRequest{Action: "delete_snapshot"}
+	request.Values.Repo, request.Values.Snapshot = "archive", "s1"
+	rt.dispatch(w, r, &request)
+	if calls := es.requests(); w.Code != http.StatusOK || len(calls) != 1 || calls[0].Path != "/_snapshot/archive/s1" {
+		t.Fatalf("delete_snapshot archive/s1: %d, %+v", w.Code, calls)
+	}
+}
@@ -0,0 +1,633 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// event - one entry of the audit history
+type event struct {
+	Time     time.Time `json:"time"`
+	User     string    `json:"user,omitempty"`
+	Action   string    `json:"action"`
+	Repo     string    `json:"repo,omitempty"`
+	Snapshot string    `json:"snapshot,omitempty"`
+	Index    string    `json:"index,omitempty"`
+	Job      string    `json:"job_id,omitempty"`
+	Schedule string    `json:"schedule_id,omitempty"`
+	Details  string    `json:"details,omitempty"`
+}
+
+// store keeps restore jobs and the audit history between restarts
+type store interface {
+	SaveJob(j restoreJob) error
+	Jobs() ([]restoreJob, error)
+	AddEvent(e event) error
+	// Events returns the last limit events, newest first
+	Events(limit int) ([]event, error)
+	// SaveSchedule overwrites the schedule, deleted schedules are saved with Deleted
+	SaveSchedule(s schedule) error
+	Schedules() ([]schedule, error)
+	// SaveCheck overwrites the repository check
+	SaveCheck(c repoCheck) error
+	Checks() ([]repoCheck, error)
+	// SavePin overwrites the pin of the index, unpinned indices are saved with Removed
+	SavePin(p indexPin) error
+	Pins() ([]indexPin, error)
+}
+
+func newStore(rt *Router) (store, error) {
+	switch rt.conf.Store.Type {
+	case "", "memory":
+		return &memStore{jobs: make(map[string]restoreJob), schedules: make(map[string]schedule), checks: make(map[string]repoCheck), pins: make(map[string]indexPin)}, nil
+	case "file":
+		return newFileStore(rt.conf.Store.Path)
+	case "elastic":
+		s := &esStore{rt: rt, index: rt.conf.Store.Index}
+		return s, s.createIndex()
+	}
+	return nil, errors.New("unknown store type: " + rt.conf.Store.Type)
+}
+
+// record writes e to the store, errors are only logged
+func (rt *Router) record(e event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if err := rt.store.AddEvent(e); err != nil {
+		log.Println("Store:", err)
+	}
+}
+
+func lastEvents(list []event, limit int) []event {
+	sort.SliceStable(list, func(a, b int) bool { return list[a].Time.After(list[b].Time) })
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list
+}
+
+// memStore - state lives until restart, used when store is not configured
+type memStore struct {
+	sync.Mutex
+	jobs      map[string]restoreJob
+	events    []event
+	schedules map[string]schedule
+	checks    map[string]repoCheck
+	pins      map[string]indexPin
+}
+
+func (s *memStore) SaveJob(j restoreJob) error {
+	s.Lock()
+	defer s.Unlock()
+	s.jobs[j.ID] = j
+	return nil
+}
+
+func (s *memStore) Jobs() ([]restoreJob, error) {
+	s.Lock()
+	defer s.Unlock()
+	var res []restoreJob
+	for _, j := range s.jobs {
+		res = append(res, j)
+	}
+	return res, nil
+}
+
+func (s *memStore) AddEvent(e event) error {
+	s.Lock()
+	defer s.Unlock()
+	s.events = append(s.events, e)
+	return nil
+}
+
+func (s *memStore) Events(limit int) ([]event, error) {
+	s.Lock()
+	list := make([]event, len(s.events))
+	copy(list, s.events)
+	s.Unlock()
+	return lastEvents(list, limit), nil
+}
+
+func (s *memStore) SaveSchedule(sc schedule) error {
+	s.Lock()
+	defer s.Unlock()
+	s.schedules[sc.ID] = sc
+	return nil
+}
+
+func (s *memStore) Schedules() ([]schedule, error) {
+	s.Lock()
+	defer s.Unlock()
+	var res []schedule
+	for _, sc := range s.schedules {
+		if !sc.Deleted {
+			res = append(res, sc)
+		}
+	}
+	return res, nil
+}
+
+func (s *memStore) SaveCheck(c repoCheck) error {
+	s.Lock()
+	defer s.Unlock()
+	s.checks[c.ID] = c
+	return nil
+}
+
+func (s *memStore) Checks() ([]repoCheck, error) {
+	s.Lock()
+	defer s.Unlock()
+	var res []repoCheck
+	for _, c := range s.checks {
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+func (s *memStore) SavePin(p indexPin) error {
+	s.Lock()
+	defer s.Unlock()
+	if p.Removed {
+		delete(s.pins, p.Index)
+	} else {
+		s.pins[p.Index] = p
+	}
+	return nil
+}
+
+func (s *memStore) Pins() ([]indexPin, error) {
+	s.Lock()
+	defer s.Unlock()
+	var res []indexPin
+	for _, p := range s.pins {
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+// fileStore - JSON lines files in a local directory, the last record of a job wins;
+// loading jobs, schedules, checks and pins compacts their files to the last
+// records, deleted schedules and removed pins are dropped. Events are the
+// audit trail and stay as they are
+type fileStore struct {
+	sync.Mutex
+	jobsFile      string
+	eventsFile    string
+	schedulesFile string
+	checksFile    string
+	pinsFile      string
+}
+
+func newFileStore(dir string) (*fileStore, error) {
+	if dir == "" {
+		return nil, errors.New("store.path is required for the file store")
+	}
+	err := os.MkdirAll(dir, 0750)
+	if err != nil {
+		return nil, err
+	}
+	return &fileStore{
+		jobsFile:      filepath.Join(dir, "jobs.jsonl"),
+		eventsFile:    filepath.Join(dir, "events.jsonl"),
+		schedulesFile: filepath.Join(dir, "schedules.jsonl"),
+		checksFile:    filepath.Join(dir, "checks.jsonl"),
+		pinsFile:      filepath.Join(dir, "pins.jsonl"),
+	}, nil
+}
+
+func (s *fileStore) appendLine(file string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	s.Lock()
+	defer s.Unlock()
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(b, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (s *fileStore) readLines(file string, fn func([]byte) error) error {
+	s.Lock()
+	defer s.Unlock()
+	_, err := scanLines(file, fn)
+	return err
+}
+
+// loadLines reads the file like readLines and rewrites it with the kept
+// records when there are fewer of them than lines, the lock is held for both
+// so that no record appended in between is lost
+func (s *fileStore) loadLines(file string, fn func([]byte) error, kept func() []interface{}) error {
+	s.Lock()
+	defer s.Unlock()
+	n, err := scanLines(file, fn)
+	if err != nil {
+		return err
+	}
+	list := kept()
+	if len(list) == n {
+		return nil
+	}
+	return writeLines(file, list)
+}
+
+// scanLines calls fn for every non-empty line of the file and returns their number
+func scanLines(file string, fn func([]byte) error) (int, error) {
+	f, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	n := 0
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		n++
+		if err := fn(sc.Bytes()); err != nil {
+			return n, err
+		}
+	}
+	return n, sc.Err()
+}
+
+// writeLines replaces the file with list, one JSON value per line; the new
+// content goes to a temporary file first so that a crash leaves the old one
+func writeLines(file string, list []interface{}) error {
+	tmp := file + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0640)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, v := range list {
+		if err = enc.Encode(v); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, file)
+}
+
+func (s *fileStore) SaveJob(j restoreJob) error {
+	return s.appendLine(s.jobsFile, j)
+}
+
+func (s *fileStore) Jobs() ([]restoreJob, error) {
+	list := make(map[string]restoreJob)
+	var res []restoreJob
+	err := s.loadLines(s.jobsFile, func(b []byte) error {
+		var j restoreJob
+		if err := json.Unmarshal(b, &j); err != nil {
+			return err
+		}
+		list[j.ID] = j
+		return nil
+	}, func() []interface{} {
+		var kept []interface{}
+		for _, j := range list {
+			res = append(res, j)
+			kept = append(kept, j)
+		}
+		return kept
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *fileStore) AddEvent(e event) error {
+	return s.appendLine(s.eventsFile, e)
+}
+
+func (s *fileStore) Events(limit int) ([]event, error) {
+	var list []event
+	err := s.readLines(s.eventsFile, func(b []byte) error {
+		var e event
+		if err := json.Unmarshal(b, &e); err != nil {
+			return err
+		}
+		list = append(list, e)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lastEvents(list, limit), nil
+}
+
+func (s *fileStore) SaveSchedule(sc schedule) error {
+	return s.appendLine(s.schedulesFile, sc)
+}
+
+func (s *fileStore) Schedules() ([]schedule, error) {
+	list := make(map[string]schedule)
+	var res []schedule
+	err := s.loadLines(s.schedulesFile, func(b []byte) error {
+		var sc schedule
+		if err := json.Unmarshal(b, &sc); err != nil {
+			return err
+		}
+		list[sc.ID] = sc
+		return nil
+	}, func() []interface{} {
+		var kept []interface{}
+		for _, sc := range list {
+			if sc.Deleted {
+				continue
+			}
+			res = append(res, sc)
+			kept = append(kept, sc)
+		}
+		return kept
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *fileStore) SaveCheck(c repoCheck) error {
+	return s.appendLine(s.checksFile, c)
+}
+
+func (s *fileStore) Checks() ([]repoCheck, error) {
+	list := make(map[string]repoCheck)
+	var res []repoCheck
+	err := s.loadLines(s.checksFile, func(b []byte) error {
+		var c repoCheck
+		if err := json.Unmarshal(b, &c); err != nil {
+			return err
+		}
+		list[c.ID] = c
+		return nil
+	}, func() []interface{} {
+		var kept []interface{}
+		for _, c := range list {
+			res = append(res, c)
+			kept = append(kept, c)
+		}
+		return kept
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (s *fileStore) SavePin(p indexPin) error {
+	return s.appendLine(s.pinsFile, p)
+}
+
+func (s *fileStore) Pins() ([]indexPin, error) {
+	list := make(map[string]indexPin)
+	var res []indexPin
+	err := s.loadLines(s.pinsFile, func(b []byte) error {
+		var p indexPin
+		if err := json.Unmarshal(b, &p); err != nil {
+			return err
+		}
+		list[p.Index] = p
+		return nil
+	}, func() []interface{} {
+		var kept []interface{}
+		for _, p := range list {
+			if p.Removed {
+				continue
+			}
+			res = append(res, p)
+			kept = append(kept, p)
+		}
+		return kept
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// esStore - documents in an index of the same cluster, jobs are stored
+// with their id so that every save overwrites the previous state
+type esStore struct {
+	rt    *Router
+	index string
+}
+
+type storeDoc struct {
+	Type     string      `json:"type"`
+	Time     time.Time   `json:"@timestamp"`
+	Job      *restoreJob `json:"job,omitempty"`
+	Event    *event      `json:"event,omitempty"`
+	Schedule *schedule   `json:"schedule,omitempty"`
+	Check    *repoCheck  `json:"check,omitempty"`
+	Pin      *indexPin   `json:"pin,omitempty"`
+}
+
+// createIndex creates the store index unless it exists: term on type and sort
+// on @timestamp need keyword and date, documents themselves are not indexed
+func (s *esStore) createIndex() error {
+	_, err := s.rt.doGet(s.rt.conf.Elastic.Host + s.index)
+	if httpStatus(err) != http.StatusNotFound {
+		return err
+	}
+	_, err = s.rt.doPut(s.rt.conf.Elastic.Host+s.index, map[string]interface{}{
+		"mappings": map[string]interface{}{
+			"dynamic": false,
+			"properties": map[string]interface{}{
+				"type":       map[string]interface{}{"type": "keyword"},
+				"@timestamp": map[string]interface{}{"type": "date"},
+			},
+		},
+	})
+	// индекс мог создать другой экземпляр extractor'а
+	if err != nil && strings.Contains(err.Error(), "already exists") {
+		return nil
+	}
+	if err == nil {
+		log.Println("Store: created index", s.index)
+	}
+	return err
+}
+
+func (s *esStore) put(id string, doc storeDoc) error {
+	var body map[string]interface{}
+	b, _ := json.Marshal(doc)
+	_ = json.Unmarshal(b, &body)
+
+	url := s.rt.conf.Elastic.Host + s.index + "/_doc"
+	if id != "" {
+		url += "/" + id
+	}
+	_, err := s.rt.doPost(url+"?refresh=wait_for", body)
+	return err
+}
+
+func (s *esStore) search(docType string, size int) ([]storeDoc, error) {
+	var res struct {
+		Hits struct {
+			Hits []struct {
+				Source storeDoc `json:"_source"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	req := map[string]interface{}{
+		"size":  size,
+		"query": map[string]interface{}{"term": map[string]interface{}{"type": docType}},
+		"sort":  []interface{}{map[string]interface{}{"@timestamp": "desc"}},
+	}
+	response, err := s.rt.doPost(s.rt.conf.Elastic.Host+s.index+"/_search?ignore_unavailable=true", req)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(response, &res)
+	if err != nil {
+		return nil, err
+	}
+	var docs []storeDoc
+	for _, h := range res.Hits.Hits {
+		docs = append(docs, h.Source)
+	}
+	return docs, nil
+}
+
+func (s *esStore) SaveJob(j restoreJob) error {
+	return s.put("job-"+j.ID, storeDoc{Type: "job", Time: j.Started, Job: &j})
+}
+
+func (s *esStore) Jobs() ([]restoreJob, error) {
+	docs, err := s.search("job", 10000)
+	if err != nil {
+		return nil, err
+	}
+	var res []restoreJob
+	for _, d := range docs {
+		if d.Job != nil {
+			res = append(res, *d.Job)
+		}
+	}
+	return res, nil
+}
+
+func (s *esStore) AddEvent(e event) error {
+	return s.put("", storeDoc{Type: "event", Time: e.Time, Event: &e})
+}
+
+func (s *esStore) Events(limit int) ([]event, error) {
+	if limit <= 0 {
+		limit = 10000
+	}
+	docs, err := s.search("event", limit)
+	if err != nil {
+		return nil, err
+	}
+	var res []event
+	for _, d := range docs {
+		if d.Event != nil {
+			res = append(res, *d.Event)
+		}
+	}
+	return res, nil
+}
+
+func (s *esStore) SaveSchedule(sc schedule) error {
+	return s.put("schedule-"+sc.ID, storeDoc{Type: "schedule", Time: sc.Created, Schedule: &sc})
+}
+
+func (s *esStore) Schedules() ([]schedule, error) {
+	docs, err := s.search("schedule", 10000)
+	if err != nil {
+		return nil, err
+	}
+	var res []schedule
+	for _, d := range docs {
+		if d.Schedule != nil && !d.Schedule.Deleted {
+			res = append(res, *d.Schedule)
+		}
+	}
+	return res, nil
+}
+
+func (s *esStore) SaveCheck(c repoCheck) error {
+	return s.put("check-"+c.ID, storeDoc{Type: "check", Time: c.Started, Check: &c})
+}
+
+func (s *esStore) Checks() ([]repoCheck, error) {
+	docs, err := s.search("check", 10000)
+	if err != nil {
+		return nil, err
+	}
+	var res []repoCheck
+	for _, d := range docs {
+		if d.Check != nil {
+			res = append(res, *d.Check)
+		}
+	}
+	return res, nil
+}
+
+func (s *esStore) SavePin(p indexPin) error {
+	return s.put("pin-"+p.Index, storeDoc{Type: "pin", Time: time.Now(), Pin: &p})
+}
+
+func (s *esStore) Pins() ([]indexPin, error) {
+	docs, err := s.search("pin", 10000)
+	if err != nil {
+		return nil, err
+	}
+	var res []indexPin
+	for _, d := range docs {
+		if d.Pin != nil && !d.Pin.Removed {
+			res = append(res, *d.Pin)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,167 @@
+// Copyright © 2020 Uzhinskiy Boris
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fileLines - number of non-empty lines in the file
+func fileLines(t *testing.T, file string) int {
+	t.Helper()
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(bytes.Fields(b))
+}
+
+func TestFileStoreLastRecordWins(t *testing.T) {
+	dir := t.TempDir()
+	s, err := newFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, state := range []string{jobRunning, jobRunning, jobDone} {
+		if err := s.SaveJob(restoreJob{ID: "j1", Repo: "archive", State: state}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.SaveJob(restoreJob{ID: "j2", Repo: "archive", State: jobRunning})
+
+	jobs, err := s.Jobs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	states := make(map[string]string)
+	for _, j := range jobs {
+		states[j.ID] = j.State
+	}
+	if len(states) != 2 || states["j1"] != jobDone || states["j2"] != jobRunning {
+		t.Errorf("jobs %v, want j1 done and j2 running", states)
+	}
+	// загрузка сжимает файл до последних записей
+	if n := fileLines(t, filepath.Join(dir, "jobs.jsonl")); n != 2 {
+		t.Errorf("jobs.jsonl has %d lines after the load, want 2", n)
+	}
+	jobs, err = s.Jobs()
+	if err != nil || len(jobs) != 2 {
+		t.Errorf("jobs after compaction %v, %v", jobs, err)
+	}
+}
+
+func TestFileStoreReload(t *testing.T) {
+	dir := t.TempDir()
+	s, err := newFileStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SaveSchedule(schedule{ID: "s1", Name: "nightly", Repo: "archive"})
+	s.SaveSchedule(schedule{ID: "s2", Name: "weekly", Repo: "archive"})
+	s.SaveSchedule(schedule{ID: "s1", Name: "nightly", Repo: "archive", Deleted: true})
+	s.SavePin(indexPin{Index: "extracted_logs-a"})
+	s.SavePin(indexPin{Index: "extracted_logs-b"})
+	s.SavePin(indexPin{Index: "extracted_logs-a", Removed: true})
+	s.AddEvent(event{Time: time.Now(), Action: "restore"})
+	s.AddEvent(event{Time: time.Now(), Action: "restore"})
+
+	// два раза: при первой загрузке файлы сжимаются, удалённое не должно вернуться
+	for i := 0; i < 2; i++ {
+		s, err = newFileStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		schedules, err := s.Schedules()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(schedules) != 1 || schedules[0].ID != "s2" {
+			t.Errorf("load %d: schedules %v, want only s2", i, schedules)
+		}
+		pins, err := s.Pins()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pins) != 1 || pins[0].Index != "extracted_logs-b" {
+			t.Errorf("load %d: pins %v, want only extracted_logs-b", i, pins)
+		}
+	}
+	if n := fileLines(t, filepath.Join(dir, "schedules.jsonl")); n != 1 {
+		t.Errorf("schedules.jsonl has %d lines, want 1", n)
+	}
+	if n := fileLines(t, filepath.Join(dir, "pins.jsonl")); n != 1 {
+		t.Errorf("pins.jsonl has %d lines, want 1", n)
+	}
+	// события не сжимаются
+	if events, err := s.Events(0); err != nil || len(events) != 2 {
+		t.Errorf("events %v, %v, want both", events, err)
+	}
+}
+
+func TestESStore(t *testing.T) {
+	es := newFakeES(t, func(method, path string) (int, string) {
+		switch {
+		case method == http.MethodGet && path == "/extractor":
+			return http.StatusNotFound, `{"error":{"type":"index_not_found_exception"},"status":404}`
+		case strings.HasSuffix(path, "/_search"):
+			return http.StatusOK, `{"hits":{"hits":[
+				{"_source":{"type":"schedule","schedule":{"id":"s2","name":"weekly","repo":"archive"}}},
+				{"_source":{"type":"schedule","schedule":{"id":"s1","name":"nightly","repo":"archive","deleted":true}}}
+			]}}`
+		}
+		return http.StatusOK, `{"acknowledged":true,"result":"created"}`
+	})
+	rt := testRouter(t, "elastic:\n  host: "+es.srv.URL+"/\nstore:\n  type: elastic\n  index: extractor\n")
+
+	if err := rt.store.SaveSchedule(schedule{ID: "s2", Name: "weekly", Repo: "archive"}); err != nil {
+		t.Fatal(err)
+	}
+	schedules, err := rt.store.Schedules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(schedules) != 1 || schedules[0].ID != "s2" {
+		t.Errorf("schedules %v, want only s2", schedules)
+	}
+
+	var mapping, doc, search map[string]interface{}
+	for _, c := range es.requests() {
+		switch {
+		case c.Method == http.MethodPut && c.Path == "/extractor":
+			json.Unmarshal([]byte(c.Body), &mapping)
+		case c.Method == http.MethodPost && c.Path == "/extractor/_doc/schedule-s2":
+			json.Unmarshal([]byte(c.Body), &doc)
+		case c.Method == http.MethodPost && c.Path == "/extractor/_search":
+			json.Unmarshal([]byte(c.Body), &search)
+		}
+	}
+	m, _ := json.Marshal(mapping["mappings"])
+	if string(m) != `{"dynamic":false,"properties":{"@timestamp":{"type":"date"},"type":{"type":"keyword"}}}` {
+		t.Errorf("mappings %s", m)
+	}
+	if doc["type"] != "schedule" || doc["schedule"] == nil {
+		t.Errorf("saved document %v", doc)
+	}
+	q, _ := json.Marshal(search["query"])
+	if string(q) != `{"term":{"type":"schedule"}}` {
+		t.Errorf("search query %s", q)
+	}
+}